@@ -124,6 +124,30 @@ type ForwardAuthConfig struct {
 	ClientHeaders   []string `json:"client_headers,omitempty"`
 }
 
+// JwtAuthRouteConfig is the rule config for jwt-auth plugin
+// used in Route object.
+// +k8s:deepcopy-gen=true
+type JwtAuthRouteConfig struct {
+	Header string `json:"header,omitempty"`
+	Query  string `json:"query,omitempty"`
+	Cookie string `json:"cookie,omitempty"`
+}
+
+// HMACAuthRouteConfig is the rule config for hmac-auth plugin
+// used in Route object.
+// +k8s:deepcopy-gen=true
+type HMACAuthRouteConfig struct{}
+
+// OpenIDConnectConfig is the rule config for openid-connect plugin.
+// +k8s:deepcopy-gen=true
+type OpenIDConnectConfig struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	Discovery    string `json:"discovery"`
+	Scope        string `json:"scope,omitempty"`
+	BearerOnly   bool   `json:"bearer_only,omitempty"`
+}
+
 // BasicAuthConfig is the rule config for basic-auth plugin.
 // +k8s:deepcopy-gen=true
 type BasicAuthConfig struct {
@@ -133,3 +157,15 @@ type BasicAuthConfig struct {
 // +k8s:deepcopy-gen=true
 type KeyAuthConfig struct {
 }
+
+// ClientControlConfig is the rule config for client-control plugin.
+// +k8s:deepcopy-gen=true
+type ClientControlConfig struct {
+	MaxBodySize int64 `json:"max_body_size"`
+}
+
+// ProxyControlConfig is the rule config for proxy-control plugin.
+// +k8s:deepcopy-gen=true
+type ProxyControlConfig struct {
+	RequestBuffering bool `json:"request_buffering"`
+}
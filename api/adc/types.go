@@ -31,6 +31,7 @@ import (
 
 const (
 	TypeRoute          = "route"
+	TypeStreamRoute    = "stream_route"
 	TypeService        = "service"
 	TypeConsumer       = "consumer"
 	TypeSSL            = "ssl"
@@ -78,6 +79,190 @@ func (r *Resources) MarshalLog() any {
 	}
 }
 
+// Redacted is the value secrets are replaced with wherever resources are shown.
+const Redacted = "[REDACTED]"
+
+// Redacted returns a copy of the SSL with its private keys replaced, for showing outside
+// the data plane. The certificates themselves are public.
+func (s *SSL) Redacted() *SSL {
+	out := s.DeepCopy()
+	for i := range out.Certificates {
+		if out.Certificates[i].Key != "" {
+			out.Certificates[i].Key = Redacted
+		}
+	}
+	return out
+}
+
+// Redacted returns a copy of the consumer with the configuration of its credentials and
+// plugins replaced, for showing outside the data plane: auth plugins keep their secrets
+// there.
+func (c *Consumer) Redacted() *Consumer {
+	out := c.DeepCopy()
+	for i := range out.Credentials {
+		out.Credentials[i].Config = redactPlugins(out.Credentials[i].Config)
+	}
+	out.Plugins = redactPlugins(out.Plugins)
+	return out
+}
+
+func redactPlugins(plugins Plugins) Plugins {
+	if plugins == nil {
+		return nil
+	}
+	out := make(Plugins, len(plugins))
+	for name := range plugins {
+		out[name] = Redacted
+	}
+	return out
+}
+
+// Redacted returns a copy of the service, its upstreams and its routes with their
+// secrets replaced, see RedactPluginSecrets.
+func (s *Service) Redacted() *Service {
+	out := s.DeepCopy()
+	out.Plugins = RedactPluginSecrets(out.Plugins)
+	out.Upstream = out.Upstream.Redacted()
+	for i := range out.Upstreams {
+		out.Upstreams[i] = out.Upstreams[i].Redacted()
+	}
+	for i := range out.Routes {
+		out.Routes[i] = out.Routes[i].Redacted()
+	}
+	for i := range out.StreamRoutes {
+		out.StreamRoutes[i] = out.StreamRoutes[i].Redacted()
+	}
+	return out
+}
+
+// Redacted returns a copy of the upstream with the private key it presents to the
+// backends replaced.
+func (u *Upstream) Redacted() *Upstream {
+	if u == nil {
+		return nil
+	}
+	out := u.DeepCopy()
+	if out.TLS != nil && out.TLS.Key != "" {
+		out.TLS.Key = Redacted
+	}
+	return out
+}
+
+// Redacted returns a copy of the route with the secrets in its plugins replaced.
+func (r *Route) Redacted() *Route {
+	out := r.DeepCopy()
+	out.Plugins = RedactPluginSecrets(out.Plugins)
+	return out
+}
+
+// Redacted returns a copy of the stream route with the secrets in its plugins replaced.
+func (r *StreamRoute) Redacted() *StreamRoute {
+	out := r.DeepCopy()
+	out.Plugins = RedactPluginSecrets(out.Plugins)
+	return out
+}
+
+// Redacted returns a copy of the resources with every secret they hold replaced, for
+// showing or writing them anywhere but the data plane.
+func (r *Resources) Redacted() *Resources {
+	if r == nil {
+		return nil
+	}
+	out := &Resources{
+		ConsumerGroups: make([]*ConsumerGroup, 0, len(r.ConsumerGroups)),
+		Consumers:      make([]*Consumer, 0, len(r.Consumers)),
+		GlobalRules:    GlobalRule(RedactPluginSecrets(Plugins(r.GlobalRules))),
+		PluginMetadata: r.PluginMetadata.Redacted(),
+		Services:       make([]*Service, 0, len(r.Services)),
+		SSLs:           make([]*SSL, 0, len(r.SSLs)),
+	}
+	for _, group := range r.ConsumerGroups {
+		group = group.DeepCopy()
+		group.Plugins = redactPlugins(group.Plugins)
+		for i := range group.Consumers {
+			group.Consumers[i] = *group.Consumers[i].Redacted()
+		}
+		out.ConsumerGroups = append(out.ConsumerGroups, group)
+	}
+	for _, consumer := range r.Consumers {
+		out.Consumers = append(out.Consumers, consumer.Redacted())
+	}
+	for _, svc := range r.Services {
+		out.Services = append(out.Services, svc.Redacted())
+	}
+	for _, ssl := range r.SSLs {
+		out.SSLs = append(out.SSLs, ssl.Redacted())
+	}
+	return out
+}
+
+// RedactPluginSecrets returns a copy of plugins in which every field whose name marks it
+// as a secret -- client_secret, private_key, password, an Authorization header and the
+// like -- is replaced, however deep in a plugin's configuration it sits. Unlike the
+// credentials of a consumer, which are secrets through and through, the rest of a
+// plugin's configuration is kept for debugging.
+func RedactPluginSecrets(plugins Plugins) Plugins {
+	if plugins == nil {
+		return nil
+	}
+	out := make(Plugins, len(plugins))
+	for name, config := range plugins {
+		// A round trip through JSON turns typed configurations into maps that can be
+		// walked, and is what the data plane is sent anyway.
+		data, err := json.Marshal(config)
+		if err != nil {
+			out[name] = Redacted
+			continue
+		}
+		var generic any
+		if err := json.Unmarshal(data, &generic); err != nil {
+			out[name] = Redacted
+			continue
+		}
+		out[name] = redactSecretFields(generic)
+	}
+	return out
+}
+
+func redactSecretFields(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if value != nil && isSecretField(key) {
+				v[key] = Redacted
+				continue
+			}
+			v[key] = redactSecretFields(value)
+		}
+	case []any:
+		for i := range v {
+			v[i] = redactSecretFields(v[i])
+		}
+	}
+	return v
+}
+
+var (
+	secretFieldWords    = []string{"secret", "password", "passwd", "authorization", "cookie", "credential"}
+	secretFieldSuffixes = []string{"key", "keys", "token", "tokens"}
+)
+
+// isSecretField reports whether a configuration field named name holds a secret.
+func isSecretField(name string) bool {
+	name = strings.ToLower(name)
+	for _, word := range secretFieldWords {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	for _, suffix := range secretFieldSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
 type GlobalRule Plugins
 
 func (g *GlobalRule) DeepCopy() GlobalRule {
@@ -93,6 +278,14 @@ type GlobalRuleItem struct {
 	Plugins Plugins `json:"plugins" yaml:"plugins"`
 }
 
+// Redacted returns a copy of the global rule item with its secrets replaced, see
+// RedactPluginSecrets.
+func (g *GlobalRuleItem) Redacted() *GlobalRuleItem {
+	out := g.DeepCopy()
+	out.Plugins = RedactPluginSecrets(out.Plugins)
+	return out
+}
+
 type PluginMetadata Plugins
 
 func (p *PluginMetadata) DeepCopy() PluginMetadata {
@@ -101,6 +294,12 @@ func (p *PluginMetadata) DeepCopy() PluginMetadata {
 	return PluginMetadata(copied)
 }
 
+// Redacted returns a copy of the plugin metadata with its secrets replaced, see
+// RedactPluginSecrets.
+func (p PluginMetadata) Redacted() PluginMetadata {
+	return PluginMetadata(RedactPluginSecrets(Plugins(p)))
+}
+
 // +k8s:deepcopy-gen=true
 type ConsumerGroup struct {
 	Metadata  `json:",inline" yaml:",inline"`
@@ -806,6 +1005,9 @@ type Config struct {
 	Token       string
 	TlsVerify   bool
 	BackendType string
+	// Executor selects how the configuration is synced, one of the config.ProviderExecutor values.
+	// Empty means the controller-wide default.
+	Executor string
 
 	// BypassCache makes the ADC server drop the in-memory baseline it holds for this
 	// cacheKey and re-derive it from the data plane before computing the diff. It is a
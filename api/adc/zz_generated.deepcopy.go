@@ -121,6 +121,21 @@ func (in *ClientClass) DeepCopy() *ClientClass {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientControlConfig) DeepCopyInto(out *ClientControlConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientControlConfig.
+func (in *ClientControlConfig) DeepCopy() *ClientControlConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientControlConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClientTLS) DeepCopyInto(out *ClientTLS) {
 	*out = *in
@@ -283,6 +298,21 @@ func (in *HMACAuthConsumerConfig) DeepCopy() *HMACAuthConsumerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HMACAuthRouteConfig) DeepCopyInto(out *HMACAuthRouteConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HMACAuthRouteConfig.
+func (in *HMACAuthRouteConfig) DeepCopy() *HMACAuthRouteConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HMACAuthRouteConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPRestrictConfig) DeepCopyInto(out *IPRestrictConfig) {
 	*out = *in
@@ -323,6 +353,21 @@ func (in *JwtAuthConsumerConfig) DeepCopy() *JwtAuthConsumerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JwtAuthRouteConfig) DeepCopyInto(out *JwtAuthRouteConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JwtAuthRouteConfig.
+func (in *JwtAuthRouteConfig) DeepCopy() *JwtAuthRouteConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(JwtAuthRouteConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeyAuthConfig) DeepCopyInto(out *KeyAuthConfig) {
 	*out = *in
@@ -390,6 +435,36 @@ func (in *Metadata) DeepCopy() *Metadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenIDConnectConfig) DeepCopyInto(out *OpenIDConnectConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenIDConnectConfig.
+func (in *OpenIDConnectConfig) DeepCopy() *OpenIDConnectConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenIDConnectConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyControlConfig) DeepCopyInto(out *ProxyControlConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyControlConfig.
+func (in *ProxyControlConfig) DeepCopy() *ProxyControlConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyControlConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Route) DeepCopyInto(out *Route) {
 	*out = *in
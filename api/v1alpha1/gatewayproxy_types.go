@@ -41,6 +41,35 @@ type GatewayProxySpec struct {
 	Plugins []GatewayProxyPlugin `json:"plugins,omitempty"`
 	// PluginMetadata configures common configuration shared by all plugin instances of the same name.
 	PluginMetadata map[string]apiextensionsv1.JSON `json:"pluginMetadata,omitempty"`
+	// Ingress configures how Ingress resources of the IngressClasses referencing this GatewayProxy are translated.
+	// +optional
+	Ingress *GatewayProxyIngress `json:"ingress,omitempty"`
+}
+
+// ImplementationSpecificPathType defines how Ingress paths with `pathType: ImplementationSpecific` are matched.
+// +kubebuilder:validation:Enum=Exact;Prefix;RegularExpression;NginxPrefix
+type ImplementationSpecificPathType string
+
+const (
+	// ImplementationSpecificPathTypeExact matches the path exactly.
+	ImplementationSpecificPathTypeExact ImplementationSpecificPathType = "Exact"
+	// ImplementationSpecificPathTypePrefix matches the path by path elements, like `pathType: Prefix`.
+	ImplementationSpecificPathTypePrefix ImplementationSpecificPathType = "Prefix"
+	// ImplementationSpecificPathTypeRegularExpression matches the path as a regular expression.
+	ImplementationSpecificPathTypeRegularExpression ImplementationSpecificPathType = "RegularExpression"
+	// ImplementationSpecificPathTypeNginxPrefix matches the path as a plain string prefix, like an NGINX
+	// prefix location. A path containing regular expression characters is matched as a case-insensitive
+	// regular expression anchored at the beginning of the request path instead.
+	ImplementationSpecificPathTypeNginxPrefix ImplementationSpecificPathType = "NginxPrefix"
+)
+
+// GatewayProxyIngress defines the settings applied to Ingress resources.
+type GatewayProxyIngress struct {
+	// ImplementationSpecificPathType specifies how paths with `pathType: ImplementationSpecific` are matched.
+	// Can be `Exact`, `Prefix`, `RegularExpression` or `NginxPrefix`, defaults to `Exact`.
+	// The `k8s.apisix.apache.org/use-regex` annotation of an Ingress takes precedence.
+	// +optional
+	ImplementationSpecificPathType ImplementationSpecificPathType `json:"implementationSpecificPathType,omitempty"`
 }
 
 // ProviderType defines the type of provider.
@@ -122,10 +151,20 @@ type ControlPlaneAuth struct {
 // +kubebuilder:validation:XValidation:rule="oldSelf == null || (!has(self.mode) && !has(oldSelf.mode)) || self.mode == oldSelf.mode",message="mode is immutable"
 type ControlPlaneProvider struct {
 	// Mode specifies the mode of control plane provider.
-	// Can be `apisix` or `apisix-standalone`.
+	// Can be `apisix` or `apisix-standalone`. With `apisix-standalone`, the configuration
+	// is pushed to every endpoint of the service rather than to the service itself.
+	// Defaults to the controller's `provider.type` setting.
 	//
 	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=apisix;apisix-standalone
 	Mode string `json:"mode,omitempty"`
+	// Executor specifies how the configuration is written to the control plane.
+	// `adc` syncs through the ADC server, `native` talks to the APISIX Admin API
+	// directly. Defaults to the controller's `provider.executor` setting.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=adc;native
+	Executor string `json:"executor,omitempty"`
 	// Endpoints specifies the list of control plane endpoints.
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:MinItems=1
@@ -152,6 +191,7 @@ type ProviderService struct {
 }
 
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
 // GatewayProxy defines configuration for the gateway proxy instances used to route traffic to services.
 type GatewayProxy struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -159,9 +199,76 @@ type GatewayProxy struct {
 
 	// GatewayProxySpec defines configuration of gateway proxy instances,
 	// including networking settings, global plugins, and plugin metadata.
-	Spec GatewayProxySpec `json:"spec,omitempty"`
+	Spec   GatewayProxySpec   `json:"spec,omitempty"`
+	Status GatewayProxyStatus `json:"status,omitempty"`
+}
+
+// GatewayProxyStatus reports the state of the data plane a GatewayProxy configures.
+type GatewayProxyStatus struct {
+	Status `json:",inline"`
+
+	// Endpoints reports how syncing to each control plane endpoint is going.
+	// +optional
+	Endpoints []GatewayProxyEndpointStatus `json:"endpoints,omitempty"`
 }
 
+// GatewayProxyEndpointStatus reports how syncing to one control plane endpoint is going.
+type GatewayProxyEndpointStatus struct {
+	// Address is the endpoint's Admin API address.
+	Address string `json:"address"`
+	// State is Healthy when the endpoint accepted the last sync, Unhealthy when it did not,
+	// and CircuitOpen when it failed often enough in a row to be left out of syncs until it
+	// is caught up in the background.
+	// +kubebuilder:validation:Enum=Healthy;Unhealthy;CircuitOpen
+	State string `json:"state"`
+	// SyncedVersion is the version of the configuration the endpoint last accepted. An
+	// endpoint behind the others holds a lower one.
+	// +optional
+	SyncedVersion int64 `json:"syncedVersion,omitempty"`
+	// Message is the error of the last sync the endpoint did not accept.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is when State last changed.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+const (
+	// GatewayProxyConditionDataPlaneInSync reports whether what the data plane holds matches
+	// what the controller last computed for it, as the drift detector last found it.
+	GatewayProxyConditionDataPlaneInSync = "DataPlaneInSync"
+
+	// GatewayProxyReasonInSync is used with the DataPlaneInSync condition when every
+	// endpoint holds what the controller computed.
+	GatewayProxyReasonInSync = "InSync"
+	// GatewayProxyReasonDrifted is used with the DataPlaneInSync condition when an endpoint
+	// has resources missing, extra or modified.
+	GatewayProxyReasonDrifted = "Drifted"
+	// GatewayProxyReasonDriftCheckFailed is used with the DataPlaneInSync condition when
+	// an endpoint could not be read back.
+	GatewayProxyReasonDriftCheckFailed = "DriftCheckFailed"
+
+	// GatewayProxyConditionConfigWritten reports whether the file provider wrote out the
+	// configuration last computed for the GatewayProxy, where, and with what content hash.
+	GatewayProxyConditionConfigWritten = "ConfigWritten"
+
+	// GatewayProxyReasonWritten is used with the ConfigWritten condition when the file
+	// holds the configuration last computed.
+	GatewayProxyReasonWritten = "Written"
+	// GatewayProxyReasonWriteFailed is used with the ConfigWritten condition when the
+	// configuration could not be written.
+	GatewayProxyReasonWriteFailed = "WriteFailed"
+
+	// GatewayProxyEndpointHealthy is the state of an endpoint that accepted the last sync.
+	GatewayProxyEndpointHealthy = "Healthy"
+	// GatewayProxyEndpointUnhealthy is the state of an endpoint that did not accept the
+	// last sync.
+	GatewayProxyEndpointUnhealthy = "Unhealthy"
+	// GatewayProxyEndpointCircuitOpen is the state of an endpoint left out of syncs until
+	// it is caught up in the background.
+	GatewayProxyEndpointCircuitOpen = "CircuitOpen"
+)
+
 // +kubebuilder:object:root=true
 // GatewayProxyList contains a list of GatewayProxy.
 type GatewayProxyList struct {
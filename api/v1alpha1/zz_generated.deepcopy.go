@@ -441,6 +441,7 @@ func (in *GatewayProxy) DeepCopyInto(out *GatewayProxy) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayProxy.
@@ -461,6 +462,40 @@ func (in *GatewayProxy) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayProxyIngress) DeepCopyInto(out *GatewayProxyIngress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayProxyIngress.
+func (in *GatewayProxyIngress) DeepCopy() *GatewayProxyIngress {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayProxyIngress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayProxyEndpointStatus) DeepCopyInto(out *GatewayProxyEndpointStatus) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayProxyEndpointStatus.
+func (in *GatewayProxyEndpointStatus) DeepCopy() *GatewayProxyEndpointStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayProxyEndpointStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GatewayProxyList) DeepCopyInto(out *GatewayProxyList) {
 	*out = *in
@@ -556,6 +591,11 @@ func (in *GatewayProxySpec) DeepCopyInto(out *GatewayProxySpec) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(GatewayProxyIngress)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayProxySpec.
@@ -568,6 +608,29 @@ func (in *GatewayProxySpec) DeepCopy() *GatewayProxySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayProxyStatus) DeepCopyInto(out *GatewayProxyStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]GatewayProxyEndpointStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayProxyStatus.
+func (in *GatewayProxyStatus) DeepCopy() *GatewayProxyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayProxyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GatewayRef) DeepCopyInto(out *GatewayRef) {
 	*out = *in
@@ -17,8 +17,31 @@
 
 package v2
 
+// Reason is the reason of an Event the controller reports on a resource.
 type Reason string
 
 const (
+	// ReasonSyncFailed is reported when the data plane rejected what the resource was
+	// translated to.
 	ReasonSyncFailed Reason = "SyncFailed"
+	// ReasonTranslationFailed is reported when the resource could not be translated.
+	ReasonTranslationFailed Reason = "TranslationFailed"
+	// ReasonUnresolvedReference is reported when an object the resource refers to, such
+	// as a backend Service or a Secret, cannot be found or is not permitted.
+	ReasonUnresolvedReference Reason = "UnresolvedReference"
+	// ReasonPolicyConflict is reported when the policies attached to the resource
+	// conflict, so that one of them is not applied.
+	ReasonPolicyConflict Reason = "PolicyConflict"
+	// ReasonRouteConflict is reported when a route of the resource shadows, or is
+	// shadowed by, a route of another resource.
+	ReasonRouteConflict Reason = "RouteConflict"
+	// ReasonDefaultBackendConflict is reported when the default backend of an Ingress is
+	// not applied, as another Ingress of the same class owns it.
+	ReasonDefaultBackendConflict Reason = "DefaultBackendConflict"
+	// ReasonInvalidAnnotation is reported when an invalid annotation of an Ingress is
+	// ignored, rather than failing its translation.
+	ReasonInvalidAnnotation Reason = "InvalidAnnotation"
+	// ReasonProgrammed is reported when a generation of the resource reached the data
+	// plane for the first time.
+	ReasonProgrammed Reason = "Programmed"
 )
@@ -35,6 +35,20 @@ const (
 	ConditionReasonAccepted    ApisixRouteConditionReason = gatewayv1.RouteReasonAccepted
 	ConditionReasonInvalidSpec ApisixRouteConditionReason = "InvalidSpec"
 	ConditionReasonSyncFailed  ApisixRouteConditionReason = "SyncFailed"
+
+	// ConditionTypeProgrammed is true once the current generation of the object was pushed
+	// to every endpoint of the data plane of every GatewayProxy it belongs to, and false
+	// while it is waiting to be, only some endpoints took it, or the push failed.
+	ConditionTypeProgrammed            ApisixRouteConditionType   = "Programmed"
+	ConditionReasonProgrammed          ApisixRouteConditionReason = "Programmed"
+	ConditionReasonPending             ApisixRouteConditionReason = "Pending"
+	ConditionReasonPartiallyProgrammed ApisixRouteConditionReason = "PartiallyProgrammed"
+
+	// ConditionTypeConflicted is true when a route of the object matches the same
+	// requests as a route of another object, so that one of them is shadowed.
+	ConditionTypeConflicted      ApisixRouteConditionType   = "Conflicted"
+	ConditionReasonRouteConflict ApisixRouteConditionReason = "RouteConflict"
+	ConditionReasonNoConflict    ApisixRouteConditionReason = "NoConflict"
 )
 
 const (
@@ -33,6 +33,10 @@ type Cache interface {
 	InsertConsumer(*types.Consumer) error
 	// InsertGlobalRule adds or updates global rule to cache.
 	InsertGlobalRule(*types.GlobalRuleItem) error
+	// InsertRoute adds or updates route in the service with the given id.
+	InsertRoute(string, *types.Route) error
+	// InsertStreamRoute adds or updates stream route in the service with the given id.
+	InsertStreamRoute(string, *types.StreamRoute) error
 
 	// GetSSL finds the ssl from cache according to the primary index (id).
 	GetSSL(string) (*types.SSL, error)
@@ -42,6 +46,12 @@ type Cache interface {
 	GetConsumer(string) (*types.Consumer, error)
 	// GetGlobalRule finds the global rule from cache according to the primary index (id).
 	GetGlobalRule(string) (*types.GlobalRuleItem, error)
+	// GetRoute finds the route from cache according to the primary index (id).
+	GetRoute(string) (*types.Route, error)
+	// GetStreamRoute finds the stream route from cache according to the primary index (id).
+	GetStreamRoute(string) (*types.StreamRoute, error)
+	// ServiceOf returns the id of the service the route or stream route with id belongs to.
+	ServiceOf(resourceType, id string) (string, error)
 
 	// DeleteSSL deletes the specified ssl in cache.
 	DeleteSSL(*types.SSL) error
@@ -51,6 +61,10 @@ type Cache interface {
 	DeleteConsumer(*types.Consumer) error
 	// DeleteGlobalRule deletes the specified global rule in cache.
 	DeleteGlobalRule(*types.GlobalRuleItem) error
+	// DeleteRoute deletes the specified route from the service that holds it.
+	DeleteRoute(*types.Route) error
+	// DeleteStreamRoute deletes the specified stream route from the service that holds it.
+	DeleteStreamRoute(*types.StreamRoute) error
 
 	// ListSSL lists all ssl objects in cache.
 	ListSSL(...ListOption) ([]*types.SSL, error)
@@ -60,6 +74,10 @@ type Cache interface {
 	ListConsumers(...ListOption) ([]*types.Consumer, error)
 	// ListGlobalRules lists all global rule objects in cache.
 	ListGlobalRules(...ListOption) ([]*types.GlobalRuleItem, error)
+	// ListRoutes lists the routes of all services in cache.
+	ListRoutes(...ListOption) ([]*types.Route, error)
+	// ListStreamRoutes lists the stream routes of all services in cache.
+	ListStreamRoutes(...ListOption) ([]*types.StreamRoute, error)
 }
 
 type ListOption interface {
@@ -67,13 +85,29 @@ type ListOption interface {
 }
 
 type ListOptions struct {
-	KindLabelSelector *KindLabelSelector
+	KindLabelSelector   *KindLabelSelector
+	PolicyLabelSelector *PolicyLabelSelector
+	HostSelector        *HostSelector
+	URISelector         *URISelector
+	SNISelector         *SNISelector
 }
 
 func (o *ListOptions) ApplyToList(lo *ListOptions) {
 	if o.KindLabelSelector != nil {
 		lo.KindLabelSelector = o.KindLabelSelector
 	}
+	if o.PolicyLabelSelector != nil {
+		lo.PolicyLabelSelector = o.PolicyLabelSelector
+	}
+	if o.HostSelector != nil {
+		lo.HostSelector = o.HostSelector
+	}
+	if o.URISelector != nil {
+		lo.URISelector = o.URISelector
+	}
+	if o.SNISelector != nil {
+		lo.SNISelector = o.SNISelector
+	}
 }
 
 func (o *ListOptions) ApplyOptions(opts []ListOption) *ListOptions {
@@ -92,3 +126,46 @@ type KindLabelSelector struct {
 func (o *KindLabelSelector) ApplyToList(opts *ListOptions) {
 	opts.KindLabelSelector = o
 }
+
+// PolicyLabelSelector selects the services a policy shaped, themselves or through one of
+// their routes. Only services can be listed by it.
+type PolicyLabelSelector struct {
+	Kind      string
+	Name      string
+	Namespace string
+}
+
+func (o *PolicyLabelSelector) ApplyToList(opts *ListOptions) {
+	opts.PolicyLabelSelector = o
+}
+
+// HostSelector selects the routes served on Host, ignoring case: those listing it, or,
+// if they list no hosts, whose service does. An empty Host selects the routes served on
+// any host. Only routes can be listed by it.
+type HostSelector struct {
+	Host string
+}
+
+func (o *HostSelector) ApplyToList(opts *ListOptions) {
+	opts.HostSelector = o
+}
+
+// URISelector selects the routes listing URI as it is, so a prefix such as "/api/*" only
+// selects routes listing that prefix. Only routes can be listed by it.
+type URISelector struct {
+	URI string
+}
+
+func (o *URISelector) ApplyToList(opts *ListOptions) {
+	opts.URISelector = o
+}
+
+// SNISelector selects the stream routes matching SNI, ignoring case. Only stream routes
+// can be listed by it.
+type SNISelector struct {
+	SNI string
+}
+
+func (o *SNISelector) ApplyToList(opts *ListOptions) {
+	opts.SNISelector = o
+}
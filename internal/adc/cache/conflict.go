@@ -0,0 +1,269 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cache
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+)
+
+// RouteConflict is a pair of routes of one config, translated from different objects,
+// that APISIX matches alike for some requests: requests to Host, for URI, with one of
+// Methods. Only the Winner is ever routed those requests, the Loser is shadowed.
+//
+// Routes on a more specific host or URI than another are not in conflict, APISIX tries
+// them first whatever their priority. Neither are routes whose vars, remote addresses
+// or filter functions differ, unless only one of them has any: it is shadowed if it
+// does not win.
+type RouteConflict struct {
+	Config string `json:"config"`
+	// Host is empty for routes matching any host.
+	Host string `json:"host,omitempty"`
+	URI  string `json:"uri"`
+	// Methods is empty when the routes share every method.
+	Methods []string `json:"methods,omitempty"`
+
+	Winner ConflictingRoute `json:"winner"`
+	Loser  ConflictingRoute `json:"loser"`
+	// Reason says why the winner wins.
+	Reason string `json:"reason"`
+}
+
+// ConflictingRoute is one route of a RouteConflict.
+type ConflictingRoute struct {
+	ID       string `json:"id"`
+	Name     string `json:"name,omitempty"`
+	Owner    Owner  `json:"owner"`
+	Priority int64  `json:"priority"`
+}
+
+// String explains the conflict in a sentence.
+func (c RouteConflict) String() string {
+	match := c.URI
+	if c.Host != "" {
+		match = c.Host + c.URI
+	}
+	if len(c.Methods) > 0 {
+		match = strings.Join(c.Methods, ",") + " " + match
+	}
+	return fmt.Sprintf("%s route %s of %s shadows route %s of %s for %s: %s",
+		c.Config, c.Winner.ID, c.Winner.Owner, c.Loser.ID, c.Loser.Owner, match, c.Reason)
+}
+
+// Involves tells whether one of the routes of the conflict was translated from obj.
+func (c RouteConflict) Involves(obj Owner) bool {
+	return c.Winner.Owner == obj || c.Loser.Owner == obj
+}
+
+// RouteConflicts returns the conflicts among the routes of the config with name, in the
+// order of the routes shadowed. A config the store holds nothing for has none.
+func (s *Store) RouteConflicts(name string) ([]RouteConflict, error) {
+	s.Lock()
+	defer s.Unlock()
+	targetCache, ok := s.cacheMap[name]
+	if !ok {
+		return nil, nil
+	}
+	services, err := targetCache.ListServices()
+	if err != nil {
+		return nil, err
+	}
+	return findRouteConflicts(name, targetCache, services, Owner{})
+}
+
+// OwnerRouteConflicts returns the conflicts the routes the config with name holds for
+// owners have with any other route of it: those among the routes of every other owner
+// stay as they were. A config the store holds nothing for has none.
+func (s *Store) OwnerRouteConflicts(name string, owners []Owner) ([]RouteConflict, error) {
+	s.Lock()
+	defer s.Unlock()
+	targetCache, ok := s.cacheMap[name]
+	if !ok {
+		return nil, nil
+	}
+	var services []*adctypes.Service
+	for _, owner := range owners {
+		found, err := targetCache.ListServices(&KindLabelSelector{Kind: owner.Kind, Namespace: owner.Namespace, Name: owner.Name})
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, found...)
+	}
+	return findRouteConflicts(name, targetCache, services, Owner{})
+}
+
+// CheckRouteConflicts returns the conflicts that the routes of services, translated
+// from the object labels name, would have with the routes the config with name holds
+// for other objects. It tells whether the store holds the config at all.
+func (s *Store) CheckRouteConflicts(name string, services []*adctypes.Service, labels map[string]string) ([]RouteConflict, bool, error) {
+	s.Lock()
+	defer s.Unlock()
+	targetCache, ok := s.cacheMap[name]
+	if !ok {
+		return nil, false, nil
+	}
+	conflicts, err := findRouteConflicts(name, targetCache, services, ownerOf(labels))
+	return conflicts, true, err
+}
+
+// findRouteConflicts looks up, in c, the routes conflicting with those of services. The
+// routes of services are taken to be translated from owner, unless it is empty. Routes
+// of the same object never conflict: the object orders them itself.
+func findRouteConflicts(name string, c Cache, services []*adctypes.Service, owner Owner) ([]RouteConflict, error) {
+	type pair struct{ winner, loser string }
+	seen := make(map[pair]bool)
+	var conflicts []RouteConflict
+	for _, service := range services {
+		for _, route := range service.Routes {
+			routeOwner := owner
+			if routeOwner == (Owner{}) {
+				routeOwner = ownerOf(route.Labels)
+			}
+			hosts := route.Hosts
+			if len(hosts) == 0 {
+				hosts = service.Hosts
+			}
+			if len(hosts) == 0 {
+				hosts = []string{""}
+			}
+			for _, host := range hosts {
+				for _, uri := range route.Uris {
+					others, err := c.ListRoutes(&HostSelector{Host: host}, &URISelector{URI: uri})
+					if err != nil {
+						return nil, err
+					}
+					for _, other := range others {
+						if other.ID == route.ID || ownerOf(other.Labels) == routeOwner {
+							continue
+						}
+						conflict, ok := routeConflict(route, routeOwner, other)
+						if !ok {
+							continue
+						}
+						key := pair{conflict.Winner.ID, conflict.Loser.ID}
+						if seen[key] {
+							continue
+						}
+						seen[key] = true
+						conflict.Config = name
+						conflict.Host = strings.ToLower(host)
+						conflict.URI = uri
+						conflicts = append(conflicts, conflict)
+					}
+				}
+			}
+		}
+	}
+	slices.SortFunc(conflicts, func(a, b RouteConflict) int {
+		return cmp.Or(strings.Compare(a.Loser.ID, b.Loser.ID), strings.Compare(a.Winner.ID, b.Winner.ID))
+	})
+	return conflicts, nil
+}
+
+// routeConflict tells whether a, translated from owner, and b, which share a host and
+// a URI, conflict, and if so which wins. Host, URI and Config are left to the caller.
+func routeConflict(a *adctypes.Route, owner Owner, b *adctypes.Route) (RouteConflict, bool) {
+	methods, ok := sharedMethods(a.Methods, b.Methods)
+	if !ok {
+		return RouteConflict{}, false
+	}
+	aConditional, bConditional := hasConditions(a), hasConditions(b)
+	if aConditional && bConditional && !sameConditions(a, b) {
+		return RouteConflict{}, false
+	}
+	// Only one has conditions: which one APISIX tries first decides.
+	conditional := aConditional != bConditional
+
+	winner := ConflictingRoute{ID: a.ID, Name: a.Name, Owner: owner, Priority: priorityOf(a)}
+	loser := ConflictingRoute{ID: b.ID, Name: b.Name, Owner: ownerOf(b.Labels), Priority: priorityOf(b)}
+	winnerConditional := aConditional
+	switch {
+	case winner.Priority != loser.Priority:
+		if winner.Priority < loser.Priority {
+			winner, loser, winnerConditional = loser, winner, bConditional
+		}
+	case conditional:
+		// Report the route without conditions, which may be tried first, as winning.
+		if aConditional {
+			winner, loser, winnerConditional = loser, winner, bConditional
+		}
+	case winner.ID > loser.ID:
+		winner, loser = loser, winner
+	}
+
+	var reason string
+	switch {
+	case winner.Priority > loser.Priority && conditional && winnerConditional:
+		// A route with conditions tried first only takes the requests they match, and
+		// leaves the rest to the other: that is what the priority is there for.
+		return RouteConflict{}, false
+	case winner.Priority > loser.Priority && conditional:
+		reason = fmt.Sprintf("its priority is higher (%d > %d) and it has no conditions, "+
+			"so the conditions of the other are never tried", winner.Priority, loser.Priority)
+	case winner.Priority > loser.Priority:
+		reason = fmt.Sprintf("its priority is higher (%d > %d)", winner.Priority, loser.Priority)
+	case conditional:
+		reason = fmt.Sprintf("both have priority %d, so APISIX may try it first, and it has no conditions; "+
+			"raise the priority of the other to have its conditions tried", winner.Priority)
+	default:
+		reason = fmt.Sprintf("both have priority %d, so which one APISIX picks is undefined; "+
+			"set a priority to choose", winner.Priority)
+	}
+	return RouteConflict{Methods: methods, Winner: winner, Loser: loser, Reason: reason}, true
+}
+
+// sharedMethods returns the methods both a and b match, which is every method when
+// neither lists any, and whether they share any.
+func sharedMethods(a, b []string) ([]string, bool) {
+	if len(a) == 0 {
+		return slices.Clone(b), true
+	}
+	if len(b) == 0 {
+		return slices.Clone(a), true
+	}
+	var shared []string
+	for _, method := range a {
+		if slices.ContainsFunc(b, func(other string) bool { return strings.EqualFold(method, other) }) {
+			shared = append(shared, strings.ToUpper(method))
+		}
+	}
+	return shared, len(shared) > 0
+}
+
+// hasConditions tells whether r matches requests on more than their host, URI and method.
+func hasConditions(r *adctypes.Route) bool {
+	return len(r.Vars) > 0 || len(r.RemoteAddrs) > 0 || r.FilterFunc != ""
+}
+
+func sameConditions(a, b *adctypes.Route) bool {
+	return reflect.DeepEqual(a.Vars, b.Vars) &&
+		slices.Equal(a.RemoteAddrs, b.RemoteAddrs) &&
+		a.FilterFunc == b.FilterFunc
+}
+
+func priorityOf(r *adctypes.Route) int64 {
+	if r.Priority == nil {
+		return 0
+	}
+	return *r.Priority
+}
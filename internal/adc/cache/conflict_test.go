@@ -0,0 +1,139 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/controller/label"
+)
+
+func routeService(kind, name string, hosts []string, routes ...*adctypes.Route) (*adctypes.Resources, map[string]string) {
+	labels := map[string]string{label.LabelKind: kind, label.LabelNamespace: "default", label.LabelName: name}
+	for _, route := range routes {
+		route.Labels = labels
+	}
+	return &adctypes.Resources{Services: []*adctypes.Service{{
+		Metadata: adctypes.Metadata{ID: name, Labels: labels},
+		Hosts:    hosts,
+		Routes:   routes,
+	}}}, labels
+}
+
+func insertRouteService(t *testing.T, s *Store, kind, name string, hosts []string, routes ...*adctypes.Route) {
+	t.Helper()
+	resources, labels := routeService(kind, name, hosts, routes...)
+	require.NoError(t, s.Insert("gp", []string{adctypes.TypeService}, resources, labels))
+}
+
+func TestRouteConflicts(t *testing.T) {
+	s := NewStore(logr.Discard())
+	insertRouteService(t, s, "Ingress", "team-a", []string{"api.example.com"},
+		&adctypes.Route{Metadata: adctypes.Metadata{ID: "a-v1"}, Uris: []string{"/v1"}})
+	insertRouteService(t, s, "HTTPRoute", "team-b", nil,
+		&adctypes.Route{Metadata: adctypes.Metadata{ID: "b-v1"}, Hosts: []string{"API.example.com"}, Uris: []string{"/v1"},
+			Methods: []string{"GET", "POST"}, Priority: ptr.To[int64](10)},
+		&adctypes.Route{Metadata: adctypes.Metadata{ID: "b-v2"}, Hosts: []string{"api.example.com"}, Uris: []string{"/v2"}})
+	insertRouteService(t, s, "ApisixRoute", "team-c", []string{"api.example.com"},
+		&adctypes.Route{Metadata: adctypes.Metadata{ID: "c-v1"}, Uris: []string{"/v1"}, Methods: []string{"DELETE"}},
+		&adctypes.Route{Metadata: adctypes.Metadata{ID: "c-v2"}, Uris: []string{"/v2"},
+			Vars: adctypes.Vars{{{StrVal: "http_x_canary"}, {StrVal: "=="}, {StrVal: "true"}}}})
+
+	conflicts, err := s.RouteConflicts("gp")
+	require.NoError(t, err)
+	require.Len(t, conflicts, 3)
+
+	assert.Equal(t, "api.example.com", conflicts[0].Host)
+	assert.Equal(t, "/v1", conflicts[0].URI)
+	assert.Equal(t, []string{"GET", "POST"}, conflicts[0].Methods)
+	assert.Equal(t, "b-v1", conflicts[0].Winner.ID)
+	assert.Equal(t, Owner{Kind: "Ingress", Namespace: "default", Name: "team-a"}, conflicts[0].Loser.Owner)
+	assert.Equal(t, "its priority is higher (10 > 0)", conflicts[0].Reason)
+
+	assert.Equal(t, "a-v1", conflicts[1].Winner.ID, "a route without methods matches every method")
+	assert.Equal(t, "c-v1", conflicts[1].Loser.ID)
+	assert.Equal(t, []string{"DELETE"}, conflicts[1].Methods)
+
+	assert.Equal(t, "b-v2", conflicts[2].Winner.ID, "the route without conditions may be tried first")
+	assert.Equal(t, "c-v2", conflicts[2].Loser.ID)
+	assert.Contains(t, conflicts[2].Reason, "both have priority 0")
+	assert.True(t, conflicts[2].Involves(Owner{Kind: "ApisixRoute", Namespace: "default", Name: "team-c"}))
+
+	conflicts, err = s.RouteConflicts("missing")
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+}
+
+func TestCheckRouteConflicts(t *testing.T) {
+	s := NewStore(logr.Discard())
+	insertRouteService(t, s, "Ingress", "team-a", []string{"api.example.com"},
+		&adctypes.Route{Metadata: adctypes.Metadata{ID: "a-v1"}, Uris: []string{"/v1"}})
+
+	resources, labels := routeService("HTTPRoute", "team-b", []string{"api.example.com"},
+		&adctypes.Route{Metadata: adctypes.Metadata{ID: "b-v1"}, Uris: []string{"/v1"},
+			Vars: adctypes.Vars{{{StrVal: "http_x_canary"}, {StrVal: "=="}, {StrVal: "true"}}}, Priority: ptr.To[int64](1)})
+	conflicts, ok, err := s.CheckRouteConflicts("gp", resources.Services, labels)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Empty(t, conflicts, "a route with conditions tried first is not shadowed")
+
+	resources.Services[0].Routes[0].Priority = nil
+	resources.Services[0].Routes[0].Vars = nil
+	conflicts, _, err = s.CheckRouteConflicts("gp", resources.Services, labels)
+	require.NoError(t, err)
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, "gp route a-v1 of Ingress/default/team-a shadows route b-v1 of HTTPRoute/default/team-b "+
+		"for api.example.com/v1: both have priority 0, so which one APISIX picks is undefined; set a priority to choose",
+		conflicts[0].String())
+
+	resources, labels = routeService("Ingress", "team-a", []string{"api.example.com"},
+		&adctypes.Route{Metadata: adctypes.Metadata{ID: "a-v1"}, Uris: []string{"/v1"}})
+	conflicts, _, err = s.CheckRouteConflicts("gp", resources.Services, labels)
+	require.NoError(t, err)
+	assert.Empty(t, conflicts, "an object does not conflict with what it held before")
+
+	_, ok, err = s.CheckRouteConflicts("other", resources.Services, labels)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestOwnerRouteConflicts(t *testing.T) {
+	s := NewStore(logr.Discard())
+	insertRouteService(t, s, "Ingress", "team-a", []string{"api.example.com"},
+		&adctypes.Route{Metadata: adctypes.Metadata{ID: "a-v1"}, Uris: []string{"/v1"}})
+	insertRouteService(t, s, "HTTPRoute", "team-b", []string{"api.example.com"},
+		&adctypes.Route{Metadata: adctypes.Metadata{ID: "b-v1"}, Uris: []string{"/v1"}})
+	insertRouteService(t, s, "ApisixRoute", "team-c", []string{"api.example.com"},
+		&adctypes.Route{Metadata: adctypes.Metadata{ID: "c-v2"}, Uris: []string{"/v2"}})
+
+	conflicts, err := s.OwnerRouteConflicts("gp", []Owner{{Kind: "HTTPRoute", Namespace: "default", Name: "team-b"}})
+	require.NoError(t, err)
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, "a-v1", conflicts[0].Winner.ID)
+	assert.Equal(t, "b-v1", conflicts[0].Loser.ID)
+
+	conflicts, err = s.OwnerRouteConflicts("gp", []Owner{{Kind: "ApisixRoute", Namespace: "default", Name: "team-c"}})
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+}
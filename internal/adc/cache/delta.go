@@ -0,0 +1,333 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cache
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/controller/label"
+)
+
+// ObjectKey identifies one object of a config by its ADC resource type and id.
+type ObjectKey struct {
+	Type string
+	ID   string
+}
+
+// Owner names the Kubernetes object a set of ADC objects was translated from.
+type Owner struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+func (o Owner) String() string {
+	return o.Kind + "/" + o.Namespace + "/" + o.Name
+}
+
+// objectState is the content hash of one object, and the labels that select every
+// object translated from the same owner on the data plane.
+type objectState struct {
+	hash        string
+	owner       Owner
+	ownerLabels map[string]string
+}
+
+// syncedState is what was last pushed for a config.
+type syncedState struct {
+	objects map[ObjectKey]objectState
+	// global hashes the global rules and plugin metadata, which are not owned by any
+	// one object and can only be pushed with the whole config.
+	global string
+}
+
+// OwnerChange is the part of a config one owner accounts for, in the resource types
+// that changed since the config was last pushed.
+type OwnerChange struct {
+	Owner Owner
+	// Labels select the owner's objects on the data plane.
+	Labels        map[string]string
+	ResourceTypes []string
+	// Resources holds everything the owner has in those types now. An owner whose
+	// objects were all removed has none.
+	Resources *adctypes.Resources
+
+	keys []ObjectKey
+}
+
+// Delta is what changed in a config since it was last marked synced.
+type Delta struct {
+	// Full is set when the change cannot be expressed per owner and the whole config
+	// has to be pushed; FullReason says why.
+	Full       bool
+	FullReason string
+	// Changes is set unless Full is, ordered by owner.
+	Changes []OwnerChange
+
+	objects map[ObjectKey]objectState
+	global  string
+
+	store     *Store
+	name      string
+	resources *adctypes.Resources
+}
+
+// Resources returns the whole config, for when it is pushed whole. It is read from the
+// store on first use only, so that a delta pushed per owner never lists the rest of it.
+func (d *Delta) Resources() (*adctypes.Resources, error) {
+	if d.resources == nil {
+		resources, err := d.store.GetResources(d.name)
+		if err != nil {
+			return nil, err
+		}
+		d.resources = resources
+	}
+	return d.resources, nil
+}
+
+// Empty reports whether there is nothing to push.
+func (d *Delta) Empty() bool {
+	return !d.Full && len(d.Changes) == 0
+}
+
+func hashObject(obj any) string {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		// Every object in the store was translated from JSON-marshalable types; an
+		// empty hash never matches a synced one, so the object is pushed regardless.
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func newObjectState(obj any, labels map[string]string) objectState {
+	st := objectState{
+		hash: hashObject(obj),
+		owner: Owner{
+			Kind:      labels[label.LabelKind],
+			Namespace: labels[label.LabelNamespace],
+			Name:      labels[label.LabelName],
+		},
+	}
+	if st.owner.Kind == "" || st.owner.Name == "" {
+		return st
+	}
+	st.ownerLabels = map[string]string{
+		label.LabelKind:      st.owner.Kind,
+		label.LabelNamespace: st.owner.Namespace,
+		label.LabelName:      st.owner.Name,
+	}
+	if controller, ok := labels[label.LabelControllerName]; ok {
+		st.ownerLabels[label.LabelControllerName] = controller
+	}
+	return st
+}
+
+// setHash records the content hash of an object now in the cache of config name.
+// It must be called with the store locked.
+func (s *Store) setHash(name, resourceType, id string, obj any, labels map[string]string) {
+	hashes, ok := s.contentHashes[name]
+	if !ok {
+		hashes = make(map[ObjectKey]objectState)
+		s.contentHashes[name] = hashes
+	}
+	hashes[ObjectKey{Type: resourceType, ID: id}] = newObjectState(obj, labels)
+}
+
+// dropHash forgets an object removed from the cache of config name. It must be called
+// with the store locked.
+func (s *Store) dropHash(name, resourceType, id string) {
+	delete(s.contentHashes[name], ObjectKey{Type: resourceType, ID: id})
+}
+
+// Delta reports what changed in config name since MarkSynced last recorded a push of
+// it. A config that was never pushed, or whose global rules or plugin metadata changed,
+// has to be pushed whole.
+func (s *Store) Delta(name string) (*Delta, error) {
+	// The hashes are taken before the resources they describe are read, so that what
+	// is pushed is never older than what is marked synced: a change that lands in
+	// between is pushed early and reported again, never lost.
+	s.Lock()
+	delta := &Delta{objects: maps.Clone(s.contentHashes[name]), store: s, name: name}
+	synced, neverSynced := syncedState{}, true
+	if st, ok := s.syncedHashes[name]; ok {
+		synced, neverSynced = syncedState{objects: maps.Clone(st.objects), global: st.global}, false
+	}
+	var globalrule adctypes.GlobalRule
+	var metadata adctypes.PluginMetadata
+	if targetCache, ok := s.cacheMap[name]; ok {
+		globalrule, metadata = s.globals(name, targetCache)
+	}
+	s.Unlock()
+	delta.global = hashObject([]any{globalrule, metadata})
+
+	switch {
+	case neverSynced:
+		delta.Full, delta.FullReason = true, "never synced"
+	case synced.global != delta.global:
+		delta.Full, delta.FullReason = true, "global rules or plugin metadata changed"
+	}
+	if delta.Full {
+		return delta, nil
+	}
+
+	changes := make(map[Owner]*OwnerChange)
+	touch := func(key ObjectKey, st objectState) bool {
+		if st.ownerLabels == nil {
+			return false
+		}
+		change, ok := changes[st.owner]
+		if !ok {
+			change = &OwnerChange{Owner: st.owner, Labels: st.ownerLabels}
+			changes[st.owner] = change
+		}
+		if !slices.Contains(change.ResourceTypes, key.Type) {
+			change.ResourceTypes = append(change.ResourceTypes, key.Type)
+		}
+		return true
+	}
+	for key, st := range delta.objects {
+		if prev, ok := synced.objects[key]; ok && prev.hash == st.hash && st.hash != "" {
+			continue
+		}
+		if !touch(key, st) {
+			delta.Full, delta.FullReason = true, "object without owner labels changed"
+		}
+	}
+	for key, st := range synced.objects {
+		if _, ok := delta.objects[key]; ok {
+			continue
+		}
+		if !touch(key, st) {
+			delta.Full, delta.FullReason = true, "object without owner labels removed"
+		}
+	}
+	if delta.Full {
+		return delta, nil
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	targetCache := s.cacheMap[name]
+	for _, owner := range slices.SortedFunc(maps.Keys(changes), compareOwners) {
+		change := changes[owner]
+		slices.Sort(change.ResourceTypes)
+		for _, objects := range []map[ObjectKey]objectState{delta.objects, synced.objects} {
+			for key, st := range objects {
+				if st.owner == owner && slices.Contains(change.ResourceTypes, key.Type) && !slices.Contains(change.keys, key) {
+					change.keys = append(change.keys, key)
+				}
+			}
+		}
+
+		change.Resources = &adctypes.Resources{}
+		if targetCache != nil {
+			var err error
+			selector := &KindLabelSelector{Kind: owner.Kind, Namespace: owner.Namespace, Name: owner.Name}
+			for _, resourceType := range change.ResourceTypes {
+				switch resourceType {
+				case adctypes.TypeService:
+					change.Resources.Services, err = targetCache.ListServices(selector)
+				case adctypes.TypeSSL:
+					change.Resources.SSLs, err = targetCache.ListSSL(selector)
+				case adctypes.TypeConsumer:
+					change.Resources.Consumers, err = targetCache.ListConsumers(selector)
+				}
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		delta.Changes = append(delta.Changes, *change)
+	}
+	return delta, nil
+}
+
+// MarkSynced records that the whole of delta was pushed for config name, whether or not
+// it had to be.
+func (s *Store) MarkSynced(name string, delta *Delta) {
+	s.Lock()
+	defer s.Unlock()
+	s.syncedHashes[name] = &syncedState{
+		objects: maps.Clone(delta.objects),
+		global:  delta.global,
+	}
+}
+
+// MarkSyncedExcept records that delta was pushed for config name, but for the objects
+// keys names, which the data plane did not take. The next Delta reports those again.
+func (s *Store) MarkSyncedExcept(name string, delta *Delta, keys []ObjectKey) {
+	s.Lock()
+	defer s.Unlock()
+	objects := maps.Clone(delta.objects)
+	for _, key := range keys {
+		delete(objects, key)
+	}
+	s.syncedHashes[name] = &syncedState{
+		objects: objects,
+		global:  delta.global,
+	}
+}
+
+// MarkChangeSynced records that one owner change of delta was pushed for config name.
+// A change that is not marked is reported again by the next Delta.
+func (s *Store) MarkChangeSynced(name string, delta *Delta, change OwnerChange) {
+	s.Lock()
+	defer s.Unlock()
+	synced, ok := s.syncedHashes[name]
+	if !ok {
+		return
+	}
+	for _, key := range change.keys {
+		if st, ok := delta.objects[key]; ok {
+			synced.objects[key] = st
+		} else {
+			delete(synced.objects, key)
+		}
+	}
+}
+
+// ResetSynced forgets what was pushed for the configs named, or for every config when
+// none is, so that each is next pushed whole.
+func (s *Store) ResetSynced(names ...string) {
+	s.Lock()
+	defer s.Unlock()
+	if len(names) == 0 {
+		clear(s.syncedHashes)
+		return
+	}
+	for _, name := range names {
+		delete(s.syncedHashes, name)
+	}
+}
+
+func compareOwners(a, b Owner) int {
+	return cmp.Or(
+		strings.Compare(a.Kind, b.Kind),
+		strings.Compare(a.Namespace, b.Namespace),
+		strings.Compare(a.Name, b.Name),
+	)
+}
@@ -0,0 +1,169 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/controller/label"
+)
+
+const deltaConfig = "GatewayProxy/default/gp"
+
+func routeLabels(name string) map[string]string {
+	return map[string]string{
+		label.LabelKind:      "HTTPRoute",
+		label.LabelNamespace: "default",
+		label.LabelName:      name,
+	}
+}
+
+func insertRoute(t *testing.T, s *Store, name, host string) {
+	t.Helper()
+	labels := routeLabels(name)
+	require.NoError(t, s.Insert(deltaConfig, []string{adctypes.TypeService}, &adctypes.Resources{
+		Services: []*adctypes.Service{{
+			Metadata: adctypes.Metadata{ID: name, Name: name, Labels: labels},
+			Hosts:    []string{host},
+		}},
+	}, labels))
+}
+
+func syncedStore(t *testing.T) *Store {
+	t.Helper()
+	s := NewStore(logr.Discard())
+	insertRoute(t, s, "a", "a.example.com")
+	insertRoute(t, s, "b", "b.example.com")
+
+	delta, err := s.Delta(deltaConfig)
+	require.NoError(t, err)
+	require.True(t, delta.Full)
+	assert.Equal(t, "never synced", delta.FullReason)
+	resources, err := delta.Resources()
+	require.NoError(t, err)
+	assert.Len(t, resources.Services, 2)
+	s.MarkSynced(deltaConfig, delta)
+	return s
+}
+
+func TestDeltaIsEmptyOnceEverythingIsSynced(t *testing.T) {
+	s := syncedStore(t)
+
+	delta, err := s.Delta(deltaConfig)
+	require.NoError(t, err)
+	assert.True(t, delta.Empty())
+
+	// Re-inserting the same content is not a change.
+	insertRoute(t, s, "a", "a.example.com")
+	delta, err = s.Delta(deltaConfig)
+	require.NoError(t, err)
+	assert.True(t, delta.Empty())
+}
+
+func TestDeltaReportsOnlyTheOwnerThatChanged(t *testing.T) {
+	s := syncedStore(t)
+	insertRoute(t, s, "b", "b2.example.com")
+
+	delta, err := s.Delta(deltaConfig)
+	require.NoError(t, err)
+	require.False(t, delta.Full)
+	require.Len(t, delta.Changes, 1)
+	change := delta.Changes[0]
+	assert.Equal(t, Owner{Kind: "HTTPRoute", Namespace: "default", Name: "b"}, change.Owner)
+	assert.Equal(t, routeLabels("b"), change.Labels)
+	assert.Equal(t, []string{adctypes.TypeService}, change.ResourceTypes)
+	require.Len(t, change.Resources.Services, 1)
+	assert.Equal(t, []string{"b2.example.com"}, change.Resources.Services[0].Hosts)
+	// Nothing outside the change is read unless the config is pushed whole.
+	assert.Nil(t, delta.resources)
+
+	s.MarkChangeSynced(deltaConfig, delta, change)
+	delta, err = s.Delta(deltaConfig)
+	require.NoError(t, err)
+	assert.True(t, delta.Empty())
+}
+
+func TestDeltaReportsARemovedOwnerWithNoResources(t *testing.T) {
+	s := syncedStore(t)
+	require.NoError(t, s.Delete(deltaConfig, []string{adctypes.TypeService}, routeLabels("a")))
+
+	delta, err := s.Delta(deltaConfig)
+	require.NoError(t, err)
+	require.Len(t, delta.Changes, 1)
+	assert.Equal(t, "a", delta.Changes[0].Owner.Name)
+	assert.Empty(t, delta.Changes[0].Resources.Services)
+
+	s.MarkChangeSynced(deltaConfig, delta, delta.Changes[0])
+	delta, err = s.Delta(deltaConfig)
+	require.NoError(t, err)
+	assert.True(t, delta.Empty())
+}
+
+func TestDeltaKeepsUnmarkedChanges(t *testing.T) {
+	s := syncedStore(t)
+	insertRoute(t, s, "a", "a2.example.com")
+	insertRoute(t, s, "b", "b2.example.com")
+
+	delta, err := s.Delta(deltaConfig)
+	require.NoError(t, err)
+	require.Len(t, delta.Changes, 2)
+	s.MarkChangeSynced(deltaConfig, delta, delta.Changes[0])
+
+	delta, err = s.Delta(deltaConfig)
+	require.NoError(t, err)
+	require.Len(t, delta.Changes, 1)
+	assert.Equal(t, "b", delta.Changes[0].Owner.Name)
+}
+
+func TestDeltaIsFullWhenItCannotBeSplitByOwner(t *testing.T) {
+	t.Run("global rules", func(t *testing.T) {
+		s := syncedStore(t)
+		require.NoError(t, s.Insert(deltaConfig, []string{adctypes.TypeGlobalRule}, &adctypes.Resources{
+			GlobalRules: adctypes.GlobalRule{"cors": map[string]any{}},
+		}, routeLabels("g")))
+
+		delta, err := s.Delta(deltaConfig)
+		require.NoError(t, err)
+		assert.True(t, delta.Full)
+		assert.Equal(t, "global rules or plugin metadata changed", delta.FullReason)
+	})
+	t.Run("object without owner labels", func(t *testing.T) {
+		s := syncedStore(t)
+		require.NoError(t, s.Insert(deltaConfig, []string{adctypes.TypeService}, &adctypes.Resources{
+			Services: []*adctypes.Service{{Metadata: adctypes.Metadata{ID: "bare", Name: "bare"}}},
+		}, map[string]string{}))
+
+		delta, err := s.Delta(deltaConfig)
+		require.NoError(t, err)
+		assert.True(t, delta.Full)
+		assert.Equal(t, "object without owner labels changed", delta.FullReason)
+	})
+	t.Run("reset", func(t *testing.T) {
+		s := syncedStore(t)
+		s.ResetSynced(deltaConfig)
+
+		delta, err := s.Delta(deltaConfig)
+		require.NoError(t, err)
+		assert.True(t, delta.Full)
+	})
+}
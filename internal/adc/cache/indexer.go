@@ -26,7 +26,11 @@ import (
 )
 
 const (
-	KindLabelIndex = "label"
+	KindLabelIndex   = "label"
+	PolicyLabelIndex = "policy"
+	HostIndex        = "host"
+	URIIndex         = "uri"
+	SNIIndex         = "sni"
 )
 
 /*
@@ -94,3 +98,92 @@ func (emi *LabelIndexer) FromArgs(args ...any) ([]byte, error) {
 
 	return emi.genKey(labelValues), nil
 }
+
+// PolicyLabelIndexer indexes a service by every policy that its labels, or those of its
+// routes and stream routes, record as having shaped it. See label.WithPolicy.
+type PolicyLabelIndexer struct{}
+
+func (pi *PolicyLabelIndexer) FromObject(obj any) (bool, [][]byte, error) {
+	service, ok := obj.(*adc.Service)
+	if !ok {
+		return false, nil, fmt.Errorf("unexpected object type %T", obj)
+	}
+	seen := make(map[string]bool)
+	var keys [][]byte
+	add := func(labels map[string]string) {
+		for key := range labels {
+			kind, namespace, name, ok := label.ParsePolicy(key)
+			if !ok || seen[key] {
+				continue
+			}
+			seen[key] = true
+			keys = append(keys, pi.genKey(kind, namespace, name))
+		}
+	}
+	add(service.Labels)
+	for _, route := range service.Routes {
+		add(route.Labels)
+	}
+	for _, streamRoute := range service.StreamRoutes {
+		add(streamRoute.Labels)
+	}
+	return len(keys) > 0, keys, nil
+}
+
+func (pi *PolicyLabelIndexer) FromArgs(args ...any) ([]byte, error) {
+	if len(args) != 3 {
+		return nil, fmt.Errorf("expected 3 arguments, got %d", len(args))
+	}
+	values := make([]string, 0, len(args))
+	for _, arg := range args {
+		value, ok := arg.(string)
+		if !ok {
+			return nil, fmt.Errorf("argument is not a string")
+		}
+		values = append(values, value)
+	}
+	return pi.genKey(values...), nil
+}
+
+func (pi *PolicyLabelIndexer) genKey(values ...string) []byte {
+	return []byte(strings.Join(values, "/") + "\x00")
+}
+
+// RouteHostIndexer indexes a route by each of its hosts, lowercased. A route without hosts
+// of its own is served on those of its service, and one whose service has none either is
+// indexed by the empty host, as it matches any.
+type RouteHostIndexer struct{}
+
+func (hi *RouteHostIndexer) FromObject(obj any) (bool, [][]byte, error) {
+	row, ok := obj.(*routeRow)
+	if !ok {
+		return false, nil, fmt.Errorf("unexpected object type %T", obj)
+	}
+	hosts := row.Hosts
+	if len(hosts) == 0 {
+		hosts = row.ServiceHosts
+	}
+	if len(hosts) == 0 {
+		return true, [][]byte{hi.genKey("")}, nil
+	}
+	keys := make([][]byte, 0, len(hosts))
+	for _, host := range hosts {
+		keys = append(keys, hi.genKey(host))
+	}
+	return true, keys, nil
+}
+
+func (hi *RouteHostIndexer) FromArgs(args ...any) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("expected 1 argument, got %d", len(args))
+	}
+	host, ok := args[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("argument is not a string")
+	}
+	return hi.genKey(host), nil
+}
+
+func (hi *RouteHostIndexer) genKey(host string) []byte {
+	return []byte(strings.ToLower(host) + "\x00")
+}
@@ -19,6 +19,8 @@ package cache
 
 import (
 	"errors"
+	"fmt"
+	"slices"
 
 	"github.com/hashicorp/go-memdb"
 
@@ -36,6 +38,21 @@ type dbCache struct {
 	db *memdb.MemDB
 }
 
+// routeRow is a route as the route table holds it: with the service it belongs to, whose
+// hosts it is served on unless it has hosts of its own. Routes are written with their
+// service, which holds them; the route table only indexes them.
+type routeRow struct {
+	*types.Route
+	ServiceID    string
+	ServiceHosts []string
+}
+
+// streamRouteRow is a stream route as the stream route table holds it, see routeRow.
+type streamRouteRow struct {
+	*types.StreamRoute
+	ServiceID string
+}
+
 // NewMemDBCache creates a Cache object backs with a memory DB.
 func NewMemDBCache() (Cache, error) {
 	db, err := memdb.NewMemDB(_schema)
@@ -66,6 +83,8 @@ func (c *dbCache) Delete(obj any) error {
 	switch t := obj.(type) {
 	case *types.Route:
 		return c.DeleteRoute(t)
+	case *types.StreamRoute:
+		return c.DeleteStreamRoute(t)
 	case *types.SSL:
 		return c.DeleteSSL(t)
 	case *types.Service:
@@ -79,9 +98,30 @@ func (c *dbCache) Delete(obj any) error {
 	}
 }
 
-func (c *dbCache) InsertRoute(r *types.Route) error {
-	route := r.DeepCopy()
-	return c.insert(types.TypeRoute, route)
+func (c *dbCache) InsertRoute(serviceID string, r *types.Route) error {
+	return c.updateService(serviceID, func(service *types.Service) {
+		route := r.DeepCopy()
+		for i := range service.Routes {
+			if service.Routes[i].ID == route.ID {
+				service.Routes[i] = route
+				return
+			}
+		}
+		service.Routes = append(service.Routes, route)
+	})
+}
+
+func (c *dbCache) InsertStreamRoute(serviceID string, r *types.StreamRoute) error {
+	return c.updateService(serviceID, func(service *types.Service) {
+		streamRoute := r.DeepCopy()
+		for i := range service.StreamRoutes {
+			if service.StreamRoutes[i].ID == streamRoute.ID {
+				service.StreamRoutes[i] = streamRoute
+				return
+			}
+		}
+		service.StreamRoutes = append(service.StreamRoutes, streamRoute)
+	})
 }
 
 func (c *dbCache) InsertSSL(ssl *types.SSL) error {
@@ -89,7 +129,64 @@ func (c *dbCache) InsertSSL(ssl *types.SSL) error {
 }
 
 func (c *dbCache) InsertService(u *types.Service) error {
-	return c.insert(types.TypeService, u.DeepCopy())
+	txn := c.db.Txn(true)
+	defer txn.Abort()
+	if err := insertService(txn, u.DeepCopy()); err != nil {
+		return err
+	}
+	txn.Commit()
+	return nil
+}
+
+// insertService writes service, and indexes its routes and stream routes in place of
+// those it had before.
+func insertService(txn *memdb.Txn, service *types.Service) error {
+	if err := deleteServiceRoutes(txn, service.ID); err != nil {
+		return err
+	}
+	if err := txn.Insert(types.TypeService, service); err != nil {
+		return err
+	}
+	for _, route := range service.Routes {
+		row := &routeRow{Route: route, ServiceID: service.ID, ServiceHosts: service.Hosts}
+		if err := txn.Insert(types.TypeRoute, row); err != nil {
+			return err
+		}
+	}
+	for _, streamRoute := range service.StreamRoutes {
+		if err := txn.Insert(types.TypeStreamRoute, &streamRouteRow{StreamRoute: streamRoute, ServiceID: service.ID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteServiceRoutes(txn *memdb.Txn, serviceID string) error {
+	if _, err := txn.DeleteAll(types.TypeRoute, "service_id", serviceID); err != nil {
+		return err
+	}
+	_, err := txn.DeleteAll(types.TypeStreamRoute, "service_id", serviceID)
+	return err
+}
+
+// updateService writes a copy of the service with serviceID that update changed.
+func (c *dbCache) updateService(serviceID string, update func(*types.Service)) error {
+	txn := c.db.Txn(true)
+	defer txn.Abort()
+	obj, err := txn.First(types.TypeService, "id", serviceID)
+	if err != nil {
+		return err
+	}
+	if obj == nil {
+		return ErrNotFound
+	}
+	service := obj.(*types.Service).DeepCopy()
+	update(service)
+	if err := insertService(txn, service); err != nil {
+		return err
+	}
+	txn.Commit()
+	return nil
 }
 
 func (c *dbCache) InsertConsumer(consumer *types.Consumer) error {
@@ -115,7 +212,7 @@ func (c *dbCache) GetRoute(id string) (*types.Route, error) {
 	if err != nil {
 		return nil, err
 	}
-	return obj.(*types.Route).DeepCopy(), nil
+	return obj.(*routeRow).DeepCopy(), nil
 }
 
 func (c *dbCache) GetSSL(id string) (*types.SSL, error) {
@@ -151,11 +248,11 @@ func (c *dbCache) GetGlobalRule(id string) (*types.GlobalRuleItem, error) {
 }
 
 func (c *dbCache) GetStreamRoute(id string) (*types.StreamRoute, error) {
-	obj, err := c.get("stream_route", id)
+	obj, err := c.get(types.TypeStreamRoute, id)
 	if err != nil {
 		return nil, err
 	}
-	return obj.(*types.StreamRoute).DeepCopy(), nil
+	return obj.(*streamRouteRow).DeepCopy(), nil
 }
 
 func (c *dbCache) get(table, id string) (any, error) {
@@ -181,11 +278,23 @@ func (c *dbCache) ListRoutes(opts ...ListOption) ([]*types.Route, error) {
 	}
 	routes := make([]*types.Route, 0, len(raws))
 	for _, raw := range raws {
-		routes = append(routes, raw.(*types.Route).DeepCopy())
+		routes = append(routes, raw.(*routeRow).DeepCopy())
 	}
 	return routes, nil
 }
 
+func (c *dbCache) ListStreamRoutes(opts ...ListOption) ([]*types.StreamRoute, error) {
+	raws, err := c.list(types.TypeStreamRoute, opts...)
+	if err != nil {
+		return nil, err
+	}
+	streamRoutes := make([]*types.StreamRoute, 0, len(raws))
+	for _, raw := range raws {
+		streamRoutes = append(streamRoutes, raw.(*streamRouteRow).DeepCopy())
+	}
+	return streamRoutes, nil
+}
+
 func (c *dbCache) ListSSL(opts ...ListOption) ([]*types.SSL, error) {
 	raws, err := c.list(types.TypeSSL, opts...)
 	if err != nil {
@@ -234,30 +343,103 @@ func (c *dbCache) ListGlobalRules(opts ...ListOption) ([]*types.GlobalRuleItem,
 	return globalRules, nil
 }
 
+// list returns the objects of table every selector in opts selects, or all of them.
 func (c *dbCache) list(table string, opts ...ListOption) ([]any, error) {
 	txn := c.db.Txn(false)
 	defer txn.Abort()
 	listOpts := &ListOptions{}
 	listOpts.ApplyOptions(opts)
-	index := "id"
-	var args []any
+
+	type query struct {
+		index string
+		args  []any
+	}
+	var queries []query
 	if listOpts.KindLabelSelector != nil {
-		index = KindLabelIndex
-		args = []any{listOpts.KindLabelSelector.Kind, listOpts.KindLabelSelector.Namespace, listOpts.KindLabelSelector.Name}
+		queries = append(queries, query{KindLabelIndex, []any{listOpts.KindLabelSelector.Kind, listOpts.KindLabelSelector.Namespace, listOpts.KindLabelSelector.Name}})
 	}
-	iter, err := txn.Get(table, index, args...)
-	if err != nil {
-		return nil, err
+	if listOpts.PolicyLabelSelector != nil {
+		queries = append(queries, query{PolicyLabelIndex, []any{listOpts.PolicyLabelSelector.Kind, listOpts.PolicyLabelSelector.Namespace, listOpts.PolicyLabelSelector.Name}})
+	}
+	// A URI selects fewer routes than a host usually does, and the first query bounds
+	// how many objects the others are matched against.
+	if listOpts.URISelector != nil {
+		queries = append(queries, query{URIIndex, []any{listOpts.URISelector.URI}})
 	}
-	var objs []any
-	for obj := iter.Next(); obj != nil; obj = iter.Next() {
-		objs = append(objs, obj)
+	if listOpts.HostSelector != nil {
+		queries = append(queries, query{HostIndex, []any{listOpts.HostSelector.Host}})
+	}
+	if listOpts.SNISelector != nil {
+		queries = append(queries, query{SNIIndex, []any{listOpts.SNISelector.SNI}})
+	}
+	if len(queries) == 0 {
+		queries = append(queries, query{index: "id"})
+	}
+
+	var (
+		objs     []any
+		selected map[any]struct{}
+	)
+	for i, q := range queries {
+		iter, err := txn.Get(table, q.index, q.args...)
+		if err != nil {
+			return nil, err
+		}
+		var found []any
+		for obj := iter.Next(); obj != nil; obj = iter.Next() {
+			// The table holds one pointer per object, so the objects every index
+			// selects are the same pointers.
+			if _, ok := selected[obj]; i == 0 || ok {
+				found = append(found, obj)
+			}
+		}
+		objs = found
+		if i < len(queries)-1 {
+			selected = make(map[any]struct{}, len(objs))
+			for _, obj := range objs {
+				selected[obj] = struct{}{}
+			}
+		}
 	}
 	return objs, nil
 }
 
 func (c *dbCache) DeleteRoute(r *types.Route) error {
-	return c.delete(types.TypeRoute, r)
+	serviceID, err := c.ServiceOf(types.TypeRoute, r.ID)
+	if err != nil {
+		return err
+	}
+	return c.updateService(serviceID, func(service *types.Service) {
+		service.Routes = slices.DeleteFunc(service.Routes, func(route *types.Route) bool { return route.ID == r.ID })
+	})
+}
+
+func (c *dbCache) DeleteStreamRoute(r *types.StreamRoute) error {
+	serviceID, err := c.ServiceOf(types.TypeStreamRoute, r.ID)
+	if err != nil {
+		return err
+	}
+	return c.updateService(serviceID, func(service *types.Service) {
+		service.StreamRoutes = slices.DeleteFunc(service.StreamRoutes, func(streamRoute *types.StreamRoute) bool {
+			return streamRoute.ID == r.ID
+		})
+	})
+}
+
+// ServiceOf returns the id of the service the route or stream route with id belongs to.
+func (c *dbCache) ServiceOf(resourceType, id string) (string, error) {
+	obj, err := c.get(resourceType, id)
+	if err != nil {
+		return "", err
+	}
+	switch row := obj.(type) {
+	case *routeRow:
+		return row.ServiceID, nil
+	case *streamRouteRow:
+		return row.ServiceID, nil
+	default:
+		return "", fmt.Errorf("unexpected object type %T", obj)
+	}
 }
 
 func (c *dbCache) DeleteSSL(ssl *types.SSL) error {
@@ -265,7 +447,19 @@ func (c *dbCache) DeleteSSL(ssl *types.SSL) error {
 }
 
 func (c *dbCache) DeleteService(u *types.Service) error {
-	return c.delete(types.TypeService, u)
+	txn := c.db.Txn(true)
+	defer txn.Abort()
+	if err := txn.Delete(types.TypeService, u); err != nil {
+		if err == memdb.ErrNotFound {
+			return ErrNotFound
+		}
+		return err
+	}
+	if err := deleteServiceRoutes(txn, u.ID); err != nil {
+		return err
+	}
+	txn.Commit()
+	return nil
 }
 
 func (c *dbCache) DeleteConsumer(consumer *types.Consumer) error {
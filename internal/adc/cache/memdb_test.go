@@ -0,0 +1,120 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	types "github.com/apache/apisix-ingress-controller/api/adc"
+)
+
+func routeIDs(routes []*types.Route) []string {
+	ids := make([]string, 0, len(routes))
+	for _, route := range routes {
+		ids = append(ids, route.ID)
+	}
+	return ids
+}
+
+func TestDBCacheIndexesRoutes(t *testing.T) {
+	c, err := NewMemDBCache()
+	require.NoError(t, err)
+	require.NoError(t, c.InsertService(&types.Service{
+		Metadata: types.Metadata{ID: "web"},
+		Hosts:    []string{"Example.com"},
+		Routes: []*types.Route{
+			{Metadata: types.Metadata{ID: "inherits"}, Uris: []string{"/"}},
+			{Metadata: types.Metadata{ID: "own"}, Hosts: []string{"api.example.com"}, Uris: []string{"/api/*"}},
+		},
+	}))
+	require.NoError(t, c.InsertService(&types.Service{
+		Metadata: types.Metadata{ID: "any"},
+		Routes:   []*types.Route{{Metadata: types.Metadata{ID: "catch-all"}, Uris: []string{"/"}}},
+		StreamRoutes: []*types.StreamRoute{
+			{Metadata: types.Metadata{ID: "tls"}, SNI: "DB.example.com"},
+			{Metadata: types.Metadata{ID: "tcp"}, ServerPort: 5432},
+		},
+	}))
+
+	routes, err := c.ListRoutes(&HostSelector{Host: "example.COM"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"inherits"}, routeIDs(routes), "a route without hosts is served on its service's")
+
+	routes, err = c.ListRoutes(&HostSelector{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"catch-all"}, routeIDs(routes))
+
+	routes, err = c.ListRoutes(&URISelector{URI: "/"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"inherits", "catch-all"}, routeIDs(routes))
+
+	routes, err = c.ListRoutes(&URISelector{URI: "/"}, &HostSelector{Host: "example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"inherits"}, routeIDs(routes), "selectors are combined")
+
+	streamRoutes, err := c.ListStreamRoutes(&SNISelector{SNI: "db.example.com"})
+	require.NoError(t, err)
+	require.Len(t, streamRoutes, 1)
+	assert.Equal(t, "tls", streamRoutes[0].ID)
+
+	streamRoutes, err = c.ListStreamRoutes()
+	require.NoError(t, err)
+	assert.Len(t, streamRoutes, 2)
+}
+
+func TestDBCacheWritesRoutesThroughTheirService(t *testing.T) {
+	c, err := NewMemDBCache()
+	require.NoError(t, err)
+	require.NoError(t, c.InsertService(&types.Service{
+		Metadata: types.Metadata{ID: "web"},
+		Routes:   []*types.Route{{Metadata: types.Metadata{ID: "a"}, Uris: []string{"/a"}}},
+	}))
+
+	require.NoError(t, c.InsertRoute("web", &types.Route{Metadata: types.Metadata{ID: "a"}, Uris: []string{"/b"}}))
+	require.NoError(t, c.InsertRoute("web", &types.Route{Metadata: types.Metadata{ID: "c"}, Uris: []string{"/c"}}))
+	assert.ErrorIs(t, c.InsertRoute("missing", &types.Route{Metadata: types.Metadata{ID: "d"}}), ErrNotFound)
+
+	service, err := c.GetService("web")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "c"}, routeIDs(service.Routes))
+	route, err := c.GetRoute("a")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/b"}, route.Uris)
+	routes, err := c.ListRoutes(&URISelector{URI: "/a"})
+	require.NoError(t, err)
+	assert.Empty(t, routes, "the route is indexed by its new URIs only")
+
+	require.NoError(t, c.InsertStreamRoute("web", &types.StreamRoute{Metadata: types.Metadata{ID: "s"}, SNI: "web"}))
+	require.NoError(t, c.DeleteStreamRoute(&types.StreamRoute{Metadata: types.Metadata{ID: "s"}}))
+	_, err = c.GetStreamRoute("s")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, c.DeleteRoute(&types.Route{Metadata: types.Metadata{ID: "a"}}))
+	service, err = c.GetService("web")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c"}, routeIDs(service.Routes))
+	assert.ErrorIs(t, c.DeleteRoute(&types.Route{Metadata: types.Metadata{ID: "a"}}), ErrNotFound)
+
+	require.NoError(t, c.DeleteService(service))
+	routes, err = c.ListRoutes()
+	require.NoError(t, err)
+	assert.Empty(t, routes, "deleting a service deletes its routes")
+}
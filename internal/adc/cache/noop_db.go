@@ -53,6 +53,14 @@ func (c *noopCache) InsertConsumer(consumer *types.Consumer) error {
 	return nil
 }
 
+func (c *noopCache) InsertRoute(serviceID string, route *types.Route) error {
+	return nil
+}
+
+func (c *noopCache) InsertStreamRoute(serviceID string, streamRoute *types.StreamRoute) error {
+	return nil
+}
+
 func (c *noopCache) GetSSL(id string) (*types.SSL, error) {
 	return nil, nil
 }
@@ -69,6 +77,18 @@ func (c *noopCache) GetConsumer(username string) (*types.Consumer, error) {
 	return nil, nil
 }
 
+func (c *noopCache) GetRoute(id string) (*types.Route, error) {
+	return nil, nil
+}
+
+func (c *noopCache) GetStreamRoute(id string) (*types.StreamRoute, error) {
+	return nil, nil
+}
+
+func (c *noopCache) ServiceOf(resourceType, id string) (string, error) {
+	return "", nil
+}
+
 func (c *noopCache) ListSSL(...ListOption) ([]*types.SSL, error) {
 	return nil, nil
 }
@@ -77,6 +97,10 @@ func (c *noopCache) ListServices(...ListOption) ([]*types.Service, error) {
 	return nil, nil
 }
 
+func (c *noopCache) ListRoutes(...ListOption) ([]*types.Route, error) {
+	return nil, nil
+}
+
 func (c *noopCache) ListStreamRoutes(...ListOption) ([]*types.StreamRoute, error) {
 	return nil, nil
 }
@@ -101,6 +125,14 @@ func (c *noopCache) DeleteGlobalRule(gr *types.GlobalRuleItem) error {
 	return nil
 }
 
+func (c *noopCache) DeleteRoute(route *types.Route) error {
+	return nil
+}
+
+func (c *noopCache) DeleteStreamRoute(streamRoute *types.StreamRoute) error {
+	return nil
+}
+
 func (c *noopCache) DeleteConsumer(consumer *types.Consumer) error {
 	return nil
 }
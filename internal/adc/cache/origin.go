@@ -0,0 +1,209 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cache
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/controller/label"
+)
+
+// Origin is where one object of a config came from: the Kubernetes object it was
+// translated from, and the policies that shaped it. A route is shaped by the policies of
+// its service too, such as a BackendTrafficPolicy on its upstream.
+type Origin struct {
+	Config   string
+	Type     string
+	ID       string
+	Name     string
+	Owner    Owner
+	Policies []Owner
+}
+
+// Origin returns where the object of resourceType with id in the config name came from.
+func (s *Store) Origin(name, resourceType, id string) (*Origin, error) {
+	s.Lock()
+	defer s.Unlock()
+	targetCache, ok := s.cacheMap[name]
+	if !ok {
+		return nil, fmt.Errorf("cache not found for name: %s", name)
+	}
+
+	var origin *Origin
+	switch resourceType {
+	case adctypes.TypeService:
+		service, err := targetCache.GetService(id)
+		if err != nil {
+			return nil, err
+		}
+		origin = newOrigin(name, resourceType, service.ID, service.Name, service.Labels)
+	case adctypes.TypeRoute, adctypes.TypeStreamRoute:
+		serviceID, err := targetCache.ServiceOf(resourceType, id)
+		if err != nil {
+			return nil, err
+		}
+		service, err := targetCache.GetService(serviceID)
+		if err != nil {
+			return nil, err
+		}
+		if resourceType == adctypes.TypeRoute {
+			route, err := targetCache.GetRoute(id)
+			if err != nil {
+				return nil, err
+			}
+			origin = newOrigin(name, resourceType, route.ID, route.Name, route.Labels, service.Labels)
+		} else {
+			streamRoute, err := targetCache.GetStreamRoute(id)
+			if err != nil {
+				return nil, err
+			}
+			origin = newOrigin(name, resourceType, streamRoute.ID, streamRoute.Name, streamRoute.Labels, service.Labels)
+		}
+	case adctypes.TypeSSL:
+		ssl, err := targetCache.GetSSL(id)
+		if err != nil {
+			return nil, err
+		}
+		origin = newOrigin(name, resourceType, ssl.ID, ssl.Name, ssl.Labels)
+	case adctypes.TypeConsumer:
+		consumer, err := targetCache.GetConsumer(id)
+		if err != nil {
+			return nil, err
+		}
+		origin = newOrigin(name, resourceType, consumer.Username, consumer.Username, consumer.Labels)
+	case adctypes.TypeGlobalRule:
+		globalRule, err := targetCache.GetGlobalRule(id)
+		if err != nil {
+			return nil, err
+		}
+		origin = newOrigin(name, resourceType, globalRule.ID, globalRule.Name, globalRule.Labels)
+	default:
+		return nil, fmt.Errorf("unknown resource type: %s", resourceType)
+	}
+	if origin == nil {
+		return nil, ErrNotFound
+	}
+	return origin, nil
+}
+
+// Derived lists, across every config, the objects obj was translated into or shaped as
+// a policy, ordered by config, type and id.
+func (s *Store) Derived(obj Owner) ([]*Origin, error) {
+	s.Lock()
+	defer s.Unlock()
+	owned := &KindLabelSelector{Kind: obj.Kind, Namespace: obj.Namespace, Name: obj.Name}
+	shaped := &PolicyLabelSelector{Kind: obj.Kind, Namespace: obj.Namespace, Name: obj.Name}
+
+	var origins []*Origin
+	for _, name := range slices.Sorted(maps.Keys(s.cacheMap)) {
+		targetCache := s.cacheMap[name]
+		found := make(map[ObjectKey]*Origin)
+		add := func(origin *Origin) {
+			if origin.Owner == obj || slices.Contains(origin.Policies, obj) {
+				found[ObjectKey{Type: origin.Type, ID: origin.ID}] = origin
+			}
+		}
+
+		for _, selector := range []ListOption{owned, shaped} {
+			services, err := targetCache.ListServices(selector)
+			if err != nil {
+				return nil, err
+			}
+			for _, service := range services {
+				add(newOrigin(name, adctypes.TypeService, service.ID, service.Name, service.Labels))
+				for _, origin := range serviceOrigins(name, service) {
+					add(origin)
+				}
+			}
+		}
+		ssls, err := targetCache.ListSSL(owned)
+		if err != nil {
+			return nil, err
+		}
+		for _, ssl := range ssls {
+			add(newOrigin(name, adctypes.TypeSSL, ssl.ID, ssl.Name, ssl.Labels))
+		}
+		consumers, err := targetCache.ListConsumers(owned)
+		if err != nil {
+			return nil, err
+		}
+		for _, consumer := range consumers {
+			add(newOrigin(name, adctypes.TypeConsumer, consumer.Username, consumer.Username, consumer.Labels))
+		}
+		globalRules, err := targetCache.ListGlobalRules(owned)
+		if err != nil {
+			return nil, err
+		}
+		for _, globalRule := range globalRules {
+			add(newOrigin(name, adctypes.TypeGlobalRule, globalRule.ID, globalRule.Name, globalRule.Labels))
+		}
+
+		for _, key := range slices.SortedFunc(maps.Keys(found), func(a, b ObjectKey) int {
+			return cmp.Or(cmp.Compare(a.Type, b.Type), cmp.Compare(a.ID, b.ID))
+		}) {
+			origins = append(origins, found[key])
+		}
+	}
+	return origins, nil
+}
+
+// serviceOrigins returns where the routes and stream routes of service came from.
+func serviceOrigins(config string, service *adctypes.Service) []*Origin {
+	var origins []*Origin
+	for _, route := range service.Routes {
+		origins = append(origins, newOrigin(config, adctypes.TypeRoute, route.ID, route.Name, route.Labels, service.Labels))
+	}
+	for _, streamRoute := range service.StreamRoutes {
+		origins = append(origins, newOrigin(config, adctypes.TypeStreamRoute, streamRoute.ID, streamRoute.Name,
+			streamRoute.Labels, service.Labels))
+	}
+	return origins
+}
+
+// newOrigin builds the origin of an object from its labels. inherited holds the labels
+// of the objects it belongs to, whose policies shaped it too.
+func newOrigin(config, resourceType, id, name string, labels map[string]string, inherited ...map[string]string) *Origin {
+	origin := &Origin{
+		Config: config,
+		Type:   resourceType,
+		ID:     id,
+		Name:   name,
+		Owner: Owner{
+			Kind:      labels[label.LabelKind],
+			Namespace: labels[label.LabelNamespace],
+			Name:      labels[label.LabelName],
+		},
+	}
+	for _, set := range append([]map[string]string{labels}, inherited...) {
+		for key := range set {
+			kind, namespace, name, ok := label.ParsePolicy(key)
+			policy := Owner{Kind: kind, Namespace: namespace, Name: name}
+			if ok && !slices.Contains(origin.Policies, policy) {
+				origin.Policies = append(origin.Policies, policy)
+			}
+		}
+	}
+	slices.SortFunc(origin.Policies, func(a, b Owner) int {
+		return cmp.Or(cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+	return origin
+}
@@ -0,0 +1,86 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/controller/label"
+)
+
+func TestStoreOriginAndDerived(t *testing.T) {
+	s := NewStore(logr.Discard())
+	httpRoute := Owner{Kind: "HTTPRoute", Namespace: "default", Name: "web"}
+	btp := Owner{Kind: "BackendTrafficPolicy", Namespace: "default", Name: "retries"}
+	hrp := Owner{Kind: "HTTPRoutePolicy", Namespace: "default", Name: "priority"}
+
+	labels := map[string]string{
+		label.LabelKind:      httpRoute.Kind,
+		label.LabelNamespace: httpRoute.Namespace,
+		label.LabelName:      httpRoute.Name,
+	}
+	require.NoError(t, s.Insert("gp", []string{adctypes.TypeService}, &adctypes.Resources{
+		Services: []*adctypes.Service{{
+			Metadata: adctypes.Metadata{
+				ID:     "svc",
+				Labels: label.WithPolicy(labels, btp.Kind, btp.Namespace, btp.Name),
+			},
+			Routes: []*adctypes.Route{
+				{Metadata: adctypes.Metadata{ID: "plain", Labels: labels}},
+				{Metadata: adctypes.Metadata{ID: "prioritized", Labels: label.WithPolicy(labels, hrp.Kind, hrp.Namespace, hrp.Name)}},
+			},
+		}},
+	}, labels))
+
+	origin, err := s.Origin("gp", adctypes.TypeRoute, "prioritized")
+	require.NoError(t, err)
+	assert.Equal(t, httpRoute, origin.Owner)
+	assert.Equal(t, []Owner{btp, hrp}, origin.Policies, "a route is shaped by the policies of its service too")
+
+	_, err = s.Origin("gp", adctypes.TypeRoute, "missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	ids := func(origins []*Origin) []string {
+		var out []string
+		for _, origin := range origins {
+			out = append(out, origin.Type+"/"+origin.ID)
+		}
+		return out
+	}
+
+	derived, err := s.Derived(httpRoute)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"route/plain", "route/prioritized", "service/svc"}, ids(derived))
+
+	derived, err = s.Derived(btp)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"route/plain", "route/prioritized", "service/svc"}, ids(derived))
+
+	derived, err = s.Derived(hrp)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"route/prioritized"}, ids(derived), "only the route the policy shaped")
+
+	derived, err = s.Derived(Owner{Kind: "HTTPRoutePolicy", Namespace: "default", Name: "other"})
+	require.NoError(t, err)
+	assert.Empty(t, derived)
+}
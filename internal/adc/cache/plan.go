@@ -0,0 +1,254 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cache
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+)
+
+// ResourceDiff names the objects of one type a change adds, changes and removes.
+type ResourceDiff struct {
+	Added   []string `json:"added,omitempty"`
+	Changed []string `json:"changed,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+// Empty reports whether the change leaves objects of the type as they are.
+func (d ResourceDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0
+}
+
+func (d ResourceDiff) String() string {
+	return fmt.Sprintf("+%d ~%d -%d", len(d.Added), len(d.Changed), len(d.Removed))
+}
+
+// Plan is what a change would push to the data plane of one config, relative to what
+// the store holds for it now.
+type Plan struct {
+	Config       string       `json:"config"`
+	Routes       ResourceDiff `json:"routes"`
+	StreamRoutes ResourceDiff `json:"streamRoutes"`
+	// Services compares services without their routes, which Routes and StreamRoutes
+	// cover.
+	Services ResourceDiff `json:"services"`
+	SSLs     ResourceDiff `json:"ssls"`
+	// Consumers are named by username.
+	Consumers ResourceDiff `json:"consumers"`
+	// GlobalRules are named by plugin, as the data plane merges them into one.
+	GlobalRules    ResourceDiff `json:"globalRules"`
+	PluginMetadata ResourceDiff `json:"pluginMetadata"`
+}
+
+// Empty reports whether the change would push nothing.
+func (p *Plan) Empty() bool {
+	for _, diff := range p.diffs() {
+		if !diff.Empty() {
+			return false
+		}
+	}
+	return true
+}
+
+// Summary is a one-line account of the plan, such as
+// "routes +1 ~0 -0, services +1 ~0 -0".
+func (p *Plan) Summary() string {
+	if p.Empty() {
+		return "no changes"
+	}
+	diffs := p.diffs()
+	var parts []string
+	for _, name := range []string{"routes", "stream routes", "services", "ssls", "consumers", "global rules", "plugin metadata"} {
+		if diff := diffs[name]; !diff.Empty() {
+			parts = append(parts, name+" "+diff.String())
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (p *Plan) diffs() map[string]ResourceDiff {
+	return map[string]ResourceDiff{
+		"routes":          p.Routes,
+		"stream routes":   p.StreamRoutes,
+		"services":        p.Services,
+		"ssls":            p.SSLs,
+		"consumers":       p.Consumers,
+		"global rules":    p.GlobalRules,
+		"plugin metadata": p.PluginMetadata,
+	}
+}
+
+// Plan works out what inserting resources, with the resource types and labels Insert
+// takes, into the cache of config name would change, without changing it. It reports
+// false when the store holds nothing for name, which leaves nothing to compare with.
+func (s *Store) Plan(name string, resourceTypes []string, resources *adctypes.Resources, labels map[string]string) (*Plan, bool, error) {
+	preview, ok, err := s.clone(name)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+	before, err := preview.GetResources(name)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := preview.Insert(name, resourceTypes, resources, labels); err != nil {
+		return nil, false, err
+	}
+	after, err := preview.GetResources(name)
+	if err != nil {
+		return nil, false, err
+	}
+	return diffResources(name, before, after), true, nil
+}
+
+// clone copies the cache of config name into a store of its own.
+func (s *Store) clone(name string) (*Store, bool, error) {
+	s.Lock()
+	defer s.Unlock()
+	source, ok := s.cacheMap[name]
+	if !ok {
+		return nil, false, nil
+	}
+	target, err := NewMemDBCache()
+	if err != nil {
+		return nil, false, err
+	}
+	// The objects are shared: the store never changes one in place, it replaces it.
+	services, _ := source.ListServices()
+	for _, service := range services {
+		if err := target.InsertService(service); err != nil {
+			return nil, false, err
+		}
+	}
+	ssls, _ := source.ListSSL()
+	for _, ssl := range ssls {
+		if err := target.InsertSSL(ssl); err != nil {
+			return nil, false, err
+		}
+	}
+	consumers, _ := source.ListConsumers()
+	for _, consumer := range consumers {
+		if err := target.InsertConsumer(consumer); err != nil {
+			return nil, false, err
+		}
+	}
+	globalRules, _ := source.ListGlobalRules()
+	for _, globalRule := range globalRules {
+		if err := target.InsertGlobalRule(globalRule); err != nil {
+			return nil, false, err
+		}
+	}
+
+	preview := NewStore(s.log)
+	preview.cacheMap[name] = target
+	if metadata, ok := s.pluginMetadataMap[name]; ok {
+		preview.pluginMetadataMap[name] = metadata
+	}
+	return preview, true, nil
+}
+
+func diffResources(name string, before, after *adctypes.Resources) *Plan {
+	plan := &Plan{Config: name}
+
+	routes := func(resources *adctypes.Resources) map[string]any {
+		objects := make(map[string]any)
+		for _, service := range resources.Services {
+			for _, route := range service.Routes {
+				objects[route.ID] = route
+			}
+		}
+		return objects
+	}
+	plan.Routes = diffObjects(routes(before), routes(after))
+
+	streamRoutes := func(resources *adctypes.Resources) map[string]any {
+		objects := make(map[string]any)
+		for _, service := range resources.Services {
+			for _, route := range service.StreamRoutes {
+				objects[route.ID] = route
+			}
+		}
+		return objects
+	}
+	plan.StreamRoutes = diffObjects(streamRoutes(before), streamRoutes(after))
+
+	services := func(resources *adctypes.Resources) map[string]any {
+		objects := make(map[string]any)
+		for _, service := range resources.Services {
+			withoutRoutes := *service
+			withoutRoutes.Routes, withoutRoutes.StreamRoutes = nil, nil
+			objects[service.ID] = withoutRoutes
+		}
+		return objects
+	}
+	plan.Services = diffObjects(services(before), services(after))
+
+	ssls := func(resources *adctypes.Resources) map[string]any {
+		objects := make(map[string]any)
+		for _, ssl := range resources.SSLs {
+			objects[ssl.ID] = ssl
+		}
+		return objects
+	}
+	plan.SSLs = diffObjects(ssls(before), ssls(after))
+
+	consumers := func(resources *adctypes.Resources) map[string]any {
+		objects := make(map[string]any)
+		for _, consumer := range resources.Consumers {
+			objects[consumer.Username] = consumer
+		}
+		return objects
+	}
+	plan.Consumers = diffObjects(consumers(before), consumers(after))
+
+	plan.GlobalRules = diffObjects(pluginObjects(before.GlobalRules), pluginObjects(after.GlobalRules))
+	plan.PluginMetadata = diffObjects(pluginObjects(before.PluginMetadata), pluginObjects(after.PluginMetadata))
+	return plan
+}
+
+func pluginObjects[T ~map[string]any](plugins T) map[string]any {
+	objects := make(map[string]any, len(plugins))
+	for name, config := range plugins {
+		objects[name] = config
+	}
+	return objects
+}
+
+func diffObjects(before, after map[string]any) ResourceDiff {
+	var diff ResourceDiff
+	for id, obj := range after {
+		prev, ok := before[id]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, id)
+		case hashObject(prev) != hashObject(obj):
+			diff.Changed = append(diff.Changed, id)
+		}
+	}
+	for id := range before {
+		if _, ok := after[id]; !ok {
+			diff.Removed = append(diff.Removed, id)
+		}
+	}
+	slices.Sort(diff.Added)
+	slices.Sort(diff.Changed)
+	slices.Sort(diff.Removed)
+	return diff
+}
@@ -0,0 +1,93 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+)
+
+func planRoute(t *testing.T, s *Store, name string, routes ...string) *Plan {
+	t.Helper()
+	labels := routeLabels(name)
+	service := &adctypes.Service{Metadata: adctypes.Metadata{ID: name, Name: name, Labels: labels}}
+	for _, route := range routes {
+		service.Routes = append(service.Routes, &adctypes.Route{
+			Metadata: adctypes.Metadata{ID: route, Labels: labels},
+			Uris:     []string{"/" + route},
+		})
+	}
+	plan, ok, err := s.Plan(deltaConfig, []string{adctypes.TypeService},
+		&adctypes.Resources{Services: []*adctypes.Service{service}}, labels)
+	require.NoError(t, err)
+	require.True(t, ok)
+	return plan
+}
+
+func TestStorePlanDiffsAgainstTheCurrentStore(t *testing.T) {
+	s := NewStore(logr.Discard())
+	insertRoute(t, s, "a", "a.example.com")
+	insertRoute(t, s, "b", "b.example.com")
+
+	plan := planRoute(t, s, "a", "a-1")
+	assert.Equal(t, ResourceDiff{Added: []string{"a-1"}}, plan.Routes)
+	assert.Equal(t, ResourceDiff{Changed: []string{"a"}}, plan.Services, "the service lost its hosts")
+	assert.Equal(t, "routes +1 ~0 -0, services +0 ~1 -0", plan.Summary())
+
+	resources, err := s.GetResources(deltaConfig)
+	require.NoError(t, err)
+	assert.Len(t, resources.Services, 2)
+	for _, service := range resources.Services {
+		assert.Empty(t, service.Routes, "planning leaves the store as it was")
+	}
+}
+
+func TestStorePlanReportsRemovedObjects(t *testing.T) {
+	s := NewStore(logr.Discard())
+	insertRoute(t, s, "a", "a.example.com")
+
+	plan, ok, err := s.Plan(deltaConfig, []string{adctypes.TypeService}, &adctypes.Resources{}, routeLabels("a"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, ResourceDiff{Removed: []string{"a"}}, plan.Services)
+
+	_, ok, err = s.Plan("GatewayProxy/default/other", []string{adctypes.TypeService}, &adctypes.Resources{}, routeLabels("a"))
+	require.NoError(t, err)
+	assert.False(t, ok, "there is nothing to compare with")
+}
+
+func TestStorePlanIsEmptyForWhatTheStoreHolds(t *testing.T) {
+	s := NewStore(logr.Discard())
+	insertRoute(t, s, "a", "a.example.com")
+
+	plan, ok, err := s.Plan(deltaConfig, []string{adctypes.TypeService}, &adctypes.Resources{
+		Services: []*adctypes.Service{{
+			Metadata: adctypes.Metadata{ID: "a", Name: "a", Labels: routeLabels("a")},
+			Hosts:    []string{"a.example.com"},
+		}},
+	}, routeLabels("a"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.True(t, plan.Empty())
+	assert.Equal(t, "no changes", plan.Summary())
+}
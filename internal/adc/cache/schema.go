@@ -44,6 +44,71 @@ var (
 						AllowMissing: true,
 						Indexer:      &KindLabelIndexer,
 					},
+					PolicyLabelIndex: {
+						Name:         PolicyLabelIndex,
+						Unique:       false,
+						AllowMissing: true,
+						Indexer:      &PolicyLabelIndexer{},
+					},
+				},
+			},
+			"route": {
+				Name: "route",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": {
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.StringFieldIndex{Field: "ID"},
+					},
+					"service_id": {
+						Name:    "service_id",
+						Unique:  false,
+						Indexer: &memdb.StringFieldIndex{Field: "ServiceID"},
+					},
+					KindLabelIndex: {
+						Name:         KindLabelIndex,
+						Unique:       false,
+						AllowMissing: true,
+						Indexer:      &KindLabelIndexer,
+					},
+					HostIndex: {
+						Name:    HostIndex,
+						Unique:  false,
+						Indexer: &RouteHostIndexer{},
+					},
+					URIIndex: {
+						Name:         URIIndex,
+						Unique:       false,
+						AllowMissing: true,
+						Indexer:      &memdb.StringSliceFieldIndex{Field: "Uris"},
+					},
+				},
+			},
+			"stream_route": {
+				Name: "stream_route",
+				Indexes: map[string]*memdb.IndexSchema{
+					"id": {
+						Name:    "id",
+						Unique:  true,
+						Indexer: &memdb.StringFieldIndex{Field: "ID"},
+					},
+					"service_id": {
+						Name:    "service_id",
+						Unique:  false,
+						Indexer: &memdb.StringFieldIndex{Field: "ServiceID"},
+					},
+					KindLabelIndex: {
+						Name:         KindLabelIndex,
+						Unique:       false,
+						AllowMissing: true,
+						Indexer:      &KindLabelIndexer,
+					},
+					SNIIndex: {
+						Name:         SNIIndex,
+						Unique:       false,
+						AllowMissing: true,
+						Indexer:      &memdb.StringFieldIndex{Field: "SNI", Lowercase: true},
+					},
 				},
 			},
 			"ssl": {
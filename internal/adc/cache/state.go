@@ -0,0 +1,202 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cache
+
+import (
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/controller/label"
+)
+
+// StoreState is everything a Store holds, in a form that can be saved and restored.
+type StoreState struct {
+	Configs map[string]*ConfigState `json:"configs"`
+}
+
+// ConfigState is everything a Store holds for one config.
+type ConfigState struct {
+	Services       []*adctypes.Service        `json:"services,omitempty"`
+	SSLs           []*adctypes.SSL            `json:"ssls,omitempty"`
+	Consumers      []*adctypes.Consumer       `json:"consumers,omitempty"`
+	GlobalRules    []*adctypes.GlobalRuleItem `json:"globalRules,omitempty"`
+	PluginMetadata adctypes.PluginMetadata    `json:"pluginMetadata,omitempty"`
+}
+
+// State returns everything the store holds.
+func (s *Store) State() (*StoreState, error) {
+	s.Lock()
+	defer s.Unlock()
+	state := &StoreState{Configs: make(map[string]*ConfigState, len(s.cacheMap))}
+	for name, targetCache := range s.cacheMap {
+		services, err := targetCache.ListServices()
+		if err != nil {
+			return nil, err
+		}
+		ssls, err := targetCache.ListSSL()
+		if err != nil {
+			return nil, err
+		}
+		consumers, err := targetCache.ListConsumers()
+		if err != nil {
+			return nil, err
+		}
+		globalRules, err := targetCache.ListGlobalRules()
+		if err != nil {
+			return nil, err
+		}
+		state.Configs[name] = &ConfigState{
+			Services:       services,
+			SSLs:           ssls,
+			Consumers:      consumers,
+			GlobalRules:    globalRules,
+			PluginMetadata: s.pluginMetadataMap[name],
+		}
+	}
+	return state, nil
+}
+
+// Restore adds what state holds to the store, except the objects of owners the store
+// already holds objects of: those were translated since, and are newer. Until an owner
+// restored is inserted or deleted again, DropRestored removes its objects.
+func (s *Store) Restore(state *StoreState) error {
+	s.Lock()
+	defer s.Unlock()
+	for name, config := range state.Configs {
+		targetCache, ok := s.cacheMap[name]
+		if !ok {
+			db, err := NewMemDBCache()
+			if err != nil {
+				return err
+			}
+			s.cacheMap[name] = db
+			targetCache = db
+		}
+		current := make(map[Owner]struct{})
+		for _, st := range s.contentHashes[name] {
+			current[st.owner] = struct{}{}
+		}
+		globalRules, err := targetCache.ListGlobalRules()
+		if err != nil {
+			return err
+		}
+		for _, globalRule := range globalRules {
+			current[ownerOf(globalRule.Labels)] = struct{}{}
+		}
+		restore := func(labels map[string]string) bool {
+			owner := ownerOf(labels)
+			if _, ok := current[owner]; ok {
+				return false
+			}
+			if s.restored[name] == nil {
+				s.restored[name] = make(map[Owner]struct{})
+			}
+			s.restored[name][owner] = struct{}{}
+			return true
+		}
+
+		for _, service := range config.Services {
+			if !restore(service.Labels) {
+				continue
+			}
+			if err := targetCache.InsertService(service); err != nil {
+				return err
+			}
+			s.setHash(name, adctypes.TypeService, service.ID, service, service.Labels)
+		}
+		for _, ssl := range config.SSLs {
+			if !restore(ssl.Labels) {
+				continue
+			}
+			if err := targetCache.InsertSSL(ssl); err != nil {
+				return err
+			}
+			s.setHash(name, adctypes.TypeSSL, ssl.ID, ssl, ssl.Labels)
+		}
+		for _, consumer := range config.Consumers {
+			if !restore(consumer.Labels) {
+				continue
+			}
+			if err := targetCache.InsertConsumer(consumer); err != nil {
+				return err
+			}
+			s.setHash(name, adctypes.TypeConsumer, consumer.Username, consumer, consumer.Labels)
+		}
+		for _, globalRule := range config.GlobalRules {
+			if !restore(globalRule.Labels) {
+				continue
+			}
+			if err := targetCache.InsertGlobalRule(globalRule); err != nil {
+				return err
+			}
+		}
+		if _, ok := s.pluginMetadataMap[name]; !ok && config.PluginMetadata != nil {
+			s.pluginMetadataMap[name] = config.PluginMetadata
+		}
+	}
+	return nil
+}
+
+// DropRestored removes the objects of every owner Restore added that was not inserted
+// or deleted since, which is what was deleted while nothing was watching. It returns the
+// owners whose objects it removed, by config.
+func (s *Store) DropRestored() map[string][]Owner {
+	s.Lock()
+	defer s.Unlock()
+	dropped := make(map[string][]Owner)
+	for name, owners := range s.restored {
+		targetCache, ok := s.cacheMap[name]
+		if !ok {
+			continue
+		}
+		for owner := range owners {
+			selector := &KindLabelSelector{Kind: owner.Kind, Namespace: owner.Namespace, Name: owner.Name}
+			services, _ := targetCache.ListServices(selector)
+			for _, service := range services {
+				if err := targetCache.DeleteService(service); err == nil {
+					s.dropHash(name, adctypes.TypeService, service.ID)
+				}
+			}
+			ssls, _ := targetCache.ListSSL(selector)
+			for _, ssl := range ssls {
+				if err := targetCache.DeleteSSL(ssl); err == nil {
+					s.dropHash(name, adctypes.TypeSSL, ssl.ID)
+				}
+			}
+			consumers, _ := targetCache.ListConsumers(selector)
+			for _, consumer := range consumers {
+				if err := targetCache.DeleteConsumer(consumer); err == nil {
+					s.dropHash(name, adctypes.TypeConsumer, consumer.Username)
+				}
+			}
+			globalRules, _ := targetCache.ListGlobalRules(selector)
+			for _, globalRule := range globalRules {
+				_ = targetCache.DeleteGlobalRule(globalRule)
+			}
+			dropped[name] = append(dropped[name], owner)
+		}
+	}
+	s.restored = make(map[string]map[Owner]struct{})
+	return dropped
+}
+
+func ownerOf(labels map[string]string) Owner {
+	return Owner{
+		Kind:      labels[label.LabelKind],
+		Namespace: labels[label.LabelNamespace],
+		Name:      labels[label.LabelName],
+	}
+}
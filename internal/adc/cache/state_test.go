@@ -0,0 +1,53 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/controller/label"
+)
+
+func TestStoreRestoreKeepsWhatWasTranslatedSince(t *testing.T) {
+	labels := map[string]string{label.LabelKind: "HTTPRoute", label.LabelNamespace: "default", label.LabelName: "web"}
+	service := func(desc string) *adctypes.Resources {
+		return &adctypes.Resources{Services: []*adctypes.Service{{
+			Metadata: adctypes.Metadata{ID: "svc", Desc: desc, Labels: labels},
+		}}}
+	}
+
+	saved := NewStore(logr.Discard())
+	require.NoError(t, saved.Insert("gp", []string{adctypes.TypeService}, service("saved"), labels))
+	state, err := saved.State()
+	require.NoError(t, err)
+
+	s := NewStore(logr.Discard())
+	require.NoError(t, s.Insert("gp", []string{adctypes.TypeService}, service("translated"), labels))
+	require.NoError(t, s.Restore(state))
+
+	resources, err := s.GetResources("gp")
+	require.NoError(t, err)
+	require.Len(t, resources.Services, 1)
+	assert.Equal(t, "translated", resources.Services[0].Desc)
+	assert.Empty(t, s.DropRestored(), "nothing was restored")
+}
@@ -18,6 +18,7 @@
 package cache
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 
@@ -32,6 +33,15 @@ type Store struct {
 	cacheMap          map[string]Cache
 	pluginMetadataMap map[string]adctypes.PluginMetadata
 
+	// contentHashes holds, per config, the content hash of every service, SSL and
+	// consumer in its cache, and syncedHashes what they were when last pushed.
+	// See Delta.
+	contentHashes map[string]map[ObjectKey]objectState
+	syncedHashes  map[string]*syncedState
+	// restored holds, per config, the owners Restore added objects of that were not
+	// inserted or deleted since. See DropRestored.
+	restored map[string]map[Owner]struct{}
+
 	sync.Mutex
 	log logr.Logger
 }
@@ -40,6 +50,9 @@ func NewStore(log logr.Logger) *Store {
 	return &Store{
 		cacheMap:          make(map[string]Cache),
 		pluginMetadataMap: make(map[string]adctypes.PluginMetadata),
+		contentHashes:     make(map[string]map[ObjectKey]objectState),
+		syncedHashes:      make(map[string]*syncedState),
+		restored:          make(map[string]map[Owner]struct{}),
 		log:               log.WithName("store"),
 	}
 }
@@ -62,6 +75,7 @@ func (s *Store) Insert(name string, resourceTypes []string, resources *adctypes.
 		Name:      Labels[label.LabelName],
 		Namespace: Labels[label.LabelNamespace],
 	}
+	delete(s.restored[name], ownerOf(Labels))
 	for _, resourceType := range resourceTypes {
 		switch resourceType {
 		case adctypes.TypeService:
@@ -73,11 +87,13 @@ func (s *Store) Insert(name string, resourceTypes []string, resources *adctypes.
 				if err := targetCache.DeleteService(service); err != nil {
 					return err
 				}
+				s.dropHash(name, adctypes.TypeService, service.ID)
 			}
 			for _, service := range resources.Services {
 				if err := targetCache.InsertService(service); err != nil {
 					return err
 				}
+				s.setHash(name, adctypes.TypeService, service.ID, service, service.Labels)
 			}
 		case adctypes.TypeConsumer:
 			consumers, err := targetCache.ListConsumers(selector)
@@ -88,11 +104,13 @@ func (s *Store) Insert(name string, resourceTypes []string, resources *adctypes.
 				if err := targetCache.DeleteConsumer(consumer); err != nil {
 					return err
 				}
+				s.dropHash(name, adctypes.TypeConsumer, consumer.Username)
 			}
 			for _, consumer := range resources.Consumers {
 				if err := targetCache.InsertConsumer(consumer); err != nil {
 					return err
 				}
+				s.setHash(name, adctypes.TypeConsumer, consumer.Username, consumer, consumer.Labels)
 			}
 		case adctypes.TypeSSL:
 			ssls, err := targetCache.ListSSL(selector)
@@ -104,11 +122,13 @@ func (s *Store) Insert(name string, resourceTypes []string, resources *adctypes.
 				if err := targetCache.DeleteSSL(ssl); err != nil {
 					return err
 				}
+				s.dropHash(name, adctypes.TypeSSL, ssl.ID)
 			}
 			for _, ssl := range resources.SSLs {
 				if err := targetCache.InsertSSL(ssl); err != nil {
 					return err
 				}
+				s.setHash(name, adctypes.TypeSSL, ssl.ID, ssl, ssl.Labels)
 			}
 		case adctypes.TypeGlobalRule:
 			// List existing global rules that match the selector
@@ -158,6 +178,7 @@ func (s *Store) Delete(name string, resourceTypes []string, Labels map[string]st
 		Name:      Labels[label.LabelName],
 		Namespace: Labels[label.LabelNamespace],
 	}
+	delete(s.restored[name], ownerOf(Labels))
 	for _, resourceType := range resourceTypes {
 		switch resourceType {
 		case adctypes.TypeService:
@@ -169,6 +190,7 @@ func (s *Store) Delete(name string, resourceTypes []string, Labels map[string]st
 				if err := targetCache.DeleteService(service); err != nil {
 					s.log.Error(err, "failed to delete service", "service", service.ID)
 				}
+				s.dropHash(name, adctypes.TypeService, service.ID)
 			}
 		case adctypes.TypeSSL:
 			ssls, err := targetCache.ListSSL(selector)
@@ -179,6 +201,7 @@ func (s *Store) Delete(name string, resourceTypes []string, Labels map[string]st
 				if err := targetCache.DeleteSSL(ssl); err != nil {
 					s.log.Error(err, "failed to delete ssl", "ssl", ssl.ID)
 				}
+				s.dropHash(name, adctypes.TypeSSL, ssl.ID)
 			}
 		case adctypes.TypeConsumer:
 			consumers, err := targetCache.ListConsumers(selector)
@@ -189,6 +212,7 @@ func (s *Store) Delete(name string, resourceTypes []string, Labels map[string]st
 				if err := targetCache.DeleteConsumer(consumer); err != nil {
 					s.log.Error(err, "failed to delete consumer", "consumer", consumer.Username)
 				}
+				s.dropHash(name, adctypes.TypeConsumer, consumer.Username)
 			}
 		case adctypes.TypeGlobalRule:
 			globalRules, err := targetCache.ListGlobalRules(selector)
@@ -206,6 +230,9 @@ func (s *Store) Delete(name string, resourceTypes []string, Labels map[string]st
 	}
 	if len(resourceTypes) == 0 {
 		delete(s.cacheMap, name)
+		delete(s.contentHashes, name)
+		delete(s.syncedHashes, name)
+		delete(s.restored, name)
 	}
 	return nil
 }
@@ -217,6 +244,23 @@ func (s *Store) GetResources(name string) (*adctypes.Resources, error) {
 	if !ok {
 		return &adctypes.Resources{}, nil
 	}
+	globalrule, metadata := s.globals(name, targetCache)
+	s.log.V(1).Info("GetResources fetched global rule items", "pluginCount", len(globalrule))
+	consumers, _ := targetCache.ListConsumers()
+	services, _ := targetCache.ListServices()
+	ssls, _ := targetCache.ListSSL()
+	return &adctypes.Resources{
+		Consumers:      consumers,
+		Services:       services,
+		SSLs:           ssls,
+		GlobalRules:    globalrule,
+		PluginMetadata: metadata,
+	}, nil
+}
+
+// globals merges the global rules of config name and copies its plugin metadata. It
+// must be called with the store locked.
+func (s *Store) globals(name string, targetCache Cache) (adctypes.GlobalRule, adctypes.PluginMetadata) {
 	var globalrule adctypes.GlobalRule
 	var metadata adctypes.PluginMetadata
 	// Get all global rules from cache and merge them
@@ -230,20 +274,10 @@ func (s *Store) GetResources(name string) (*adctypes.Resources, error) {
 		}
 		globalrule = adctypes.GlobalRule(merged)
 	}
-	s.log.V(1).Info("GetResources fetched global rule items", "itemCount", len(globalRuleItems), "pluginCount", len(globalrule))
 	if meta, ok := s.pluginMetadataMap[name]; ok {
 		metadata = meta.DeepCopy()
 	}
-	consumers, _ := targetCache.ListConsumers()
-	services, _ := targetCache.ListServices()
-	ssls, _ := targetCache.ListSSL()
-	return &adctypes.Resources{
-		Consumers:      consumers,
-		Services:       services,
-		SSLs:           ssls,
-		GlobalRules:    globalrule,
-		PluginMetadata: metadata,
-	}, nil
+	return globalrule, metadata
 }
 
 func (s *Store) ListGlobalRules(name string) ([]*adctypes.GlobalRuleItem, error) {
@@ -260,6 +294,37 @@ func (s *Store) ListGlobalRules(name string) ([]*adctypes.GlobalRuleItem, error)
 	return globalRules, nil
 }
 
+// ListRoutes lists the routes of the config with name, those opts select if any, such as
+// the routes served on a host.
+func (s *Store) ListRoutes(name string, opts ...ListOption) ([]*adctypes.Route, error) {
+	s.Lock()
+	defer s.Unlock()
+	targetCache, ok := s.cacheMap[name]
+	if !ok {
+		return nil, fmt.Errorf("cache not found for name: %s", name)
+	}
+	routes, err := targetCache.ListRoutes(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list routes: %w", err)
+	}
+	return routes, nil
+}
+
+// ListStreamRoutes lists the stream routes of the config with name, those opts select if any.
+func (s *Store) ListStreamRoutes(name string, opts ...ListOption) ([]*adctypes.StreamRoute, error) {
+	s.Lock()
+	defer s.Unlock()
+	targetCache, ok := s.cacheMap[name]
+	if !ok {
+		return nil, fmt.Errorf("cache not found for name: %s", name)
+	}
+	streamRoutes, err := targetCache.ListStreamRoutes(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stream routes: %w", err)
+	}
+	return streamRoutes, nil
+}
+
 func (s *Store) GetResourceLabel(name, resourceType string, id string) (map[string]string, error) {
 	s.Lock()
 	defer s.Unlock()
@@ -275,19 +340,14 @@ func (s *Store) GetResourceLabel(name, resourceType string, id string) (map[stri
 		}
 		return service.Labels, nil
 	case adctypes.TypeRoute:
-		services, err := targetCache.ListServices()
+		route, err := targetCache.GetRoute(id)
 		if err != nil {
-			return nil, fmt.Errorf("failed to list services: %w", err)
-		}
-		for _, service := range services {
-			for _, route := range service.Routes {
-				if route.ID == id {
-					// Return labels from the service that contains the route
-					return route.GetLabels(), nil
-				}
+			if errors.Is(err, ErrNotFound) {
+				return nil, fmt.Errorf("route not found: %s", id)
 			}
+			return nil, fmt.Errorf("failed to get route: %w", err)
 		}
-		return nil, fmt.Errorf("route not found: %s", id)
+		return route.GetLabels(), nil
 	case adctypes.TypeSSL:
 		ssl, err := targetCache.GetSSL(id)
 		if err != nil {
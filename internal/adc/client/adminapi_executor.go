@@ -0,0 +1,1016 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/id"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+const (
+	adminAPIPrefix = "/apisix/admin"
+	// adminAPIConfigs is the endpoint an APISIX standalone data plane takes its whole
+	// configuration from, one document carrying every resource type.
+	adminAPIConfigs = "/configs"
+	// adminAPISchemaValidate is the prefix of the endpoints that check an object against
+	// the schema of its collection without writing it.
+	adminAPISchemaValidate = "/schema/validate/"
+
+	collectionUpstreams      = "upstreams"
+	collectionServices       = "services"
+	collectionRoutes         = "routes"
+	collectionStreamRoutes   = "stream_routes"
+	collectionSSLs           = "ssls"
+	collectionConsumers      = "consumers"
+	collectionCredentials    = "credentials"
+	collectionGlobalRules    = "global_rules"
+	collectionPluginMetadata = "plugin_metadata"
+)
+
+// adminAPICreateOrder is the order objects are written in, so that nothing is written
+// before what it refers to exists. Deletes run in reverse.
+var adminAPICreateOrder = []string{
+	collectionUpstreams,
+	collectionServices,
+	collectionRoutes,
+	collectionStreamRoutes,
+	collectionSSLs,
+	collectionConsumers,
+	collectionCredentials,
+	collectionGlobalRules,
+	collectionPluginMetadata,
+}
+
+// adminAPIValidatedCollections are the collections Validate checks. Credentials and plugin
+// metadata have no schema validation endpoint of their own.
+var adminAPIValidatedCollections = []string{
+	collectionUpstreams,
+	collectionServices,
+	collectionRoutes,
+	collectionStreamRoutes,
+	collectionSSLs,
+	collectionConsumers,
+	collectionGlobalRules,
+}
+
+// errAdminAPIValidationUnsupported is returned by Validate for a data plane that cannot
+// check a configuration without applying it.
+var errAdminAPIValidationUnsupported = errors.New("admin api schema validation is unsupported")
+
+// adminAPICollectionTypes maps each Admin API collection to the ADC resource types that
+// include it. A service in ADC carries its routes, stream routes and upstreams, so all of
+// them are in scope whenever services are.
+var adminAPICollectionTypes = map[string][]string{
+	collectionUpstreams:      {adctypes.TypeService},
+	collectionServices:       {adctypes.TypeService},
+	collectionRoutes:         {adctypes.TypeService, adctypes.TypeRoute},
+	collectionStreamRoutes:   {adctypes.TypeService},
+	collectionSSLs:           {adctypes.TypeSSL},
+	collectionConsumers:      {adctypes.TypeConsumer},
+	collectionCredentials:    {adctypes.TypeConsumer},
+	collectionGlobalRules:    {adctypes.TypeGlobalRule},
+	collectionPluginMetadata: {adctypes.TypePluginMetadata},
+}
+
+// adminAPIObject is one object as the Admin API stores it.
+type adminAPIObject struct {
+	collection string
+	// id is the object's key within its collection. For credentials it is
+	// "<username>/credentials/<id>", which is also how standalone mode keys them.
+	id   string
+	body map[string]any
+
+	// resourceType and resourceID name the ADC resource a failure is reported against,
+	// which for objects ADC nests -- routes, upstreams, credentials -- is the one whose
+	// labels point back at the Kubernetes object.
+	resourceType string
+	resourceID   string
+	resourceName string
+}
+
+// remoteAdminAPIObject names an object found on the data plane. Failing to delete it
+// is reported against the ADC resource type it was written for.
+func remoteAdminAPIObject(collection, id string) adminAPIObject {
+	obj := adminAPIObject{collection: collection, id: id, resourceID: id, resourceName: id}
+	switch collection {
+	case collectionServices:
+		obj.resourceType = adctypes.TypeService
+	case collectionRoutes:
+		obj.resourceType = adctypes.TypeRoute
+	case collectionSSLs:
+		obj.resourceType = adctypes.TypeSSL
+	case collectionConsumers:
+		obj.resourceType = adctypes.TypeConsumer
+	case collectionCredentials:
+		owner, _, _ := strings.Cut(id, "/")
+		obj.resourceType, obj.resourceID = adctypes.TypeConsumer, owner
+	case collectionGlobalRules:
+		obj.resourceType = adctypes.TypeGlobalRule
+	case collectionPluginMetadata:
+		obj.resourceType = adctypes.TypePluginMetadata
+	default:
+		obj.resourceType = strings.TrimSuffix(collection, "s")
+	}
+	return obj
+}
+
+func (o adminAPIObject) key() string {
+	return o.collection + "/" + o.id
+}
+
+func (o adminAPIObject) path() string {
+	if o.collection == collectionCredentials {
+		return "/" + collectionConsumers + "/" + o.id
+	}
+	return "/" + o.collection + "/" + o.id
+}
+
+// adminAPIBaseline is what an AdminAPIExecutor last wrote to one server for one cacheKey.
+// It plays the part of the ADC server's cache: objects whose body has not changed since
+// they were written are not written again.
+type adminAPIBaseline struct {
+	// mu is held for the whole of a sync, which reads and writes the maps below.
+	mu sync.Mutex
+	// objects maps an object key to the hash of the body last written there.
+	objects map[string]string
+	// versions and hashes hold, per collection, the conf_version last pushed to a
+	// standalone data plane and the hash of the in-scope items it carried.
+	versions map[string]int64
+	hashes   map[string]string
+}
+
+func newAdminAPIBaseline() *adminAPIBaseline {
+	return &adminAPIBaseline{
+		objects:  make(map[string]string),
+		versions: make(map[string]int64),
+		hashes:   make(map[string]string),
+	}
+}
+
+// AdminAPIExecutor implements ADCExecutor against the APISIX Admin API directly, without
+// an ADC server in between. It diffs the desired resources against what the data plane
+// holds and writes the difference: object by object through the Admin API in apisix
+// mode, and as one document through /apisix/admin/configs in apisix-standalone mode.
+//
+// Label selectors and included resource types scope the diff the same way they scope
+// an ADC sync: only remote objects whose labels match the selector, in the included
+// types, are candidates for deletion, and the selector labels are added to every object
+// written. Global rules and plugin metadata carry no labels, so they are only in scope
+// for a sync without a selector.
+type AdminAPIExecutor struct {
+	httpClient         *http.Client
+	insecureHTTPClient *http.Client
+	log                logr.Logger
+
+	mu        sync.Mutex
+	baselines map[string]*adminAPIBaseline
+}
+
+// NewAdminAPIExecutor creates a new AdminAPIExecutor. timeout bounds each Admin API
+// request on its own, not a whole sync, which makes one request per changed object.
+func NewAdminAPIExecutor(log logr.Logger, timeout time.Duration) *AdminAPIExecutor {
+	insecureTransport := http.DefaultTransport.(*http.Transport).Clone()
+	insecureTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec // opted out through tlsVerify
+
+	return &AdminAPIExecutor{
+		httpClient:         &http.Client{Timeout: timeout},
+		insecureHTTPClient: &http.Client{Timeout: timeout, Transport: insecureTransport},
+		log:                log.WithName("adminapi-executor"),
+		baselines:          make(map[string]*adminAPIBaseline),
+	}
+}
+
+// Execute implements the ADCExecutor interface.
+func (e *AdminAPIExecutor) Execute(ctx context.Context, config adctypes.Config, req ADCRequest) error {
+	if req.CacheKey == "" {
+		req.CacheKey = config.Name
+	}
+	desired, err := buildAdminAPIObjects(req.Resources, req.Labels)
+	if err != nil {
+		return fmt.Errorf("failed to build admin api objects: %w", err)
+	}
+
+	execErrs := types.ADCExecutionError{Name: config.Name}
+	for _, addr := range config.ServerAddrs {
+		var err error
+		if config.BackendType == backendAPISIXStandalone {
+			err = e.syncStandalone(ctx, addr, config, req, desired)
+		} else {
+			err = e.syncAPISIX(ctx, addr, config, req, desired)
+		}
+		if err == nil {
+			continue
+		}
+		e.log.Error(err, "failed to sync to admin api", "server", addr)
+		var serverErr types.ADCExecutionServerAddrError
+		if !errors.As(err, &serverErr) {
+			serverErr = types.ADCExecutionServerAddrError{ServerAddr: addr, Err: err.Error()}
+		}
+		execErrs.FailedErrors = append(execErrs.FailedErrors, serverErr)
+	}
+	if len(execErrs.FailedErrors) > 0 {
+		return execErrs
+	}
+	return nil
+}
+
+// Validate implements the ADCExecutor interface. Each object is checked against the
+// schema of its collection through the Admin API's /schema/validate endpoints, which
+// write nothing. A standalone data plane, or one too old to have those endpoints, cannot
+// validate: Validate then fails with errAdminAPIValidationUnsupported rather than
+// accepting what it did not check.
+func (e *AdminAPIExecutor) Validate(ctx context.Context, config adctypes.Config, req ADCRequest) error {
+	if config.BackendType == backendAPISIXStandalone {
+		return fmt.Errorf("%w for backend %s", errAdminAPIValidationUnsupported, config.BackendType)
+	}
+	objects, err := buildAdminAPIObjects(req.Resources, req.Labels)
+	if err != nil {
+		return fmt.Errorf("failed to build admin api objects: %w", err)
+	}
+
+	validationErr := types.ADCValidationError{Name: config.Name}
+	var infraErrs []error
+	for _, addr := range config.ServerAddrs {
+		details, err := e.validate(ctx, addr, config, objects)
+		if err != nil {
+			e.log.Error(err, "failed to validate against admin api", "server", addr)
+			infraErrs = append(infraErrs, fmt.Errorf("server %s: %w", addr, err))
+			continue
+		}
+		if len(details) > 0 {
+			validationErr.FailedErrors = append(validationErr.FailedErrors, types.ADCValidationServerAddrError{
+				ServerAddr:       addr,
+				Err:              "admin api rejected the configuration",
+				ValidationErrors: details,
+			})
+		}
+	}
+	if len(validationErr.FailedErrors) > 0 {
+		return validationErr
+	}
+	if len(infraErrs) > 0 {
+		return errors.Join(infraErrs...)
+	}
+	return nil
+}
+
+// validate checks objects against the schemas server holds, and returns what it rejected.
+func (e *AdminAPIExecutor) validate(
+	ctx context.Context,
+	server string,
+	config adctypes.Config,
+	objects []adminAPIObject,
+) ([]types.ADCValidationDetail, error) {
+	var details []types.ADCValidationDetail
+	for _, obj := range objects {
+		if !slices.Contains(adminAPIValidatedCollections, obj.collection) {
+			continue
+		}
+		status, err := e.do(ctx, server, config, http.MethodPost, adminAPISchemaValidate+obj.collection, obj.body)
+		switch {
+		case err == nil:
+		case status == http.StatusNotFound:
+			return nil, fmt.Errorf("%w: %s", errAdminAPIValidationUnsupported, err)
+		case status == http.StatusBadRequest:
+			details = append(details, types.ADCValidationDetail{
+				ResourceType: obj.resourceType,
+				ResourceName: obj.resourceName,
+				Message:      err.Error(),
+			})
+		default:
+			return nil, err
+		}
+	}
+	return details, nil
+}
+
+// baseline returns the baseline for cacheKey on server, dropping it first when the
+// request asks for the data plane to be re-read.
+func (e *AdminAPIExecutor) baseline(config adctypes.Config, req ADCRequest, server string) *adminAPIBaseline {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	key := req.CacheKey + "|" + server
+	b, ok := e.baselines[key]
+	if !ok || config.BypassCache {
+		b = newAdminAPIBaseline()
+		e.baselines[key] = b
+	}
+	return b
+}
+
+func (e *AdminAPIExecutor) syncAPISIX(
+	ctx context.Context,
+	server string,
+	config adctypes.Config,
+	req ADCRequest,
+	desired []adminAPIObject,
+) error {
+	selector, resourceTypes := req.Labels, req.ResourceTypes
+	baseline := e.baseline(config, req, server)
+	baseline.mu.Lock()
+	defer baseline.mu.Unlock()
+
+	remote, err := e.listRemote(ctx, server, config, selector, resourceTypes)
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[string]struct{}, len(desired))
+	for _, obj := range desired {
+		if collectionIncluded(obj.collection, resourceTypes) {
+			wanted[obj.key()] = struct{}{}
+		}
+	}
+
+	var failed []adctypes.SyncStatus
+
+	// Plugin metadata cannot be listed, so only what this executor wrote is deleted.
+	if collectionIncluded(collectionPluginMetadata, resourceTypes) && len(selector) == 0 {
+		for key := range baseline.objects {
+			if collection, id, _ := strings.Cut(key, "/"); collection == collectionPluginMetadata {
+				remote = append(remote, remoteAdminAPIObject(collection, id))
+			}
+		}
+	}
+	slices.SortStableFunc(remote, func(a, b adminAPIObject) int {
+		return slices.Index(adminAPICreateOrder, b.collection) - slices.Index(adminAPICreateOrder, a.collection)
+	})
+	remoteKeys := make(map[string]struct{}, len(remote))
+	for _, obj := range remote {
+		remoteKeys[obj.key()] = struct{}{}
+		if _, ok := wanted[obj.key()]; ok {
+			continue
+		}
+		status, err := e.do(ctx, server, config, http.MethodDelete, obj.path(), nil)
+		if err != nil && status != http.StatusNotFound {
+			failed = append(failed, failedStatus(obj, "delete", status, err))
+			continue
+		}
+		delete(baseline.objects, obj.key())
+	}
+
+	slices.SortStableFunc(desired, func(a, b adminAPIObject) int {
+		return slices.Index(adminAPICreateOrder, a.collection) - slices.Index(adminAPICreateOrder, b.collection)
+	})
+	for _, obj := range desired {
+		if _, ok := wanted[obj.key()]; !ok {
+			continue
+		}
+		hash, err := hashAdminAPIBody(obj.body)
+		if err != nil {
+			return err
+		}
+		_, exists := remoteKeys[obj.key()]
+		if obj.collection == collectionPluginMetadata {
+			exists = true
+		}
+		if exists && baseline.objects[obj.key()] == hash {
+			continue
+		}
+		status, err := e.do(ctx, server, config, http.MethodPut, obj.path(), obj.body)
+		if err != nil {
+			failed = append(failed, failedStatus(obj, "update", status, err))
+			delete(baseline.objects, obj.key())
+			continue
+		}
+		baseline.objects[obj.key()] = hash
+	}
+
+	if len(failed) > 0 {
+		return types.ADCExecutionServerAddrError{
+			ServerAddr:     server,
+			Err:            failed[0].Reason,
+			FailedStatuses: failed,
+		}
+	}
+	return nil
+}
+
+// listRemote lists the objects on server that are in scope for the selector and the
+// included resource types.
+func (e *AdminAPIExecutor) listRemote(
+	ctx context.Context,
+	server string,
+	config adctypes.Config,
+	selector map[string]string,
+	resourceTypes []string,
+) ([]adminAPIObject, error) {
+	var remote []adminAPIObject
+	for _, collection := range adminAPICreateOrder {
+		switch collection {
+		case collectionCredentials, collectionPluginMetadata:
+			// listed below with their consumers, and not listable, respectively
+			continue
+		case collectionGlobalRules:
+			if len(selector) > 0 {
+				continue
+			}
+		}
+		if !collectionIncluded(collection, resourceTypes) {
+			continue
+		}
+		items, err := e.list(ctx, server, config, "/"+collection)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if !matchesSelector(item, selector) {
+				continue
+			}
+			obj := remoteAdminAPIObject(collection, itemID(collection, item))
+			remote = append(remote, obj)
+			if collection != collectionConsumers || !collectionIncluded(collectionCredentials, resourceTypes) {
+				continue
+			}
+			credentials, err := e.list(ctx, server, config, "/"+collectionConsumers+"/"+obj.id+"/"+collectionCredentials)
+			if err != nil {
+				return nil, err
+			}
+			for _, credential := range credentials {
+				remote = append(remote, remoteAdminAPIObject(collectionCredentials,
+					obj.id+"/"+collectionCredentials+"/"+itemID(collectionCredentials, credential)))
+			}
+		}
+	}
+	return remote, nil
+}
+
+type adminAPIListResponse struct {
+	List []struct {
+		Value map[string]any `json:"value"`
+	} `json:"list"`
+}
+
+func (e *AdminAPIExecutor) list(ctx context.Context, server string, config adctypes.Config, path string) ([]map[string]any, error) {
+	req, err := e.newRequest(ctx, server, config, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	status, body, err := e.send(config, req)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return nil, nil
+	}
+	if status/100 != 2 {
+		return nil, fmt.Errorf("failed to list %s: HTTP %d: %s", path, status, string(body))
+	}
+	var resp adminAPIListResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		// lua-cjson encodes an empty list as an empty object
+		if bytes.Contains(body, []byte(`"list":{}`)) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", path, err)
+	}
+	items := make([]map[string]any, 0, len(resp.List))
+	for _, item := range resp.List {
+		if item.Value != nil {
+			items = append(items, item.Value)
+		}
+	}
+	return items, nil
+}
+
+// syncStandalone replaces the in-scope items of every changed collection in the
+// configuration a standalone data plane holds, and pushes the whole document back with
+// the conf_version of each changed collection moved forward.
+func (e *AdminAPIExecutor) syncStandalone(
+	ctx context.Context,
+	server string,
+	config adctypes.Config,
+	req ADCRequest,
+	desired []adminAPIObject,
+) error {
+	selector, resourceTypes := req.Labels, req.ResourceTypes
+	baseline := e.baseline(config, req, server)
+	baseline.mu.Lock()
+	defer baseline.mu.Unlock()
+
+	document, err := e.getConfigs(ctx, server, config)
+	if err != nil {
+		return err
+	}
+
+	byCollection := standaloneItems(desired)
+
+	var (
+		changed  []string
+		now      = time.Now().UnixMilli()
+		versions = make(map[string]int64)
+	)
+	for _, collection := range adminAPICreateOrder {
+		if collection == collectionCredentials || !collectionIncluded(collection, resourceTypes) {
+			continue
+		}
+		if len(selector) > 0 && (collection == collectionGlobalRules || collection == collectionPluginMetadata) {
+			continue
+		}
+
+		items := byCollection[collection]
+		hash, err := hashAdminAPIBody(items)
+		if err != nil {
+			return err
+		}
+		remoteVersion := confVersion(document, collection)
+		lastVersion, pushed := baseline.versions[collection]
+		if pushed && baseline.hashes[collection] == hash && lastVersion == remoteVersion {
+			continue
+		}
+
+		var merged []any
+		remoteItems, _ := document[collection].([]any)
+		inScopeConsumers := make(map[string]struct{})
+		for _, raw := range remoteItems {
+			item, ok := raw.(map[string]any)
+			if ok && collection == collectionConsumers && !isStandaloneCredential(item) && matchesSelector(item, selector) {
+				inScopeConsumers[itemID(collection, item)] = struct{}{}
+			}
+		}
+		for _, raw := range remoteItems {
+			item, ok := raw.(map[string]any)
+			if !ok {
+				merged = append(merged, raw)
+				continue
+			}
+			inScope := matchesSelector(item, selector)
+			if collection == collectionConsumers && isStandaloneCredential(item) {
+				owner, _, _ := strings.Cut(itemID(collection, item), "/")
+				_, inScope = inScopeConsumers[owner]
+			}
+			if !inScope {
+				merged = append(merged, item)
+			}
+		}
+		for _, item := range items {
+			merged = append(merged, item)
+		}
+		if merged == nil {
+			merged = []any{}
+		}
+		document[collection] = merged
+
+		version := max(remoteVersion+1, now)
+		document[collection+"_conf_version"] = version
+		versions[collection] = version
+		baseline.hashes[collection] = hash
+		changed = append(changed, collection)
+	}
+
+	if len(changed) == 0 {
+		e.log.V(1).Info("standalone configuration unchanged, skipping push", "server", server, "config", config.Name)
+		return nil
+	}
+
+	httpReq, err := e.newRequest(ctx, server, config, http.MethodPut, adminAPIConfigs, document)
+	if err != nil {
+		return err
+	}
+	status, body, err := e.send(config, httpReq)
+	if err == nil && status/100 != 2 {
+		err = fmt.Errorf("HTTP %d: %s", status, string(body))
+	}
+	if err != nil {
+		// The hashes recorded above describe a push that did not land.
+		for _, collection := range changed {
+			delete(baseline.hashes, collection)
+			delete(baseline.versions, collection)
+		}
+		return types.ADCExecutionServerAddrError{
+			ServerAddr:     server,
+			Err:            err.Error(),
+			FailedStatuses: []adctypes.SyncStatus{{Reason: err.Error(), FailedAt: time.Now()}},
+		}
+	}
+	maps.Copy(baseline.versions, versions)
+	e.log.V(1).Info("pushed standalone configuration", "server", server, "config", config.Name, "changed", changed)
+	return nil
+}
+
+// standaloneItems groups objects by the collection a standalone configuration holds them
+// in, each sorted by id.
+func standaloneItems(objects []adminAPIObject) map[string][]map[string]any {
+	byCollection := make(map[string][]map[string]any)
+	for _, obj := range objects {
+		collection := obj.collection
+		// standalone keeps credentials in the consumers list, keyed by their path
+		if collection == collectionCredentials {
+			collection = collectionConsumers
+		}
+		item := maps.Clone(obj.body)
+		if obj.collection != collectionConsumers {
+			item["id"] = obj.id
+		}
+		byCollection[collection] = append(byCollection[collection], item)
+	}
+	for collection, items := range byCollection {
+		sort.Slice(items, func(i, j int) bool {
+			return itemID(collection, items[i]) < itemID(collection, items[j])
+		})
+	}
+	return byCollection
+}
+
+func (e *AdminAPIExecutor) getConfigs(ctx context.Context, server string, config adctypes.Config) (map[string]any, error) {
+	req, err := e.newRequest(ctx, server, config, http.MethodGet, adminAPIConfigs, nil)
+	if err != nil {
+		return nil, err
+	}
+	status, body, err := e.send(config, req)
+	if err != nil {
+		return nil, err
+	}
+	document := make(map[string]any)
+	if status == http.StatusNotFound || len(bytes.TrimSpace(body)) == 0 {
+		return document, nil
+	}
+	if status/100 != 2 {
+		return nil, fmt.Errorf("failed to get configuration: HTTP %d: %s", status, string(body))
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal configuration: %w", err)
+	}
+	return document, nil
+}
+
+// do sends one Admin API request and returns its HTTP status.
+func (e *AdminAPIExecutor) do(ctx context.Context, server string, config adctypes.Config, method, path string, body any) (int, error) {
+	req, err := e.newRequest(ctx, server, config, method, path, body)
+	if err != nil {
+		return 0, err
+	}
+	status, respBody, err := e.send(config, req)
+	if err != nil {
+		return 0, err
+	}
+	if status/100 != 2 {
+		var apiErr struct {
+			ErrorMsg string `json:"error_msg"`
+		}
+		if json.Unmarshal(respBody, &apiErr) == nil && apiErr.ErrorMsg != "" {
+			return status, errors.New(apiErr.ErrorMsg)
+		}
+		return status, fmt.Errorf("HTTP %d: %s", status, string(respBody))
+	}
+	return status, nil
+}
+
+func (e *AdminAPIExecutor) newRequest(ctx context.Context, server string, config adctypes.Config, method, path string, body any) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(server, "/")+adminAPIPrefix+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("X-API-KEY", config.Token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+func (e *AdminAPIExecutor) send(config adctypes.Config, req *http.Request) (int, []byte, error) {
+	client := e.httpClient
+	if !config.TlsVerify {
+		client = e.insecureHTTPClient
+	}
+	e.log.V(1).Info("sending admin api request", "method", req.Method, "url", req.URL.String())
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to send HTTP request: %w", err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			e.log.Error(closeErr, "failed to close response body")
+		}
+	}()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return resp.StatusCode, body, nil
+}
+
+// buildAdminAPIObjects flattens ADC resources into the objects the Admin API stores,
+// adding the selector labels to each object that carries labels.
+func buildAdminAPIObjects(resources *adctypes.Resources, selector map[string]string) ([]adminAPIObject, error) {
+	if resources == nil {
+		return nil, nil
+	}
+	var objects []adminAPIObject
+	add := func(obj adminAPIObject, v any, drop ...string) error {
+		body, err := toAdminAPIBody(v, drop...)
+		if err != nil {
+			return err
+		}
+		obj.body = body
+		objects = append(objects, obj)
+		return nil
+	}
+
+	for _, service := range resources.Services {
+		if service == nil {
+			continue
+		}
+		owner := adminAPIObject{resourceType: adctypes.TypeService, resourceID: service.ID, resourceName: service.Name}
+
+		obj := owner
+		obj.collection, obj.id = collectionServices, service.ID
+		if err := add(obj, service, "routes", "stream_routes", "upstreams", "upstream", "path_prefix", "strip_path_prefix"); err != nil {
+			return nil, err
+		}
+		body := objects[len(objects)-1].body
+		setLabels(body, service.Labels, selector)
+		if service.Upstream != nil {
+			upstream, err := toAdminAPIBody(service.Upstream, "id")
+			if err != nil {
+				return nil, err
+			}
+			body["upstream"] = upstream
+		}
+
+		for _, upstream := range service.Upstreams {
+			obj := owner
+			obj.collection, obj.id = collectionUpstreams, upstream.ID
+			if err := add(obj, upstream); err != nil {
+				return nil, err
+			}
+			setLabels(objects[len(objects)-1].body, upstream.Labels, selector)
+		}
+		for _, route := range service.Routes {
+			obj := adminAPIObject{
+				collection:   collectionRoutes,
+				id:           route.ID,
+				resourceType: adctypes.TypeRoute,
+				resourceID:   route.ID,
+				resourceName: route.Name,
+			}
+			if err := add(obj, route); err != nil {
+				return nil, err
+			}
+			body := objects[len(objects)-1].body
+			body["service_id"] = service.ID
+			setLabels(body, route.Labels, selector)
+		}
+		for _, streamRoute := range service.StreamRoutes {
+			obj := owner
+			obj.collection, obj.id = collectionStreamRoutes, streamRoute.ID
+			if err := add(obj, streamRoute); err != nil {
+				return nil, err
+			}
+			body := objects[len(objects)-1].body
+			body["service_id"] = service.ID
+			setLabels(body, streamRoute.Labels, selector)
+		}
+	}
+
+	for _, ssl := range resources.SSLs {
+		if ssl == nil {
+			continue
+		}
+		obj := adminAPIObject{
+			collection:   collectionSSLs,
+			id:           ssl.ID,
+			resourceType: adctypes.TypeSSL,
+			resourceID:   ssl.ID,
+			resourceName: ssl.Name,
+		}
+		if err := add(obj, ssl, "certificates", "name"); err != nil {
+			return nil, err
+		}
+		body := objects[len(objects)-1].body
+		for i, cert := range ssl.Certificates {
+			if i == 0 {
+				body["cert"], body["key"] = cert.Certificate, cert.Key
+				continue
+			}
+			body["certs"] = append(asStrings(body["certs"]), cert.Certificate)
+			body["keys"] = append(asStrings(body["keys"]), cert.Key)
+		}
+		setLabels(body, ssl.Labels, selector)
+	}
+
+	for _, consumer := range resources.Consumers {
+		if consumer == nil {
+			continue
+		}
+		owner := adminAPIObject{resourceType: adctypes.TypeConsumer, resourceID: consumer.Username, resourceName: consumer.Username}
+
+		obj := owner
+		obj.collection, obj.id = collectionConsumers, consumer.Username
+		if err := add(obj, consumer, "credentials", "id", "name"); err != nil {
+			return nil, err
+		}
+		setLabels(objects[len(objects)-1].body, consumer.Labels, selector)
+
+		for _, credential := range consumer.Credentials {
+			credentialID := credential.ID
+			if credentialID == "" {
+				credentialID = id.GenID(consumer.Username + "_" + credential.Name)
+			}
+			obj := owner
+			obj.collection, obj.id = collectionCredentials, consumer.Username+"/"+collectionCredentials+"/"+credentialID
+			if err := add(obj, credential, "config", "type", "id"); err != nil {
+				return nil, err
+			}
+			body := objects[len(objects)-1].body
+			body["plugins"] = map[string]any{credential.Type: credential.Config}
+			setLabels(body, credential.Labels, selector)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(resources.GlobalRules)) {
+		obj := adminAPIObject{
+			collection:   collectionGlobalRules,
+			id:           name,
+			resourceType: adctypes.TypeGlobalRule,
+			resourceID:   name,
+			resourceName: name,
+		}
+		if err := add(obj, map[string]any{"plugins": map[string]any{name: resources.GlobalRules[name]}}); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(resources.PluginMetadata)) {
+		obj := adminAPIObject{
+			collection:   collectionPluginMetadata,
+			id:           name,
+			resourceType: adctypes.TypePluginMetadata,
+			resourceID:   name,
+			resourceName: name,
+		}
+		if err := add(obj, resources.PluginMetadata[name]); err != nil {
+			return nil, err
+		}
+	}
+	return objects, nil
+}
+
+// toAdminAPIBody converts an ADC object to the body the Admin API takes for it, which
+// only differs in the name of the description field and in what ADC nests.
+func toAdminAPIBody(v any, drop ...string) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	body := make(map[string]any)
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, err
+	}
+	for _, key := range drop {
+		delete(body, key)
+	}
+	if desc, ok := body["description"]; ok {
+		delete(body, "description")
+		body["desc"] = desc
+	}
+	return body, nil
+}
+
+func setLabels(body map[string]any, labels, selector map[string]string) {
+	if len(labels) == 0 && len(selector) == 0 {
+		return
+	}
+	merged := make(map[string]any, len(labels)+len(selector))
+	for k, v := range labels {
+		merged[k] = v
+	}
+	for k, v := range selector {
+		merged[k] = v
+	}
+	body["labels"] = merged
+}
+
+func asStrings(v any) []string {
+	s, _ := v.([]string)
+	return s
+}
+
+func hashAdminAPIBody(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func failedStatus(obj adminAPIObject, eventType string, status int, err error) adctypes.SyncStatus {
+	return adctypes.SyncStatus{
+		Event: adctypes.StatusEvent{
+			ResourceType: obj.resourceType,
+			Type:         eventType,
+			ResourceID:   obj.resourceID,
+			ResourceName: obj.resourceName,
+		},
+		FailedAt: time.Now(),
+		Reason:   fmt.Sprintf("failed to %s %s: %s", eventType, strings.TrimSuffix(obj.collection, "s"), err.Error()),
+		Response: adctypes.ResponseDetails{Status: status},
+	}
+}
+
+// collectionIncluded reports whether a sync limited to resourceTypes covers collection.
+// No resource types means all of them.
+func collectionIncluded(collection string, resourceTypes []string) bool {
+	if len(resourceTypes) == 0 {
+		return true
+	}
+	for _, t := range adminAPICollectionTypes[collection] {
+		if slices.Contains(resourceTypes, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesSelector reports whether the labels of an Admin API item carry every label of
+// the selector. Every item matches an empty selector.
+func matchesSelector(item map[string]any, selector map[string]string) bool {
+	if len(selector) == 0 {
+		return true
+	}
+	labels, _ := item["labels"].(map[string]any)
+	for k, v := range selector {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func itemID(collection string, item map[string]any) string {
+	key := "id"
+	if collection == collectionConsumers && !isStandaloneCredential(item) {
+		key = "username"
+	}
+	switch v := item[key].(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func isStandaloneCredential(item map[string]any) bool {
+	id, _ := item["id"].(string)
+	return strings.Contains(id, "/"+collectionCredentials+"/")
+}
+
+func confVersion(document map[string]any, collection string) int64 {
+	switch v := document[collection+"_conf_version"].(type) {
+	case json.Number:
+		n, _ := v.Int64()
+		return n
+	case float64:
+		return int64(v)
+	case int64:
+		return v
+	}
+	return 0
+}
@@ -0,0 +1,497 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/controller/config"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+const fakeAdminKey = "edd1c9f034335f136f87ad84b625c8f1"
+
+// fakeAdminAPI is an in-process APISIX Admin API. In apisix mode it keeps one object per
+// path; in standalone mode it keeps the single document /configs serves, and refuses a
+// conf_version that moves backwards the way APISIX does.
+type fakeAdminAPI struct {
+	mu sync.Mutex
+
+	objects map[string]map[string]any
+	configs map[string]any
+
+	// reject makes every write to a path with this prefix fail, and every object of a
+	// collection with this prefix fail schema validation.
+	reject string
+	// noSchemaValidate makes the fake an Admin API without /schema/validate endpoints.
+	noSchemaValidate bool
+	validated        []string
+
+	writes []string
+}
+
+func newFakeAdminAPI(t *testing.T) (*fakeAdminAPI, string) {
+	f := &fakeAdminAPI{objects: make(map[string]map[string]any)}
+	srv := httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(srv.Close)
+	return f, srv.URL
+}
+
+func (f *fakeAdminAPI) serve(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("X-API-KEY") != fakeAdminKey {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, adminAPIPrefix+"/")
+	if collection, ok := strings.CutPrefix("/"+path, adminAPISchemaValidate); ok {
+		f.serveSchemaValidate(w, collection)
+		return
+	}
+	if r.Method != http.MethodGet {
+		f.writes = append(f.writes, r.Method+" "+path)
+		if f.reject != "" && strings.HasPrefix(path, f.reject) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprintf(w, `{"error_msg":"invalid configuration: %s"}`, path)
+			return
+		}
+	}
+
+	if path == "configs" {
+		f.serveConfigs(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		var list []map[string]any
+		for key, value := range f.objects {
+			if parent, id, ok := strings.Cut(key, path+"/"); ok && parent == "" && !strings.Contains(id, "/") {
+				list = append(list, map[string]any{"key": "/apisix/" + key, "value": value})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"total": len(list), "list": list})
+	case http.MethodPut:
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		// APISIX stores the key an object is written under as its id.
+		if _, ok := body["id"]; !ok && !strings.HasPrefix(path, collectionConsumers+"/") || strings.Contains(path, "/credentials/") {
+			body["id"] = path[strings.LastIndex(path, "/")+1:]
+		}
+		f.objects[path] = body
+	case http.MethodDelete:
+		if _, ok := f.objects[path]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.objects, path)
+	}
+}
+
+func (f *fakeAdminAPI) serveSchemaValidate(w http.ResponseWriter, collection string) {
+	if f.noSchemaValidate {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprint(w, `{"error_msg":"404 Route Not Found"}`)
+		return
+	}
+	f.validated = append(f.validated, collection)
+	if f.reject != "" && strings.HasPrefix(collection+"/", f.reject) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprintf(w, `{"error_msg":"invalid configuration: %s"}`, collection)
+	}
+}
+
+func (f *fakeAdminAPI) serveConfigs(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		if f.configs == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(f.configs)
+		return
+	}
+	var document map[string]any
+	_ = json.NewDecoder(r.Body).Decode(&document)
+	for key, value := range document {
+		if !strings.HasSuffix(key, "_conf_version") {
+			continue
+		}
+		if current, ok := f.configs[key].(float64); ok && value.(float64) < current {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprintf(w, `{"error_msg":"%s must be greater than or equal to (%d)"}`, key, int64(current))
+			return
+		}
+	}
+	f.configs = document
+}
+
+func (f *fakeAdminAPI) object(path string) map[string]any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.objects[path]
+}
+
+func (f *fakeAdminAPI) takeWrites() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	writes := f.writes
+	f.writes = nil
+	return writes
+}
+
+func executeAdminAPI(t *testing.T, e *AdminAPIExecutor, config adctypes.Config, resources *adctypes.Resources, labels map[string]string, resourceTypes ...string) error {
+	t.Helper()
+	return e.Execute(context.Background(), config, ADCRequest{
+		Resources:     resources,
+		Labels:        labels,
+		ResourceTypes: resourceTypes,
+		CacheKey:      config.Name,
+	})
+}
+
+func adminAPIResources() *adctypes.Resources {
+	return &adctypes.Resources{
+		Services: []*adctypes.Service{{
+			Metadata: adctypes.Metadata{ID: "svc1", Name: "default_httpbin", Labels: map[string]string{"k8s/name": "httpbin"}},
+			Hosts:    []string{"httpbin.org"},
+			Upstream: &adctypes.Upstream{
+				Metadata: adctypes.Metadata{Name: "default_httpbin"},
+				Nodes:    adctypes.UpstreamNodes{{Host: "10.0.0.1", Port: 80, Weight: 100}},
+			},
+			Routes: []*adctypes.Route{{
+				Metadata: adctypes.Metadata{ID: "route1", Name: "default_httpbin_0", Desc: "created by the ingress controller"},
+				Uris:     []string{"/get"},
+			}},
+		}},
+		SSLs: []*adctypes.SSL{{
+			Metadata:     adctypes.Metadata{ID: "ssl1"},
+			Certificates: []adctypes.Certificate{{Certificate: "cert", Key: "key"}, {Certificate: "cert2", Key: "key2"}},
+			Snis:         []string{"httpbin.org"},
+		}},
+		Consumers: []*adctypes.Consumer{{
+			Username: "jack",
+			Credentials: []adctypes.Credential{{
+				Metadata: adctypes.Metadata{ID: "cred1", Name: "key"},
+				Type:     "key-auth",
+				Config:   adctypes.Plugins{"key": "jack-key"},
+			}},
+		}},
+		GlobalRules: adctypes.GlobalRule{"prometheus": map[string]any{}},
+	}
+}
+
+func adminAPIConfig(server, mode string) adctypes.Config {
+	return adctypes.Config{
+		Name:        "GatewayProxy/default/apisix",
+		ServerAddrs: []string{server},
+		Token:       fakeAdminKey,
+		BackendType: mode,
+	}
+}
+
+func TestAdminAPIExecutorWritesObjectsThroughTheAdminAPI(t *testing.T) {
+	fake, server := newFakeAdminAPI(t)
+	e := NewAdminAPIExecutor(logr.Discard(), 5*time.Second)
+	config := adminAPIConfig(server, "apisix")
+
+	require.NoError(t, executeAdminAPI(t, e, config, adminAPIResources(), nil))
+
+	service := fake.object("services/svc1")
+	require.NotNil(t, service)
+	assert.Equal(t, []any{"httpbin.org"}, service["hosts"])
+	assert.NotContains(t, service, "routes", "routes are objects of their own")
+	assert.Contains(t, service["upstream"], "nodes")
+
+	route := fake.object("routes/route1")
+	require.NotNil(t, route)
+	assert.Equal(t, "svc1", route["service_id"])
+	assert.Equal(t, "created by the ingress controller", route["desc"])
+
+	ssl := fake.object("ssls/ssl1")
+	require.NotNil(t, ssl)
+	assert.Equal(t, "cert", ssl["cert"])
+	assert.Equal(t, []any{"cert2"}, ssl["certs"])
+	assert.Equal(t, []any{"key2"}, ssl["keys"])
+
+	require.NotNil(t, fake.object("consumers/jack"))
+	credential := fake.object("consumers/jack/credentials/cred1")
+	require.NotNil(t, credential)
+	assert.Equal(t, map[string]any{"key-auth": map[string]any{"key": "jack-key"}}, credential["plugins"])
+
+	require.NotNil(t, fake.object("global_rules/prometheus"))
+
+	// Upstreams before services before routes, so nothing is written before what it refers to.
+	writes := fake.takeWrites()
+	assert.Less(t, indexOf(writes, "PUT services/svc1"), indexOf(writes, "PUT routes/route1"))
+
+	// Nothing changed, so nothing is written.
+	require.NoError(t, executeAdminAPI(t, e, config, adminAPIResources(), nil))
+	assert.Empty(t, fake.takeWrites())
+}
+
+func TestAdminAPIExecutorRestoresObjectsRemovedFromTheDataPlane(t *testing.T) {
+	fake, server := newFakeAdminAPI(t)
+	e := NewAdminAPIExecutor(logr.Discard(), 5*time.Second)
+	config := adminAPIConfig(server, "apisix")
+
+	require.NoError(t, executeAdminAPI(t, e, config, adminAPIResources(), nil))
+	fake.mu.Lock()
+	delete(fake.objects, "routes/route1")
+	fake.mu.Unlock()
+	fake.takeWrites()
+
+	require.NoError(t, executeAdminAPI(t, e, config, adminAPIResources(), nil))
+	assert.Equal(t, []string{"PUT routes/route1"}, fake.takeWrites())
+}
+
+func TestAdminAPIExecutorScopesDeletesBySelectorAndResourceType(t *testing.T) {
+	fake, server := newFakeAdminAPI(t)
+	fake.objects = map[string]map[string]any{
+		"routes/a":       {"id": "a", "labels": map[string]any{"k8s/name": "a"}},
+		"routes/b":       {"id": "b", "labels": map[string]any{"k8s/name": "b"}},
+		"services/a":     {"id": "a", "labels": map[string]any{"k8s/name": "a"}},
+		"ssls/a":         {"id": "a", "labels": map[string]any{"k8s/name": "a"}},
+		"global_rules/x": {"id": "x"},
+	}
+	e := NewAdminAPIExecutor(logr.Discard(), 5*time.Second)
+
+	err := executeAdminAPI(t, e, adminAPIConfig(server, "apisix"), &adctypes.Resources{},
+		map[string]string{"k8s/name": "a"}, adctypes.TypeService)
+	require.NoError(t, err)
+
+	assert.Nil(t, fake.object("routes/a"))
+	assert.Nil(t, fake.object("services/a"))
+	assert.NotNil(t, fake.object("routes/b"), "outside the label selector")
+	assert.NotNil(t, fake.object("ssls/a"), "outside the included resource types")
+	assert.NotNil(t, fake.object("global_rules/x"), "global rules carry no labels, so a selector never covers them")
+	assert.Equal(t, []string{"DELETE routes/a", "DELETE services/a"}, fake.takeWrites(),
+		"routes are deleted before the services they refer to")
+}
+
+func TestAdminAPIExecutorAddsSelectorLabels(t *testing.T) {
+	fake, server := newFakeAdminAPI(t)
+	e := NewAdminAPIExecutor(logr.Discard(), 5*time.Second)
+
+	err := executeAdminAPI(t, e, adminAPIConfig(server, "apisix"), adminAPIResources(),
+		map[string]string{"k8s/kind": "Ingress"}, adctypes.TypeService)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{"k8s/name": "httpbin", "k8s/kind": "Ingress"}, fake.object("services/svc1")["labels"])
+	assert.Nil(t, fake.object("ssls/ssl1"), "outside the included resource types")
+}
+
+func TestAdminAPIExecutorReportsTheObjectsThatFailed(t *testing.T) {
+	fake, server := newFakeAdminAPI(t)
+	fake.reject = "routes/"
+	e := NewAdminAPIExecutor(logr.Discard(), 5*time.Second)
+
+	err := executeAdminAPI(t, e, adminAPIConfig(server, "apisix"), adminAPIResources(), nil)
+
+	var execErr types.ADCExecutionError
+	require.ErrorAs(t, err, &execErr)
+	require.Len(t, execErr.FailedErrors, 1)
+	require.Len(t, execErr.FailedErrors[0].FailedStatuses, 1)
+	status := execErr.FailedErrors[0].FailedStatuses[0]
+	assert.Equal(t, adctypes.TypeRoute, status.Event.ResourceType)
+	assert.Equal(t, "route1", status.Event.ResourceID)
+	assert.Contains(t, status.Reason, "invalid configuration: routes/route1")
+	assert.Equal(t, http.StatusBadRequest, status.Response.Status)
+	assert.NotNil(t, fake.object("services/svc1"), "the objects that were accepted stay written")
+
+	// A write that failed is retried on the next sync.
+	fake.reject = ""
+	fake.takeWrites()
+	require.NoError(t, executeAdminAPI(t, e, adminAPIConfig(server, "apisix"), adminAPIResources(), nil))
+	assert.Equal(t, []string{"PUT routes/route1"}, fake.takeWrites())
+}
+
+func TestAdminAPIExecutorValidatesAgainstTheAdminAPISchemas(t *testing.T) {
+	fake, server := newFakeAdminAPI(t)
+	e := NewAdminAPIExecutor(logr.Discard(), 5*time.Second)
+	config := adminAPIConfig(server, "apisix")
+	req := ADCRequest{Resources: adminAPIResources()}
+
+	require.NoError(t, e.Validate(context.Background(), config, req))
+	assert.ElementsMatch(t, []string{"services", "routes", "ssls", "consumers", "global_rules"}, fake.validated)
+	assert.Empty(t, fake.takeWrites(), "validation writes nothing")
+
+	fake.reject = "routes/"
+	err := e.Validate(context.Background(), config, req)
+	var validationErr types.ADCValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Len(t, validationErr.FailedErrors, 1)
+	require.Len(t, validationErr.FailedErrors[0].ValidationErrors, 1)
+	detail := validationErr.FailedErrors[0].ValidationErrors[0]
+	assert.Equal(t, adctypes.TypeRoute, detail.ResourceType)
+	assert.Equal(t, "default_httpbin_0", detail.ResourceName)
+	assert.Contains(t, detail.Message, "invalid configuration: routes")
+}
+
+func TestAdminAPIExecutorReportsValidationItCannotRun(t *testing.T) {
+	fake, server := newFakeAdminAPI(t)
+	fake.noSchemaValidate = true
+	e := NewAdminAPIExecutor(logr.Discard(), 5*time.Second)
+	req := ADCRequest{Resources: adminAPIResources()}
+
+	err := e.Validate(context.Background(), adminAPIConfig(server, "apisix"), req)
+	require.ErrorIs(t, err, errAdminAPIValidationUnsupported)
+	assert.NotErrorAs(t, err, &types.ADCValidationError{}, "the webhook admits what could not be checked")
+
+	err = e.Validate(context.Background(), adminAPIConfig(server, backendAPISIXStandalone), req)
+	require.ErrorIs(t, err, errAdminAPIValidationUnsupported)
+}
+
+func TestAdminAPIExecutorStandaloneReplacesOnlyTheInScopeItems(t *testing.T) {
+	fake, server := newFakeAdminAPI(t)
+	fake.configs = map[string]any{
+		"routes": []any{
+			map[string]any{"id": "other", "labels": map[string]any{"k8s/name": "other"}},
+			map[string]any{"id": "stale", "labels": map[string]any{"k8s/name": "httpbin"}},
+		},
+		"routes_conf_version": float64(5),
+	}
+	e := NewAdminAPIExecutor(logr.Discard(), 5*time.Second)
+	config := adminAPIConfig(server, backendAPISIXStandalone)
+	selector := map[string]string{"k8s/name": "httpbin"}
+
+	require.NoError(t, executeAdminAPI(t, e, config, adminAPIResources(), selector, adctypes.TypeService))
+
+	var routeIDs []string
+	for _, route := range fake.configs["routes"].([]any) {
+		routeIDs = append(routeIDs, route.(map[string]any)["id"].(string))
+	}
+	assert.ElementsMatch(t, []string{"other", "route1"}, routeIDs)
+	assert.Greater(t, fake.configs["routes_conf_version"].(float64), float64(5))
+	assert.NotContains(t, fake.configs, "ssls", "outside the included resource types")
+	assert.Equal(t, []string{"PUT configs"}, fake.takeWrites())
+
+	// Nothing changed, so the configuration is not pushed again.
+	require.NoError(t, executeAdminAPI(t, e, config, adminAPIResources(), selector, adctypes.TypeService))
+	assert.Empty(t, fake.takeWrites())
+
+	// Another writer moved the data plane on. The in-scope items are reasserted with a
+	// conf_version past the one it left behind.
+	fake.mu.Lock()
+	fake.configs["routes_conf_version"] = float64(time.Now().Add(time.Hour).UnixMilli())
+	fake.mu.Unlock()
+	require.NoError(t, executeAdminAPI(t, e, config, adminAPIResources(), selector, adctypes.TypeService))
+	assert.Equal(t, []string{"PUT configs"}, fake.takeWrites())
+}
+
+func TestAdminAPIExecutorStandaloneKeepsCredentialsWithTheirConsumers(t *testing.T) {
+	fake, server := newFakeAdminAPI(t)
+	e := NewAdminAPIExecutor(logr.Discard(), 5*time.Second)
+
+	require.NoError(t, executeAdminAPI(t, e, adminAPIConfig(server, backendAPISIXStandalone), adminAPIResources(), nil))
+
+	var consumerIDs []string
+	for _, item := range fake.configs["consumers"].([]any) {
+		consumer := item.(map[string]any)
+		if id, ok := consumer["id"].(string); ok {
+			consumerIDs = append(consumerIDs, id)
+		} else {
+			consumerIDs = append(consumerIDs, consumer["username"].(string))
+		}
+	}
+	assert.ElementsMatch(t, []string{"jack", "jack/credentials/cred1"}, consumerIDs)
+}
+
+func TestAdminAPIExecutorStandaloneRejectionNamesTheConfVersion(t *testing.T) {
+	fake, server := newFakeAdminAPI(t)
+	e := NewAdminAPIExecutor(logr.Discard(), 5*time.Second)
+	config := adminAPIConfig(server, backendAPISIXStandalone)
+
+	require.NoError(t, executeAdminAPI(t, e, config, adminAPIResources(), nil))
+
+	// The data plane only reports a version ahead of the one this executor reads back
+	// once it is written, which is the race the Client rebuilds the baseline for.
+	fake.mu.Lock()
+	fake.configs["services_conf_version"] = float64(time.Now().Add(time.Hour).UnixMilli())
+	original := fake.configs
+	fake.mu.Unlock()
+	services := adminAPIResources()
+	services.Services[0].Hosts = []string{"httpbin.example"}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			stale := make(map[string]any, len(original))
+			for k, v := range original {
+				stale[k] = v
+			}
+			stale["services_conf_version"] = float64(1)
+			_ = json.NewEncoder(w).Encode(stale)
+			return
+		}
+		fake.serve(w, r)
+	}))
+	defer srv.Close()
+
+	config.ServerAddrs = []string{srv.URL}
+	err := executeAdminAPI(t, e, config, services, nil)
+	require.Error(t, err)
+	assert.True(t, isConfVersionRejection(err), "the Client's rebuild must recognise it: %v", err)
+}
+
+func TestClientSelectsTheExecutorPerConfig(t *testing.T) {
+	adc, native := &fakeExecutor{}, &fakeExecutor{}
+	c := afterFirstSync(adc)
+	c.nativeExecutor = native
+
+	task := newSyncTask()
+	require.NoError(t, c.sync(context.Background(), task))
+	assert.Len(t, adc.bypassSeq, 1)
+	assert.Empty(t, native.bypassSeq)
+
+	task.Configs[types.NamespacedNameKind{}] = adctypes.Config{
+		Name:        syncTaskCacheKey,
+		BackendType: backendAPISIXStandalone,
+		Executor:    string(config.ProviderExecutorNative),
+	}
+	require.NoError(t, c.sync(context.Background(), task))
+	assert.Len(t, adc.bypassSeq, 1)
+	assert.Len(t, native.bypassSeq, 1)
+
+	// Without a choice of its own, a config follows the controller-wide default.
+	c.defaultExecutor = string(config.ProviderExecutorNative)
+	require.NoError(t, c.sync(context.Background(), newSyncTask()))
+	assert.Len(t, native.bypassSeq, 2)
+}
+
+func indexOf(s []string, v string) int {
+	for i, item := range s {
+		if item == v {
+			return i
+		}
+	}
+	return -1
+}
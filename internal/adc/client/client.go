@@ -18,10 +18,13 @@
 package client
 
 import (
+	"cmp"
 	"context"
-	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -34,19 +37,28 @@ import (
 	"github.com/apache/apisix-ingress-controller/internal/provider/common"
 	"github.com/apache/apisix-ingress-controller/internal/types"
 	pkgmetrics "github.com/apache/apisix-ingress-controller/pkg/metrics"
+	"github.com/apache/apisix-ingress-controller/pkg/utils"
 )
 
 type Client struct {
-	syncMu sync.RWMutex
-	mu     sync.Mutex
+	mu sync.Mutex
 	*cache.Store
 
 	executor ADCExecutor
+	// nativeExecutor syncs the configs that select ExecutorNative.
+	nativeExecutor ADCExecutor
+	// dataPlane reads configs back from the data plane for DetectDrift.
+	dataPlane *AdminAPIExecutor
+	// fileExecutor, when set, writes every config to a file instead, see UseFileExecutor.
+	fileExecutor *FileExecutor
 
 	ConfigManager    *common.ConfigManager[types.NamespacedNameKind, adctypes.Config]
 	ADCDebugProvider *common.ADCDebugProvider
 
-	defaultMode string
+	defaultMode     string
+	defaultExecutor string
+	// dumpDir is where every request is written before it is sent, see dumpRequest.
+	dumpDir string
 
 	// rebuiltMu guards rebuiltBaselines.
 	rebuiltMu sync.Mutex
@@ -55,10 +67,98 @@ type Client struct {
 	// bypassCache first. See InvalidateADCCache.
 	rebuiltBaselines map[string]struct{}
 
+	// SyncConcurrency is how many configs Sync and SyncChanges push at once.
+	SyncConcurrency int
+
+	// statesMu guards states, what is kept about pushing each config.
+	statesMu sync.Mutex
+	states   map[string]*configState
+
+	// quarantineMu guards quarantine and contents.
+	quarantineMu sync.Mutex
+	// quarantine holds the objects the data plane rejected, see QuarantineEntry.
+	quarantine map[quarantineKey]*QuarantineEntry
+	// contents holds the hash of what the last update of each object translated to.
+	contents map[types.NamespacedNameKind]string
+
+	// endpointsMu guards endpoints, how pushes to each endpoint of each config have gone.
+	endpointsMu sync.Mutex
+	endpoints   map[string]map[string]*endpointState
+
+	// pushesMu guards pushes, the last push of each config the data plane accepted.
+	pushesMu sync.Mutex
+	pushes   map[string]Push
+
+	// driftMu guards driftReports, what the last DetectDrift found.
+	driftMu      sync.Mutex
+	driftReports []DriftReport
+
 	log logr.Logger
 }
 
-func New(log logr.Logger, defaultMode string, timeout time.Duration) (*Client, error) {
+// configState is what is kept about pushing one config. Its mu is held for every push
+// of the config, so that configs are pushed side by side but the pushes of one never
+// interleave; the fields are guarded by it.
+type configState struct {
+	mu sync.Mutex
+	// fingerprint is the one the config was last pushed whole with.
+	fingerprint string
+	// lastGood is the last version of the config the data plane accepted, which what it
+	// rejects is rolled back to.
+	lastGood *snapshot
+}
+
+// lockConfig locks the state of config name, and returns it.
+func (c *Client) lockConfig(name string) *configState {
+	for {
+		c.statesMu.Lock()
+		st, ok := c.states[name]
+		if !ok {
+			st = &configState{}
+			c.states[name] = st
+		}
+		c.statesMu.Unlock()
+
+		st.mu.Lock()
+		// forgetUnlisted may have dropped the state while this waited for it.
+		c.statesMu.Lock()
+		current := c.states[name] == st
+		c.statesMu.Unlock()
+		if current {
+			return st
+		}
+		st.mu.Unlock()
+	}
+}
+
+// lockConfigs locks the state of every config in configs, in the order of their names so
+// that two callers cannot each hold what the other waits for. It returns the unlock.
+func (c *Client) lockConfigs(configs ...map[types.NamespacedNameKind]adctypes.Config) func() {
+	var names []string
+	for _, m := range configs {
+		for _, config := range m {
+			if !slices.Contains(names, config.Name) {
+				names = append(names, config.Name)
+			}
+		}
+	}
+	slices.Sort(names)
+	states := make([]*configState, 0, len(names))
+	for _, name := range names {
+		states = append(states, c.lockConfig(name))
+	}
+	return func() {
+		for _, st := range states {
+			st.mu.Unlock()
+		}
+	}
+}
+
+// maxDeltaOwners is how many changed owners a config is pushed one by one for; past it,
+// one whole push is cheaper than that many requests.
+const maxDeltaOwners = 100
+
+func New(log logr.Logger, defaultMode, defaultExecutor string, timeout time.Duration) (*Client, error) {
 	serverURL := os.Getenv("ADC_SERVER_URL")
 	if serverURL == "" {
 		serverURL = defaultHTTPADCExecutorAddr
@@ -69,17 +169,63 @@ func New(log logr.Logger, defaultMode string, timeout time.Duration) (*Client, e
 	logger := log.WithName("client")
 	logger.Info("ADC client initialized")
 
+	adminAPI := NewAdminAPIExecutor(log, timeout)
+
 	return &Client{
 		Store:            store,
 		rebuiltBaselines: make(map[string]struct{}),
+		states:           make(map[string]*configState),
+		quarantine:       make(map[quarantineKey]*QuarantineEntry),
+		contents:         make(map[types.NamespacedNameKind]string),
+		endpoints:        make(map[string]map[string]*endpointState),
+		pushes:           make(map[string]Push),
 		executor:         NewHTTPADCExecutor(log, serverURL, timeout),
+		nativeExecutor:   adminAPI,
+		dataPlane:        adminAPI,
 		ConfigManager:    configManager,
 		ADCDebugProvider: common.NewADCDebugProvider(store, configManager),
 		log:              logger,
 		defaultMode:      defaultMode,
+		defaultExecutor:  defaultExecutor,
+		dumpDir:          os.Getenv(dumpDirEnv),
 	}, nil
 }
 
+// executorFor returns the executor that syncs cfg: the one its GatewayProxy selects,
+// or else the controller-wide default.
+func (c *Client) executorFor(cfg adctypes.Config) ADCExecutor {
+	if c.fileExecutor != nil {
+		return c.fileExecutor
+	}
+	executor := cfg.Executor
+	if executor == "" {
+		executor = c.defaultExecutor
+	}
+	if executor == ExecutorNative && c.nativeExecutor != nil {
+		return c.nativeExecutor
+	}
+	return c.executor
+}
+
+// executorName names the executor cfg is synced with, for metrics.
+func (c *Client) executorName(cfg adctypes.Config) string {
+	switch c.executorFor(cfg).(type) {
+	case *FileExecutor:
+		return "file"
+	case *AdminAPIExecutor:
+		return ExecutorNative
+	default:
+		return ExecutorADC
+	}
+}
+
+// UseFileExecutor makes the client write every config to a file through e, whatever
+// executor the config selects. A file only holds a config whole, so every change to a
+// config is then pushed as a whole one.
+func (c *Client) UseFileExecutor(e *FileExecutor) {
+	c.fileExecutor = e
+}
+
 // InvalidateADCCache forgets which ADC baselines are known to be current, so that the
 // next sync of each cacheKey re-derives its baseline from the data plane.
 //
@@ -90,7 +236,11 @@ func New(log logr.Logger, defaultMode string, timeout time.Duration) (*Client, e
 // the snapshot this pod left behind in an earlier term, while the leader in between kept
 // pushing and moved the data plane's conf_version past it. APISIX standalone requires
 // those versions to be monotonic and refuses the whole configuration otherwise.
+//
+// What was last pushed for each config is forgotten as well: another leader may have
+// pushed since, so the next sync of every config is a whole one.
 func (c *Client) InvalidateADCCache() {
+	c.ResetSynced()
 	c.rebuiltMu.Lock()
 	defer c.rebuiltMu.Unlock()
 	clear(c.rebuiltBaselines)
@@ -155,6 +305,17 @@ func (t Task) MarshalLog() any {
 	}
 }
 
+// request builds what an executor is handed to sync or validate config for t. The
+// resources are shared, not copied: executors only read them.
+func (t Task) request(config adctypes.Config) ADCRequest {
+	return ADCRequest{
+		Resources:     t.Resources,
+		Labels:        t.Labels,
+		ResourceTypes: t.ResourceTypes,
+		CacheKey:      config.Name,
+	}
+}
+
 type StoreDelta struct {
 	Deleted map[types.NamespacedNameKind]adctypes.Config
 	Applied map[types.NamespacedNameKind]adctypes.Config
@@ -167,9 +328,11 @@ func (c *Client) applyStoreChanges(args Task, isDelete bool) (StoreDelta, error)
 	var delta StoreDelta
 
 	if isDelete {
+		c.forget(args.Key)
 		delta.Deleted = c.ConfigManager.Get(args.Key)
 		c.ConfigManager.Delete(args.Key)
 	} else {
+		c.observeUpdate(args.Key, args.Configs, args.Resources)
 		deleted := c.ConfigManager.Update(args.Key, args.Configs)
 		delta.Deleted = deleted
 		delta.Applied = args.Configs
@@ -193,8 +356,7 @@ func (c *Client) applyStoreChanges(args Task, isDelete bool) (StoreDelta, error)
 }
 
 func (c *Client) applySync(ctx context.Context, args Task, delta StoreDelta) error {
-	c.syncMu.RLock()
-	defer c.syncMu.RUnlock()
+	defer c.lockConfigs(delta.Deleted, delta.Applied)()
 
 	if len(delta.Deleted) > 0 {
 		if err := c.sync(ctx, Task{
@@ -250,42 +412,84 @@ func (c *Client) Validate(ctx context.Context, task Task) error {
 		return nil
 	}
 
-	fileIOStart := time.Now()
-	syncFilePath, cleanup, err := prepareSyncFile(task.Resources)
-	if err != nil {
-		pkgmetrics.RecordFileIODuration("prepare_sync_file", "failure", time.Since(fileIOStart).Seconds())
-		return err
-	}
-	pkgmetrics.RecordFileIODuration("prepare_sync_file", adctypes.StatusSuccess, time.Since(fileIOStart).Seconds())
-	defer cleanup()
-
-	args2 := BuildADCExecuteArgs(syncFilePath, task.Labels, task.ResourceTypes)
-
-	var errs types.ADCValidationErrors
+	var (
+		errs   types.ADCValidationErrors
+		failed = &ValidateError{}
+	)
 	for _, config := range task.Configs {
 		if config.BackendType == "" {
 			config.BackendType = c.defaultMode
 		}
-		if err := c.executor.Validate(ctx, config, args2); err != nil {
+		req := task.request(config)
+		c.dumpRequest(operationValidate, req)
+		if err := c.executorFor(config).Validate(ctx, config, req); err != nil {
 			var validationErr types.ADCValidationError
 			if errors.As(err, &validationErr) {
 				errs.Errors = append(errs.Errors, validationErr)
 				continue
 			}
-			return err
+			c.log.Error(err, "failed to validate resources", "name", config.Name)
+			failed.Configs = append(failed.Configs, config.Name)
+			failed.Errs = append(failed.Errs, err)
 		}
 	}
 
+	// What one config refuses is refused, whether or not the others could be asked.
 	if len(errs.Errors) > 0 {
 		return errs
 	}
+	if len(failed.Configs) > 0 {
+		return failed
+	}
 	return nil
 }
 
+// ValidateError names the configs a validation could not be run for, and why.
+type ValidateError struct {
+	Configs []string
+	Errs    []error
+}
+
+func (e *ValidateError) Error() string {
+	messages := make([]string, 0, len(e.Errs))
+	for i, err := range e.Errs {
+		messages = append(messages, e.Configs[i]+": "+err.Error())
+	}
+	return fmt.Sprintf("failed to validate %d configs: %s", len(e.Configs), strings.Join(messages, "; "))
+}
+
+func (e *ValidateError) Unwrap() []error {
+	return e.Errs
+}
+
+// Sync pushes every config whole. It runs periodically, so that whatever a push of
+// changes alone could miss -- an object changed on the data plane behind the
+// controller's back, say -- is put right within one resync period.
 func (c *Client) Sync(ctx context.Context) (map[string]types.ADCExecutionErrors, error) {
-	c.syncMu.Lock()
-	defer c.syncMu.Unlock()
-	c.log.Info("syncing all resources")
+	return c.syncConfigs(ctx, true, nil)
+}
+
+// SyncChanges pushes, for every config, only the owners whose routes, services, SSLs or
+// consumers changed since the config was last pushed. A config falls back to a whole
+// push when the change cannot be expressed per owner, see cache.Store.Delta. Given names,
+// it pushes only the configs named.
+func (c *Client) SyncChanges(ctx context.Context, names ...string) (map[string]types.ADCExecutionErrors, error) {
+	return c.syncConfigs(ctx, false, names)
+}
+
+// SyncError names the configs a sync failed for.
+type SyncError struct {
+	Configs []string
+}
+
+func (e *SyncError) Error() string {
+	return fmt.Sprintf("failed to sync %d configs: %s", len(e.Configs), strings.Join(e.Configs, ", "))
+}
+
+// syncConfigs pushes the configs named, or all of them, up to SyncConcurrency at once: one
+// data plane that is slow to answer holds up only its own config.
+func (c *Client) syncConfigs(ctx context.Context, full bool, names []string) (map[string]types.ADCExecutionErrors, error) {
+	c.log.Info("syncing all resources", "full", full, "configs", names)
 
 	configs := c.ConfigManager.List()
 
@@ -296,44 +500,200 @@ func (c *Client) Sync(ctx context.Context) (map[string]types.ADCExecutionErrors,
 
 	c.log.V(1).Info("syncing resources with multiple configs", "configs", configs)
 
-	failedMap := map[string]types.ADCExecutionErrors{}
-	var failedConfigs []string
+	c.forgetUnlisted(configs)
+
+	var (
+		mu            sync.Mutex
+		failedMap     = map[string]types.ADCExecutionErrors{}
+		failedConfigs []string
+	)
+	executor := utils.NewParallelExecutor(c.SyncConcurrency)
 	for _, config := range configs {
 		name := config.Name
-		resources, err := c.GetResources(name)
-		if err != nil {
-			c.log.Error(err, "failed to get resources from store", "name", name)
-			failedConfigs = append(failedConfigs, name)
+		if len(names) > 0 && !slices.Contains(names, name) {
 			continue
 		}
-		if resources == nil {
+		executor.Add(func() {
+			st := c.lockConfig(name)
+			err := c.syncConfig(ctx, st, config, full)
+			st.mu.Unlock()
+			if err == nil {
+				return
+			}
+			c.log.Error(err, "failed to sync resources", "name", name)
+
+			mu.Lock()
+			defer mu.Unlock()
+			failedConfigs = append(failedConfigs, name)
+			var execErrs types.ADCExecutionErrors
+			if errors.As(err, &execErrs) {
+				failedMap[name] = execErrs
+			}
+		})
+	}
+	executor.Wait()
+
+	if len(failedConfigs) > 0 {
+		slices.Sort(failedConfigs)
+		return failedMap, &SyncError{Configs: failedConfigs}
+	}
+	return failedMap, nil
+}
+
+// forgetUnlisted drops what is kept about pushing the configs no longer listed: one that
+// comes back is pushed whole, and has nothing to roll back to.
+func (c *Client) forgetUnlisted(configs map[types.NamespacedNameKind]adctypes.Config) {
+	listed := make(map[string]struct{}, len(configs))
+	for _, config := range configs {
+		listed[config.Name] = struct{}{}
+	}
+	if c.fileExecutor != nil {
+		c.fileExecutor.Forget(slices.Collect(maps.Keys(listed))...)
+	}
+	c.statesMu.Lock()
+	defer c.statesMu.Unlock()
+	for name, st := range c.states {
+		if _, ok := listed[name]; ok {
 			continue
 		}
-		c.log.Info("syncing resources for config", "service_number", len(resources.Services))
+		// One being pushed is dropped by a later sync.
+		if st.mu.TryLock() {
+			delete(c.states, name)
+			c.forgetPush(name)
+			pkgmetrics.DeleteConfigInfo(name)
+			st.mu.Unlock()
+		}
+	}
+}
+
+// syncConfig pushes config whole when full is set, and otherwise what changed in it.
+// It must be called with st, the state of config, locked.
+func (c *Client) syncConfig(ctx context.Context, st *configState, config adctypes.Config, full bool) error {
+	name := config.Name
+	// The endpoints that took this push hold what the config is known good as after it,
+	// and a new known good version is one the data plane was pushed.
+	before := st.lastGoodVersion().version
+	defer func() {
+		version := st.lastGoodVersion().version
+		c.markEndpointsSynced(name, version)
+		if version != before {
+			c.recordPush(name, version)
+		}
+	}()
+	delta, err := c.Delta(name)
+	if err != nil {
+		return errors.Wrap(err, "failed to get resources from store")
+	}
 
+	// A config that now points somewhere else, or is pushed some other way, has not had
+	// any of its content pushed there yet.
+	fingerprint := configFingerprint(config)
+	reason := delta.FullReason
+	switch {
+	case full:
+		reason = "periodic full sync"
+	case st.fingerprint != fingerprint:
+		reason = "config changed"
+	case len(delta.Changes) > maxDeltaOwners:
+		reason = "too many changed owners"
+	case c.fileExecutor != nil && !delta.Empty():
+		reason = "files are written whole"
+	}
+	configs := map[types.NamespacedNameKind]adctypes.Config{{}: config}
+
+	if reason != "" {
+		// What was translated from a quarantined object stays as the data plane last
+		// accepted it.
+		resources, err := delta.Resources()
+		if err != nil {
+			return errors.Wrap(err, "failed to get resources from store")
+		}
+		held := c.quarantinedObjects(name, resources)
+		if len(held) > 0 {
+			resources = withLastGood(resources, st.lastGoodVersion().resources, held)
+		}
+		c.log.Info("syncing resources for config", "config", name, "reason", reason,
+			"service_number", len(resources.Services), "quarantined", len(held))
 		if err := c.sync(ctx, Task{
-			Name: name + "-sync",
-			Configs: map[types.NamespacedNameKind]adctypes.Config{
-				{}: config,
-			},
+			Name:      name + "-sync",
+			Configs:   configs,
 			Resources: resources,
 		}); err != nil {
-			c.log.Error(err, "failed to sync resources", "name", name)
-			failedConfigs = append(failedConfigs, name)
-			var execErrs types.ADCExecutionErrors
-			if errors.As(err, &execErrs) {
-				failedMap[name] = execErrs
+			rejected, ok := c.rollBackRejected(ctx, st, config, resources, err)
+			if !ok {
+				// What the data plane refused may have been marked synced long ago, and only
+				// a whole push tries it again.
+				c.ResetSynced(name)
+				return err
+			}
+			c.MarkSyncedExcept(name, delta, append(held, rejected...))
+			st.fingerprint = fingerprint
+			// Nothing is left to retry once every rejected object is quarantined.
+			if !c.quarantineRejected(name, resources, err) {
+				return err
 			}
+			return nil
 		}
+		if len(held) > 0 {
+			c.MarkSyncedExcept(name, delta, held)
+		} else {
+			c.MarkSynced(name, delta)
+		}
+		st.recordLastGood(resources)
+		st.fingerprint = fingerprint
+		return nil
 	}
 
-	var err error
-	if len(failedConfigs) > 0 {
-		err = fmt.Errorf("failed to sync %d configs: %s",
-			len(failedConfigs),
-			strings.Join(failedConfigs, ", "))
+	if len(delta.Changes) == 0 {
+		return nil
 	}
-	return failedMap, err
+	c.log.Info("syncing changed resources for config", "config", name, "owners", len(delta.Changes))
+
+	var errs types.ADCExecutionErrors
+	for _, change := range delta.Changes {
+		if c.isQuarantined(name, types.NamespacedNameKind{
+			Kind:      change.Owner.Kind,
+			Namespace: change.Owner.Namespace,
+			Name:      change.Owner.Name,
+		}) {
+			continue
+		}
+		err := c.sync(ctx, Task{
+			Name:          name + "-delta",
+			Labels:        change.Labels,
+			ResourceTypes: change.ResourceTypes,
+			Configs:       configs,
+			Resources:     change.Resources,
+		})
+		if err == nil {
+			c.MarkChangeSynced(name, delta, change)
+			st.recordLastGoodChange(change)
+			continue
+		}
+		var execErrs types.ADCExecutionErrors
+		if !errors.As(err, &execErrs) {
+			return err
+		}
+		if c.quarantineRejected(name, change.Resources, err) {
+			continue
+		}
+		errs.Errors = append(errs.Errors, execErrs.Errors...)
+	}
+	if len(errs.Errors) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// configFingerprint covers what decides where and how a config is pushed.
+func configFingerprint(config adctypes.Config) string {
+	return strings.Join([]string{
+		strings.Join(config.ServerAddrs, ","),
+		config.Token,
+		strconv.FormatBool(config.TlsVerify),
+		config.BackendType,
+		config.Executor,
+	}, "|")
 }
 
 // push syncs one config through the ADC server, re-deriving the baseline ADC diffs against
@@ -349,11 +709,15 @@ func (c *Client) Sync(ctx context.Context) (map[string]types.ADCExecutionErrors,
 // what it cannot foresee -- another writer on this data plane, a desync no leadership change
 // explains -- and a conf_version the data plane refuses is the only way any of that shows
 // itself. Re-read the data plane and push again.
-func (c *Client) push(ctx context.Context, config adctypes.Config, args []string) ([]types.ADCExecutionError, error) {
+func (c *Client) push(ctx context.Context, config adctypes.Config, req ADCRequest) ([]types.ADCExecutionError, error) {
+	mode := cmp.Or(config.BackendType, c.defaultMode)
+	pkgmetrics.SetConfigInfo(config.Name, mode, c.executorName(config))
 	standalone := config.BackendType == backendAPISIXStandalone
-	config.BypassCache = standalone && !c.baselineIsCurrent(config.Name)
+	// A caller that already knows the baseline is wrong, as healing drift does, asks for
+	// the rebuild itself, whatever the mode.
+	config.BypassCache = config.BypassCache || standalone && !c.baselineIsCurrent(config.Name)
 
-	err := c.executor.Execute(ctx, config, args)
+	err := c.executorFor(config).Execute(ctx, config, req)
 
 	var alsoReport []types.ADCExecutionError
 	if standalone && !config.BypassCache && isConfVersionRejection(err) {
@@ -362,10 +726,10 @@ func (c *Client) push(ctx context.Context, config adctypes.Config, args []string
 		// Keep the rejection visible even when the sync recovers. The rebuild is not rate
 		// limited, so a rejection on every sync -- someone else writing to this data plane --
 		// turns every sync into a full fetch and diff, and this counter is what says so.
-		pkgmetrics.RecordExecutionError(config.Name, "conf_version_conflict")
+		pkgmetrics.RecordExecutionError(config.Name, mode, "conf_version_conflict")
 
 		config.BypassCache = true
-		retryErr := c.executor.Execute(ctx, config, args)
+		retryErr := c.executorFor(config).Execute(ctx, config, req)
 
 		// Report the rejection as well. On its own a failed rebuild says nothing about what it
 		// was rebuilding for, and it is the rejection that names the cause -- an ADC server too
@@ -396,20 +760,6 @@ func (c *Client) sync(ctx context.Context, task Task) error {
 
 	var errs types.ADCExecutionErrors
 
-	// Record file I/O duration
-	fileIOStart := time.Now()
-	// every task resources is the same, so we can use the first config to prepare the sync file
-	syncFilePath, cleanup, err := prepareSyncFile(task.Resources)
-	if err != nil {
-		pkgmetrics.RecordFileIODuration("prepare_sync_file", "failure", time.Since(fileIOStart).Seconds())
-		return err
-	}
-	pkgmetrics.RecordFileIODuration("prepare_sync_file", adctypes.StatusSuccess, time.Since(fileIOStart).Seconds())
-	defer cleanup()
-	c.log.V(1).Info("prepared sync file", "path", syncFilePath)
-
-	args := BuildADCExecuteArgs(syncFilePath, task.Labels, task.ResourceTypes)
-
 	for _, config := range task.Configs {
 		// Record sync duration for each config
 		startTime := time.Now()
@@ -420,8 +770,14 @@ func (c *Client) sync(ctx context.Context, task Task) error {
 		if config.BackendType == "" {
 			config.BackendType = c.defaultMode
 		}
+		// An endpoint whose circuit is open is only caught up, see CatchUpEndpoints.
+		config.ServerAddrs = c.usableEndpoints(config)
+
+		req := task.request(config)
+		c.dumpRequest(operationSync, req)
 
-		alsoReport, err := c.push(ctx, config, args)
+		alsoReport, err := c.push(ctx, config, req)
+		err = c.recordEndpoints(config, err)
 		errs.Errors = append(errs.Errors, alsoReport...)
 
 		duration := time.Since(startTime).Seconds()
@@ -434,14 +790,14 @@ func (c *Client) sync(ctx context.Context, task Task) error {
 			var execErr types.ADCExecutionError
 			if errors.As(err, &execErr) {
 				errs.Errors = append(errs.Errors, execErr)
-				pkgmetrics.RecordExecutionError(config.Name, execErr.Name)
+				pkgmetrics.RecordExecutionError(config.Name, config.BackendType, execErr.Name)
 			} else {
-				pkgmetrics.RecordExecutionError(config.Name, "unknown")
+				pkgmetrics.RecordExecutionError(config.Name, config.BackendType, "unknown")
 			}
 		}
 
 		// Record metrics
-		pkgmetrics.RecordSyncDuration(config.Name, resourceType, status, duration)
+		pkgmetrics.RecordSyncDuration(config.Name, config.BackendType, resourceType, status, duration)
 	}
 
 	if len(errs.Errors) > 0 {
@@ -449,25 +805,3 @@ func (c *Client) sync(ctx context.Context, task Task) error {
 	}
 	return nil
 }
-
-func prepareSyncFile(resources any) (string, func(), error) {
-	data, err := json.Marshal(resources)
-	if err != nil {
-		return "", nil, err
-	}
-
-	tmpFile, err := os.CreateTemp("", "adc-task-*.json")
-	if err != nil {
-		return "", nil, err
-	}
-	cleanup := func() {
-		_ = tmpFile.Close()
-		_ = os.Remove(tmpFile.Name())
-	}
-	if _, err := tmpFile.Write(data); err != nil {
-		cleanup()
-		return "", nil, err
-	}
-
-	return tmpFile.Name(), cleanup, nil
-}
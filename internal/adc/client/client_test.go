@@ -0,0 +1,284 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/controller/label"
+	"github.com/apache/apisix-ingress-controller/internal/provider/common"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+var deltaGatewayProxy = types.NamespacedNameKind{Kind: "GatewayProxy", Namespace: "ns", Name: "name"}
+
+// newDeltaClient is a client with one config, "GatewayProxy/ns/name", holding the
+// HTTPRoutes named.
+func newDeltaClient(t *testing.T, exec ADCExecutor, routes ...string) *Client {
+	t.Helper()
+	c := afterFirstSync(exec)
+	c.ConfigManager = common.NewConfigManager[types.NamespacedNameKind, adctypes.Config]()
+	c.ConfigManager.UpdateConfig(deltaGatewayProxy, adctypes.Config{
+		Name:        syncTaskCacheKey,
+		ServerAddrs: []string{"http://apisix:9180"},
+		BackendType: "apisix-standalone",
+	})
+	for _, route := range routes {
+		updateRoute(t, c, route, route+".example.com")
+	}
+	return c
+}
+
+func httpRouteLabels(name string) map[string]string {
+	return map[string]string{
+		label.LabelKind:      "HTTPRoute",
+		label.LabelNamespace: "ns",
+		label.LabelName:      name,
+	}
+}
+
+func updateRoute(t *testing.T, c *Client, name, host string) {
+	t.Helper()
+	labels := httpRouteLabels(name)
+	require.NoError(t, c.UpdateConfig(context.Background(), Task{
+		Key:           types.NamespacedNameKind{Kind: "HTTPRoute", Namespace: "ns", Name: name},
+		Labels:        labels,
+		ResourceTypes: []string{adctypes.TypeService},
+		Configs:       map[types.NamespacedNameKind]adctypes.Config{deltaGatewayProxy: c.ConfigManager.List()[deltaGatewayProxy]},
+		Resources: &adctypes.Resources{Services: []*adctypes.Service{{
+			Metadata: adctypes.Metadata{ID: name, Name: name, Labels: labels},
+			Hosts:    []string{host},
+		}}},
+	}))
+}
+
+func TestClientSyncChangesPushesOnlyTheOwnersThatChanged(t *testing.T) {
+	exec := &fakeExecutor{}
+	c := newDeltaClient(t, exec, "a", "b")
+
+	_, err := c.SyncChanges(context.Background())
+	require.NoError(t, err)
+	require.Len(t, exec.reqs, 1, "a config that was never pushed is pushed whole")
+	assert.Nil(t, exec.reqs[0].Labels)
+	assert.Len(t, exec.reqs[0].Resources.Services, 2)
+
+	updateRoute(t, c, "b", "b2.example.com")
+	_, err = c.SyncChanges(context.Background())
+	require.NoError(t, err)
+	require.Len(t, exec.reqs, 2)
+	req := exec.reqs[1]
+	assert.Equal(t, httpRouteLabels("b"), req.Labels)
+	assert.Equal(t, []string{adctypes.TypeService}, req.ResourceTypes)
+	require.Len(t, req.Resources.Services, 1)
+	assert.Equal(t, []string{"b2.example.com"}, req.Resources.Services[0].Hosts)
+
+	_, err = c.SyncChanges(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, exec.reqs, 2, "nothing changed, nothing is pushed")
+}
+
+func TestClientSyncChangesDeletesARemovedOwner(t *testing.T) {
+	exec := &fakeExecutor{}
+	c := newDeltaClient(t, exec, "a", "b")
+	_, err := c.SyncChanges(context.Background())
+	require.NoError(t, err)
+
+	require.NoError(t, c.DeleteConfig(context.Background(), Task{
+		Key:           types.NamespacedNameKind{Kind: "HTTPRoute", Namespace: "ns", Name: "a"},
+		Labels:        httpRouteLabels("a"),
+		ResourceTypes: []string{adctypes.TypeService},
+	}))
+	_, err = c.SyncChanges(context.Background())
+	require.NoError(t, err)
+	require.Len(t, exec.reqs, 2)
+	assert.Equal(t, httpRouteLabels("a"), exec.reqs[1].Labels)
+	assert.Empty(t, exec.reqs[1].Resources.Services, "an empty push under the owner's labels deletes what it had")
+}
+
+func TestClientSyncChangesRetriesWhatFailed(t *testing.T) {
+	exec := &fakeExecutor{}
+	c := newDeltaClient(t, exec, "a")
+	_, err := c.SyncChanges(context.Background())
+	require.NoError(t, err)
+
+	exec.errs = []error{types.ADCExecutionError{Name: syncTaskCacheKey}}
+	updateRoute(t, c, "a", "a2.example.com")
+	failed, err := c.SyncChanges(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, failed, syncTaskCacheKey)
+
+	_, err = c.SyncChanges(context.Background())
+	require.NoError(t, err)
+	require.Len(t, exec.reqs, 3)
+	assert.Equal(t, httpRouteLabels("a"), exec.reqs[2].Labels)
+}
+
+func TestClientSyncPushesWholeAndRetriesWholeAfterAFailure(t *testing.T) {
+	exec := &fakeExecutor{}
+	c := newDeltaClient(t, exec, "a")
+	_, err := c.SyncChanges(context.Background())
+	require.NoError(t, err)
+
+	exec.errs = []error{types.ADCExecutionError{Name: syncTaskCacheKey}}
+	_, err = c.Sync(context.Background())
+	require.Error(t, err)
+	assert.Nil(t, exec.reqs[1].Labels, "the periodic sync pushes everything")
+
+	_, err = c.SyncChanges(context.Background())
+	require.NoError(t, err)
+	require.Len(t, exec.reqs, 3)
+	assert.Nil(t, exec.reqs[2].Labels, "a whole push that failed is retried whole")
+}
+
+func TestClientSyncChangesPushesWholeWhenTheConfigChanged(t *testing.T) {
+	exec := &fakeExecutor{}
+	c := newDeltaClient(t, exec, "a")
+	_, err := c.SyncChanges(context.Background())
+	require.NoError(t, err)
+
+	config := c.ConfigManager.List()[deltaGatewayProxy]
+	config.ServerAddrs = []string{"http://apisix-2:9180"}
+	c.ConfigManager.UpdateConfig(deltaGatewayProxy, config)
+	_, err = c.SyncChanges(context.Background())
+	require.NoError(t, err)
+	require.Len(t, exec.reqs, 2)
+	assert.Nil(t, exec.reqs[1].Labels)
+}
+
+// gatedExecutor holds every push of the configs in gates until their gate is closed.
+type gatedExecutor struct {
+	gates  map[string]chan struct{}
+	mu     sync.Mutex
+	pushed []string
+}
+
+func (g *gatedExecutor) Execute(_ context.Context, config adctypes.Config, _ ADCRequest) error {
+	if gate, ok := g.gates[config.Name]; ok {
+		<-gate
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.pushed = append(g.pushed, config.Name)
+	return nil
+}
+
+func (g *gatedExecutor) Validate(context.Context, adctypes.Config, ADCRequest) error { return nil }
+
+func (g *gatedExecutor) pushedConfigs() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return slices.Clone(g.pushed)
+}
+
+func newMultiConfigClient(exec ADCExecutor, names ...string) *Client {
+	c := newTestClient(exec)
+	c.ConfigManager = common.NewConfigManager[types.NamespacedNameKind, adctypes.Config]()
+	for _, name := range names {
+		c.ConfigManager.UpdateConfig(types.NamespacedNameKind{Kind: "GatewayProxy", Namespace: "ns", Name: name}, adctypes.Config{
+			Name:        name,
+			ServerAddrs: []string{"http://" + name + ":9180"},
+			BackendType: "apisix",
+		})
+	}
+	return c
+}
+
+func TestClientSyncDoesNotWaitForASlowConfig(t *testing.T) {
+	slow := make(chan struct{})
+	exec := &gatedExecutor{gates: map[string]chan struct{}{"slow": slow}}
+	c := newMultiConfigClient(exec, "slow", "fast")
+	c.SyncConcurrency = 2
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := c.Sync(context.Background())
+		assert.NoError(t, err)
+	}()
+
+	require.Eventually(t, func() bool {
+		return slices.Contains(exec.pushedConfigs(), "fast")
+	}, 5*time.Second, 10*time.Millisecond, "the fast config is pushed while the slow one is still pushing")
+	close(slow)
+	<-done
+	assert.ElementsMatch(t, []string{"slow", "fast"}, exec.pushedConfigs())
+}
+
+func TestClientSyncChangesPushesOnlyTheConfigsNamed(t *testing.T) {
+	exec := &gatedExecutor{}
+	c := newMultiConfigClient(exec, "a", "b")
+
+	_, err := c.SyncChanges(context.Background(), "b")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, exec.pushedConfigs())
+}
+
+func TestClientSyncNamesTheConfigsThatFailed(t *testing.T) {
+	exec := &fakeExecutor{errs: []error{types.ADCExecutionError{Name: "a"}}}
+	c := newMultiConfigClient(exec, "a")
+
+	failed, err := c.Sync(context.Background())
+	var syncErr *SyncError
+	require.ErrorAs(t, err, &syncErr)
+	assert.Equal(t, []string{"a"}, syncErr.Configs)
+	assert.Contains(t, failed, "a")
+}
+
+// validatingExecutor fails the validation of each config in errs with its error.
+type validatingExecutor struct {
+	gatedExecutor
+	errs map[string]error
+}
+
+func (v *validatingExecutor) Validate(_ context.Context, config adctypes.Config, _ ADCRequest) error {
+	return v.errs[config.Name]
+}
+
+func TestClientValidateChecksEveryConfig(t *testing.T) {
+	exec := &validatingExecutor{errs: map[string]error{"down": errors.New("connection refused")}}
+	c := newTestClient(exec)
+	task := Task{
+		Configs: map[types.NamespacedNameKind]adctypes.Config{
+			{Name: "down"}:    {Name: "down"},
+			{Name: "refuses"}: {Name: "refuses"},
+		},
+		Resources: &adctypes.Resources{},
+	}
+
+	err := c.Validate(context.Background(), task)
+	var validateErr *ValidateError
+	require.ErrorAs(t, err, &validateErr)
+	assert.Equal(t, []string{"down"}, validateErr.Configs)
+
+	// What one config refuses is refused, though another could not be asked.
+	exec.errs["refuses"] = types.ADCValidationError{Name: "refuses"}
+	err = c.Validate(context.Background(), task)
+	var validationErrs types.ADCValidationErrors
+	require.ErrorAs(t, err, &validationErrs)
+	require.Len(t, validationErrs.Errors, 1)
+	assert.Equal(t, "refuses", validationErrs.Errors[0].Name)
+}
@@ -0,0 +1,68 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
+)
+
+// ErrRoutesNotHeld is returned by a check for route conflicts on a replica that does not
+// hold the routes of the other objects: only the leader translates and stores them.
+var ErrRoutesNotHeld = errors.New("this replica is not the leader and does not hold the routes of other objects")
+
+// CheckRouteConflicts returns the conflicts the routes of task would have, in each of its
+// configs, with the routes of other objects. A config the store holds nothing for yet is
+// left out.
+func (c *Client) CheckRouteConflicts(task Task) ([]cache.RouteConflict, error) {
+	var conflicts []cache.RouteConflict
+	if task.Resources == nil {
+		return nil, nil
+	}
+	for _, config := range task.Configs {
+		found, _, err := c.Store.CheckRouteConflicts(config.Name, task.Resources.Services, task.Labels)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to check route conflicts in config %s", config.Name)
+		}
+		conflicts = append(conflicts, found...)
+	}
+	return conflicts, nil
+}
+
+// RouteConflicts returns the conflicts among the routes of every config, ordered by
+// config.
+func (c *Client) RouteConflicts() ([]cache.RouteConflict, error) {
+	var names []string
+	for _, config := range c.ConfigManager.List() {
+		names = append(names, config.Name)
+	}
+	slices.Sort(names)
+
+	var conflicts []cache.RouteConflict
+	for _, name := range slices.Compact(names) {
+		found, err := c.Store.RouteConflicts(name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to find route conflicts in config %s", name)
+		}
+		conflicts = append(conflicts, found...)
+	}
+	return conflicts, nil
+}
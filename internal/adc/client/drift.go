@@ -0,0 +1,383 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+	pkgmetrics "github.com/apache/apisix-ingress-controller/pkg/metrics"
+)
+
+const (
+	driftMissing  = "missing"
+	driftExtra    = "extra"
+	driftModified = "modified"
+)
+
+// DriftReport is what the drift detector found on one endpoint of one config. Objects
+// are listed by id, per Admin API collection.
+type DriftReport struct {
+	Config       string                   `json:"config"`
+	GatewayProxy types.NamespacedNameKind `json:"gatewayProxy"`
+	Server       string                   `json:"server"`
+	CheckedAt    time.Time                `json:"checkedAt"`
+
+	// Missing are objects the controller computed that the endpoint does not hold.
+	Missing map[string][]string `json:"missing,omitempty"`
+	// Extra are objects the endpoint holds that the controller did not compute. Plugin
+	// metadata cannot be listed, so it is never reported extra.
+	Extra map[string][]string `json:"extra,omitempty"`
+	// Modified are objects the endpoint holds differently from how they were computed.
+	Modified map[string][]string `json:"modified,omitempty"`
+
+	// Healed is set when the config was pushed again because of this report.
+	Healed bool `json:"healed,omitempty"`
+	// Error is set when the endpoint could not be read back.
+	Error string `json:"error,omitempty"`
+}
+
+// Drifted reports whether the endpoint holds anything other than what was computed.
+func (r DriftReport) Drifted() bool {
+	return len(r.Missing) > 0 || len(r.Extra) > 0 || len(r.Modified) > 0
+}
+
+func (r *DriftReport) add(drift, collection, id string) {
+	var byCollection *map[string][]string
+	switch drift {
+	case driftMissing:
+		byCollection = &r.Missing
+	case driftExtra:
+		byCollection = &r.Extra
+	default:
+		byCollection = &r.Modified
+	}
+	if *byCollection == nil {
+		*byCollection = make(map[string][]string)
+	}
+	(*byCollection)[collection] = append((*byCollection)[collection], id)
+}
+
+// DetectDrift reads every config back from each of its endpoints and compares it with
+// what the store holds for it. With heal set, a config found drifted on any endpoint is
+// pushed again whole, re-reading the data plane rather than trusting any baseline.
+//
+// Objects are compared in the layout ADC writes them to the Admin API, and only in the
+// fields the controller sets: whatever APISIX fills in by default is not drift. Fields
+// APISIX may store encrypted -- SSL keys, credential secrets -- are not compared.
+func (c *Client) DetectDrift(ctx context.Context, heal bool) []DriftReport {
+	configs := c.ConfigManager.List()
+	keys := slices.SortedFunc(maps.Keys(configs), func(a, b types.NamespacedNameKind) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	var reports []DriftReport
+	for _, key := range keys {
+		config := configs[key]
+		backend := config.BackendType
+		if backend == "" {
+			backend = c.defaultMode
+		}
+
+		resources, err := c.GetResources(config.Name)
+		var desired []adminAPIObject
+		if err == nil {
+			desired, err = buildAdminAPIObjects(resources, nil)
+		}
+
+		var configReports []DriftReport
+		drifted := false
+		for _, server := range config.ServerAddrs {
+			report := DriftReport{Config: config.Name, GatewayProxy: key, Server: server, CheckedAt: time.Now()}
+			if err != nil {
+				report.Error = err.Error()
+			} else if readErr := c.dataPlane.checkDrift(ctx, server, config, backend, desired, &report); readErr != nil {
+				report.Error = readErr.Error()
+			}
+			drifted = drifted || report.Drifted()
+			configReports = append(configReports, report)
+		}
+
+		if heal && drifted {
+			c.log.Info("data plane drifted, pushing the config again", "config", config.Name)
+			if err := c.heal(ctx, config); err != nil {
+				c.log.Error(err, "failed to heal drifted config", "config", config.Name)
+			} else {
+				for i := range configReports {
+					configReports[i].Healed = configReports[i].Drifted()
+				}
+			}
+		}
+		reports = append(reports, configReports...)
+	}
+
+	pkgmetrics.ResetDriftResources()
+	for _, report := range reports {
+		for drift, byCollection := range map[string]map[string][]string{
+			driftMissing:  report.Missing,
+			driftExtra:    report.Extra,
+			driftModified: report.Modified,
+		} {
+			for collection, ids := range byCollection {
+				pkgmetrics.SetDriftResources(report.Config, report.Server, collection, drift, float64(len(ids)))
+			}
+		}
+	}
+
+	c.driftMu.Lock()
+	c.driftReports = reports
+	c.driftMu.Unlock()
+	return reports
+}
+
+// DriftReports returns what the last DetectDrift found.
+func (c *Client) DriftReports() []DriftReport {
+	c.driftMu.Lock()
+	defer c.driftMu.Unlock()
+	return slices.Clone(c.driftReports)
+}
+
+// heal pushes config whole, with the baseline re-derived from the data plane: the
+// drift that calls for it is exactly what a baseline cannot know about.
+func (c *Client) heal(ctx context.Context, config adctypes.Config) error {
+	st := c.lockConfig(config.Name)
+	defer st.mu.Unlock()
+	c.ResetSynced(config.Name)
+	config.BypassCache = true
+	return c.syncConfig(ctx, st, config, true)
+}
+
+// driftSecretFields are the fields APISIX may hand back encrypted, by the collections
+// they occur in.
+var driftSecretFields = map[string][]string{
+	collectionSSLs:        {"key", "keys"},
+	collectionConsumers:   {"key", "password", "secret", "secret_key", "private_key"},
+	collectionCredentials: {"key", "password", "secret", "secret_key", "private_key"},
+}
+
+// checkDrift reads what server holds and adds to report how it differs from desired.
+func (e *AdminAPIExecutor) checkDrift(
+	ctx context.Context,
+	server string,
+	config adctypes.Config,
+	backend string,
+	desired []adminAPIObject,
+	report *DriftReport,
+) error {
+	var (
+		remote map[string]map[string]any
+		err    error
+	)
+	if backend == backendAPISIXStandalone {
+		remote, err = e.readStandalone(ctx, server, config)
+	} else {
+		remote, err = e.readAPISIX(ctx, server, config, desired)
+	}
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[string]struct{}, len(desired))
+	for _, obj := range desired {
+		wanted[obj.key()] = struct{}{}
+		body, ok := remote[obj.key()]
+		if !ok {
+			report.add(driftMissing, obj.collection, obj.id)
+			continue
+		}
+		if !driftContains(normalizeDriftBody(body), normalizeDriftBody(obj.body), driftSecretFields[obj.collection]) {
+			report.add(driftModified, obj.collection, obj.id)
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(remote)) {
+		if _, ok := wanted[key]; ok {
+			continue
+		}
+		collection, id, _ := strings.Cut(key, "/")
+		report.add(driftExtra, collection, id)
+	}
+	for _, byCollection := range []map[string][]string{report.Missing, report.Extra, report.Modified} {
+		for _, ids := range byCollection {
+			sort.Strings(ids)
+		}
+	}
+	return nil
+}
+
+// readAPISIX lists every object server holds, keyed the way adminAPIObject.key keys
+// them. Plugin metadata cannot be listed, so only the names in desired are fetched.
+func (e *AdminAPIExecutor) readAPISIX(
+	ctx context.Context,
+	server string,
+	config adctypes.Config,
+	desired []adminAPIObject,
+) (map[string]map[string]any, error) {
+	remote := make(map[string]map[string]any)
+	for _, collection := range adminAPICreateOrder {
+		if collection == collectionCredentials || collection == collectionPluginMetadata {
+			continue
+		}
+		items, err := e.list(ctx, server, config, "/"+collection)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			id := itemID(collection, item)
+			remote[collection+"/"+id] = item
+			if collection != collectionConsumers {
+				continue
+			}
+			credentials, err := e.list(ctx, server, config, "/"+collectionConsumers+"/"+id+"/"+collectionCredentials)
+			if err != nil {
+				return nil, err
+			}
+			for _, credential := range credentials {
+				remote[collectionCredentials+"/"+id+"/"+collectionCredentials+"/"+itemID(collectionCredentials, credential)] = credential
+			}
+		}
+	}
+
+	for _, obj := range desired {
+		if obj.collection != collectionPluginMetadata {
+			continue
+		}
+		req, err := e.newRequest(ctx, server, config, http.MethodGet, obj.path(), nil)
+		if err != nil {
+			return nil, err
+		}
+		status, body, err := e.send(config, req)
+		if err != nil {
+			return nil, err
+		}
+		if status == http.StatusNotFound {
+			continue
+		}
+		var resp struct {
+			Value map[string]any `json:"value"`
+		}
+		if status/100 != 2 || json.Unmarshal(body, &resp) != nil {
+			return nil, fmt.Errorf("failed to read %s: HTTP %d: %s", obj.path(), status, string(body))
+		}
+		remote[obj.key()] = resp.Value
+	}
+	return remote, nil
+}
+
+// readStandalone splits the configuration a standalone server holds into objects, keyed
+// the way adminAPIObject.key keys them.
+func (e *AdminAPIExecutor) readStandalone(ctx context.Context, server string, config adctypes.Config) (map[string]map[string]any, error) {
+	document, err := e.getConfigs(ctx, server, config)
+	if err != nil {
+		return nil, err
+	}
+	remote := make(map[string]map[string]any)
+	for _, collection := range adminAPICreateOrder {
+		if collection == collectionCredentials {
+			continue
+		}
+		items, _ := document[collection].([]any)
+		for _, raw := range items {
+			item, ok := raw.(map[string]any)
+			if !ok {
+				continue
+			}
+			key := collection + "/" + itemID(collection, item)
+			if collection == collectionConsumers && isStandaloneCredential(item) {
+				key = collectionCredentials + "/" + itemID(collection, item)
+			}
+			remote[key] = item
+		}
+	}
+	return remote, nil
+}
+
+// normalizeDriftBody gives numbers one representation, however the body was decoded.
+func normalizeDriftBody(body map[string]any) any {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return body
+	}
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return body
+	}
+	return normalized
+}
+
+// driftContains reports whether remote holds everything desired sets, ignoring the
+// fields named in skip at any depth. An empty object or list matches one that is
+// absent, since APISIX does not tell them apart.
+func driftContains(remote, desired any, skip []string) bool {
+	switch d := desired.(type) {
+	case map[string]any:
+		if len(d) == 0 {
+			return isEmptyDriftValue(remote)
+		}
+		r, ok := remote.(map[string]any)
+		if !ok {
+			return false
+		}
+		for k, v := range d {
+			if slices.Contains(skip, k) {
+				continue
+			}
+			if !driftContains(r[k], v, skip) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		if len(d) == 0 {
+			return isEmptyDriftValue(remote)
+		}
+		r, ok := remote.([]any)
+		if !ok || len(r) != len(d) {
+			return false
+		}
+		for i := range d {
+			if !driftContains(r[i], d[i], skip) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(remote, desired)
+	}
+}
+
+func isEmptyDriftValue(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case map[string]any:
+		return len(v) == 0
+	case []any:
+		return len(v) == 0
+	}
+	return false
+}
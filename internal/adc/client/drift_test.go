@@ -0,0 +1,141 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/provider/common"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+var driftGatewayProxy = types.NamespacedNameKind{Kind: "GatewayProxy", Namespace: "default", Name: "apisix"}
+
+// newDriftClient is a client whose one config is synced natively to a fake Admin API
+// in mode, and already pushed.
+func newDriftClient(t *testing.T, mode string) (*Client, *fakeAdminAPI) {
+	t.Helper()
+	fake, server := newFakeAdminAPI(t)
+	adminAPI := NewAdminAPIExecutor(logr.Discard(), 5*time.Second)
+
+	c := newTestClient(adminAPI)
+	c.dataPlane = adminAPI
+	c.ConfigManager = common.NewConfigManager[types.NamespacedNameKind, adctypes.Config]()
+	config := adminAPIConfig(server, mode)
+	c.ConfigManager.UpdateConfig(driftGatewayProxy, config)
+	require.NoError(t, c.Insert(config.Name, []string{
+		adctypes.TypeService, adctypes.TypeSSL, adctypes.TypeConsumer, adctypes.TypeGlobalRule,
+	}, adminAPIResources(), nil))
+
+	_, err := c.Sync(context.Background())
+	require.NoError(t, err)
+	return c, fake
+}
+
+func TestDetectDriftFindsNothingRightAfterASync(t *testing.T) {
+	for _, mode := range []string{"apisix", backendAPISIXStandalone} {
+		t.Run(mode, func(t *testing.T) {
+			c, _ := newDriftClient(t, mode)
+
+			reports := c.DetectDrift(context.Background(), false)
+			require.Len(t, reports, 1)
+			assert.Empty(t, reports[0].Error)
+			assert.False(t, reports[0].Drifted(), "%+v", reports[0])
+			assert.Equal(t, driftGatewayProxy, reports[0].GatewayProxy)
+			assert.Equal(t, reports, c.DriftReports())
+		})
+	}
+}
+
+func TestDetectDriftReportsMissingExtraAndModifiedObjects(t *testing.T) {
+	c, fake := newDriftClient(t, "apisix")
+
+	fake.mu.Lock()
+	delete(fake.objects, "routes/route1")
+	fake.objects["routes/stray"] = map[string]any{"id": "stray", "uris": []any{"/stray"}}
+	fake.objects["services/svc1"]["hosts"] = []any{"evil.org"}
+	// What APISIX fills in itself is not drift.
+	fake.objects["ssls/ssl1"]["create_time"] = 1700000000
+	fake.objects["ssls/ssl1"]["key"] = "encrypted"
+	fake.mu.Unlock()
+
+	reports := c.DetectDrift(context.Background(), false)
+	require.Len(t, reports, 1)
+	report := reports[0]
+	assert.Empty(t, report.Error)
+	assert.Equal(t, map[string][]string{collectionRoutes: {"route1"}}, report.Missing)
+	assert.Equal(t, map[string][]string{collectionRoutes: {"stray"}}, report.Extra)
+	assert.Equal(t, map[string][]string{collectionServices: {"svc1"}}, report.Modified)
+	assert.False(t, report.Healed)
+	assert.Nil(t, fake.object("routes/route1"), "alert only leaves the data plane alone")
+}
+
+func TestDetectDriftHealsWhenAskedTo(t *testing.T) {
+	c, fake := newDriftClient(t, "apisix")
+
+	fake.mu.Lock()
+	delete(fake.objects, "routes/route1")
+	fake.mu.Unlock()
+
+	reports := c.DetectDrift(context.Background(), true)
+	require.Len(t, reports, 1)
+	assert.True(t, reports[0].Healed)
+	assert.NotNil(t, fake.object("routes/route1"))
+
+	reports = c.DetectDrift(context.Background(), true)
+	assert.False(t, reports[0].Drifted())
+}
+
+func TestDetectDriftReportsAnEndpointItCannotRead(t *testing.T) {
+	c, _ := newDriftClient(t, "apisix")
+	config := c.ConfigManager.List()[driftGatewayProxy]
+	config.Token = "wrong"
+	c.ConfigManager.UpdateConfig(driftGatewayProxy, config)
+
+	reports := c.DetectDrift(context.Background(), true)
+	require.Len(t, reports, 1)
+	assert.NotEmpty(t, reports[0].Error)
+	assert.False(t, reports[0].Healed)
+}
+
+func TestDriftContains(t *testing.T) {
+	desired := map[string]any{
+		"hosts":    []any{"a.org"},
+		"plugins":  map[string]any{},
+		"upstream": map[string]any{"nodes": []any{map[string]any{"host": "10.0.0.1", "port": float64(80)}}},
+	}
+	remote := map[string]any{
+		"hosts":       []any{"a.org"},
+		"create_time": float64(1),
+		"upstream": map[string]any{
+			"scheme": "http",
+			"nodes":  []any{map[string]any{"host": "10.0.0.1", "port": float64(80), "priority": float64(0)}},
+		},
+	}
+	assert.True(t, driftContains(remote, desired, nil))
+
+	remote["hosts"] = []any{"a.org", "b.org"}
+	assert.False(t, driftContains(remote, desired, nil))
+}
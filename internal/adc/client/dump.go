@@ -0,0 +1,128 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	pkgmetrics "github.com/apache/apisix-ingress-controller/pkg/metrics"
+)
+
+// dumpDirEnv names a directory every request is written to before it is sent, to see
+// exactly what the controller hands an executor. It is meant for debugging only. The
+// dumps are redacted the way the debug API is, see adctypes.Resources.Redacted, and only
+// the latest maxRequestDumps of them are kept. Requests are sent from memory whether or
+// not they are dumped.
+const dumpDirEnv = "ADC_DUMP_DIR"
+
+// maxRequestDumps is how many dumps are kept in the dump directory; older ones are removed
+// as new ones are written.
+const maxRequestDumps = 100
+
+// requestDumpPattern matches the names of the dumps, and nothing else in the directory.
+const requestDumpPattern = "adc-*-*.json"
+
+const (
+	operationSync     = "sync"
+	operationValidate = "validate"
+)
+
+// requestDump is the file layout of a dumped request.
+type requestDump struct {
+	CacheKey      string              `json:"cacheKey"`
+	Labels        map[string]string   `json:"labels,omitempty"`
+	ResourceTypes []string            `json:"resourceTypes,omitempty"`
+	Resources     *adctypes.Resources `json:"resources"`
+}
+
+// dumpRequest writes req to the dump directory, if one is configured. A dump that
+// cannot be written is logged and otherwise ignored.
+func (c *Client) dumpRequest(operation string, req ADCRequest) {
+	if c.dumpDir == "" {
+		return
+	}
+	start := time.Now()
+	path, err := writeRequestDump(c.dumpDir, operation, req)
+	status := adctypes.StatusSuccess
+	if err != nil {
+		status = "failure"
+		c.log.Error(err, "failed to dump request", "dir", c.dumpDir, "cacheKey", req.CacheKey)
+	} else {
+		c.log.V(1).Info("dumped request", "path", path, "operation", operation, "cacheKey", req.CacheKey)
+		if err := pruneRequestDumps(c.dumpDir, maxRequestDumps); err != nil {
+			c.log.Error(err, "failed to remove old request dumps", "dir", c.dumpDir)
+		}
+	}
+	pkgmetrics.RecordFileIODuration("dump_"+operation+"_request", status, time.Since(start).Seconds())
+}
+
+func writeRequestDump(dir, operation string, req ADCRequest) (string, error) {
+	data, err := json.MarshalIndent(requestDump{
+		CacheKey:      req.CacheKey,
+		Labels:        req.Labels,
+		ResourceTypes: req.ResourceTypes,
+		Resources:     req.Resources.Redacted(),
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	f, err := os.CreateTemp(dir, "adc-"+operation+"-*.json")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+	if _, err := f.Write(data); err != nil {
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// pruneRequestDumps removes all but the newest keep dumps in dir.
+func pruneRequestDumps(dir string, keep int) error {
+	paths, err := filepath.Glob(filepath.Join(dir, requestDumpPattern))
+	if err != nil || len(paths) <= keep {
+		return err
+	}
+	type dump struct {
+		path    string
+		modTime time.Time
+	}
+	dumps := make([]dump, 0, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			// Removed since it was listed.
+			continue
+		}
+		dumps = append(dumps, dump{path: path, modTime: info.ModTime()})
+	}
+	slices.SortFunc(dumps, func(a, b dump) int { return a.modTime.Compare(b.modTime) })
+	var errs []error
+	for _, d := range dumps[:max(len(dumps)-keep, 0)] {
+		if err := os.Remove(d.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
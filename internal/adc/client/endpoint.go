@@ -0,0 +1,325 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	"github.com/apache/apisix-ingress-controller/internal/provider/common"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+const (
+	// endpointFailureThreshold is how many pushes in a row an endpoint fails before its
+	// circuit opens: it is left out of pushes, and only caught up in the background.
+	endpointFailureThreshold = 3
+
+	endpointRetryBaseDelay = 5 * time.Second
+	endpointRetryMaxDelay  = 5 * time.Minute
+)
+
+// EndpointStatus is how pushes of one config to one of its endpoints have gone.
+type EndpointStatus struct {
+	Config  string `json:"config"`
+	Address string `json:"address"`
+	// State is one of v1alpha1.GatewayProxyEndpointHealthy, GatewayProxyEndpointUnhealthy
+	// and GatewayProxyEndpointCircuitOpen.
+	State string `json:"state"`
+	// SyncedVersion is the version of the last known good config, see snapshot, the
+	// endpoint last accepted.
+	SyncedVersion int64 `json:"syncedVersion,omitempty"`
+	// Failures counts the pushes the endpoint failed since it last accepted one.
+	Failures           int       `json:"failures,omitempty"`
+	LastError          string    `json:"lastError,omitempty"`
+	LastSyncTime       time.Time `json:"lastSyncTime,omitempty"`
+	LastTransitionTime time.Time `json:"lastTransitionTime"`
+}
+
+type endpointState struct {
+	EndpointStatus
+	// retryAt is when an endpoint that is not healthy is caught up next.
+	retryAt time.Time
+	backoff common.Backoff
+}
+
+func (ep *endpointState) setState(state string, now time.Time) {
+	if ep.State != state {
+		ep.State = state
+		ep.LastTransitionTime = now
+	}
+}
+
+func (ep *endpointState) succeeded(now time.Time) {
+	ep.setState(v1alpha1.GatewayProxyEndpointHealthy, now)
+	ep.Failures = 0
+	ep.LastError = ""
+	ep.LastSyncTime = now
+	ep.backoff.Reset()
+}
+
+func (ep *endpointState) failed(msg string, now time.Time) {
+	ep.Failures++
+	ep.LastError = msg
+	state := v1alpha1.GatewayProxyEndpointUnhealthy
+	if ep.Failures >= endpointFailureThreshold {
+		state = v1alpha1.GatewayProxyEndpointCircuitOpen
+	}
+	ep.setState(state, now)
+	ep.retryAt = now.Add(ep.backoff.Next())
+}
+
+// endpoint returns the state of addr in config name. It must be called with endpointsMu
+// held.
+func (c *Client) endpoint(name, addr string) *endpointState {
+	byAddr, ok := c.endpoints[name]
+	if !ok {
+		byAddr = make(map[string]*endpointState)
+		c.endpoints[name] = byAddr
+	}
+	ep, ok := byAddr[addr]
+	if !ok {
+		ep = &endpointState{
+			EndpointStatus: EndpointStatus{
+				Config:             name,
+				Address:            addr,
+				State:              v1alpha1.GatewayProxyEndpointHealthy,
+				LastTransitionTime: time.Now(),
+			},
+			backoff: common.NewExponentialBackoff(endpointRetryBaseDelay, endpointRetryMaxDelay),
+		}
+		byAddr[addr] = ep
+	}
+	return ep
+}
+
+// usableEndpoints are the endpoints of config a push goes to: all but those whose circuit
+// is open, unless that is all of them.
+func (c *Client) usableEndpoints(config adctypes.Config) []string {
+	c.endpointsMu.Lock()
+	defer c.endpointsMu.Unlock()
+
+	// Forget the endpoints the config no longer lists.
+	for addr := range c.endpoints[config.Name] {
+		if !slices.Contains(config.ServerAddrs, addr) {
+			delete(c.endpoints[config.Name], addr)
+		}
+	}
+
+	var usable []string
+	for _, addr := range config.ServerAddrs {
+		if c.endpoint(config.Name, addr).State != v1alpha1.GatewayProxyEndpointCircuitOpen {
+			usable = append(usable, addr)
+		}
+	}
+	if len(usable) == 0 {
+		return config.ServerAddrs
+	}
+	return usable
+}
+
+// recordEndpoints records how a push of config, to its ServerAddrs, went on each of them.
+// A push fewer than half of them could not be reached for is one the rest of the config
+// goes on from: nil is returned in place of err, and those endpoints are caught up in the
+// background. A push the data plane rejected objects of is not, as rolling them back is
+// what answers that.
+func (c *Client) recordEndpoints(config adctypes.Config, err error) error {
+	failed := make(map[string]string)
+	rejected := false
+	var execErr types.ADCExecutionError
+	if errors.As(err, &execErr) {
+		for _, serverErr := range execErr.FailedErrors {
+			rejected = rejected || len(serverErr.FailedStatuses) > 0
+			// A standalone push through ADC goes to every endpoint at once, and fails as one.
+			for _, addr := range strings.Split(serverErr.ServerAddr, ",") {
+				if slices.Contains(config.ServerAddrs, addr) {
+					failed[addr] = serverErr.Error()
+				}
+			}
+		}
+	}
+	if err != nil && len(failed) == 0 {
+		// Nothing says which endpoints failed the push, so it failed on all of them.
+		for _, addr := range config.ServerAddrs {
+			failed[addr] = err.Error()
+		}
+	}
+
+	c.endpointsMu.Lock()
+	defer c.endpointsMu.Unlock()
+	now := time.Now()
+	for _, addr := range config.ServerAddrs {
+		ep := c.endpoint(config.Name, addr)
+		if msg, ok := failed[addr]; ok {
+			ep.failed(msg, now)
+		} else {
+			ep.succeeded(now)
+		}
+	}
+
+	if err != nil && !rejected && 2*len(failed) < len(config.ServerAddrs) {
+		c.log.Info("push failed on a minority of endpoints, catching them up in the background",
+			"config", config.Name, "failed", len(failed), "endpoints", len(config.ServerAddrs), "error", err.Error())
+		return nil
+	}
+	return err
+}
+
+// markEndpointsSynced records that every healthy endpoint of config name holds version of
+// it.
+func (c *Client) markEndpointsSynced(name string, version int64) {
+	c.endpointsMu.Lock()
+	defer c.endpointsMu.Unlock()
+	for _, ep := range c.endpoints[name] {
+		if ep.State == v1alpha1.GatewayProxyEndpointHealthy {
+			ep.SyncedVersion = version
+		}
+	}
+}
+
+// LaggingEndpoints returns the endpoints of config name that do not hold its last push:
+// those that are not healthy, and those that have not accepted it.
+func (c *Client) LaggingEndpoints(name string) []string {
+	var addrs []string
+	for _, config := range c.ConfigManager.List() {
+		if config.Name == name {
+			addrs = config.ServerAddrs
+			break
+		}
+	}
+	push, _ := c.LastPush(name)
+
+	c.endpointsMu.Lock()
+	defer c.endpointsMu.Unlock()
+	var lagging []string
+	for _, addr := range addrs {
+		ep, ok := c.endpoints[name][addr]
+		if !ok || ep.State != v1alpha1.GatewayProxyEndpointHealthy || ep.SyncedVersion < push.Sequence {
+			lagging = append(lagging, addr)
+		}
+	}
+	return lagging
+}
+
+// EndpointStatuses reports, per GatewayProxy, how pushes to each of its endpoints have
+// gone, in the order it lists them.
+func (c *Client) EndpointStatuses() map[types.NamespacedNameKind][]EndpointStatus {
+	configs := c.ConfigManager.List()
+
+	c.endpointsMu.Lock()
+	defer c.endpointsMu.Unlock()
+	statuses := make(map[types.NamespacedNameKind][]EndpointStatus, len(configs))
+	for key, config := range configs {
+		var endpoints []EndpointStatus
+		for _, addr := range config.ServerAddrs {
+			if ep, ok := c.endpoints[config.Name][addr]; ok {
+				endpoints = append(endpoints, ep.EndpointStatus)
+			}
+		}
+		if len(endpoints) > 0 {
+			statuses[key] = endpoints
+		}
+	}
+	return statuses
+}
+
+// CatchUpEndpoints pushes every config again, whole, to each of its endpoints that did not
+// accept the last push and is due to be retried. An endpoint is pushed the last known
+// good version of the config, which is what the endpoints that did accept it hold. It
+// reports whether any endpoint changed state.
+func (c *Client) CatchUpEndpoints(ctx context.Context) bool {
+	type due struct {
+		config adctypes.Config
+		addr   string
+	}
+	var todo []due
+	configs := c.ConfigManager.List()
+	now := time.Now()
+	c.endpointsMu.Lock()
+	for _, config := range configs {
+		for _, addr := range config.ServerAddrs {
+			ep, ok := c.endpoints[config.Name][addr]
+			if ok && ep.State != v1alpha1.GatewayProxyEndpointHealthy && !now.Before(ep.retryAt) {
+				todo = append(todo, due{config: config, addr: addr})
+			}
+		}
+	}
+	c.endpointsMu.Unlock()
+
+	changed := false
+	for _, ep := range todo {
+		if c.catchUp(ctx, ep.config, ep.addr) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+func (c *Client) catchUp(ctx context.Context, config adctypes.Config, addr string) bool {
+	name := config.Name
+	st := c.lockConfig(name)
+	defer st.mu.Unlock()
+
+	last := st.lastGoodVersion()
+	resources := last.resources
+	if last.version == 0 {
+		// No endpoint has accepted the config yet.
+		var err error
+		if resources, err = c.GetResources(name); err != nil {
+			c.log.Error(err, "failed to get resources from store", "config", name)
+			return false
+		}
+	}
+
+	if config.BackendType == "" {
+		config.BackendType = c.defaultMode
+	}
+	config.ServerAddrs = []string{addr}
+	// What the endpoint holds is unknown: read it back rather than trust any baseline.
+	config.BypassCache = true
+	req := Task{Resources: resources}.request(config)
+	if _, native := c.executorFor(config).(*AdminAPIExecutor); !native && config.BackendType == backendAPISIXStandalone {
+		// ADC keeps one baseline for every endpoint of a standalone config; catching up one
+		// of them must not replace it.
+		req.CacheKey = name + "@" + addr
+	}
+	c.log.Info("catching up endpoint", "config", name, "server", addr, "version", last.version)
+	c.dumpRequest(operationSync, req)
+	err := c.executorFor(config).Execute(ctx, config, req)
+	if err != nil {
+		c.log.Error(err, "failed to catch up endpoint", "config", name, "server", addr)
+	}
+
+	c.endpointsMu.Lock()
+	defer c.endpointsMu.Unlock()
+	ep := c.endpoint(name, addr)
+	state := ep.State
+	if err != nil {
+		ep.failed(err.Error(), time.Now())
+		return false
+	}
+	ep.succeeded(time.Now())
+	ep.SyncedVersion = last.version
+	return ep.State != state
+}
@@ -0,0 +1,143 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	"github.com/apache/apisix-ingress-controller/internal/provider/common"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+// endpointExecutor fails every push on the servers in down, as an Admin API executor
+// would, and records the servers each push went to.
+type endpointExecutor struct {
+	mu     sync.Mutex
+	down   map[string]bool
+	pushes [][]string
+}
+
+func (e *endpointExecutor) Execute(_ context.Context, config adctypes.Config, _ ADCRequest) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.pushes = append(e.pushes, config.ServerAddrs)
+	var failed []types.ADCExecutionServerAddrError
+	for _, addr := range config.ServerAddrs {
+		if e.down[addr] {
+			failed = append(failed, types.ADCExecutionServerAddrError{ServerAddr: addr, Err: "connection refused"})
+		}
+	}
+	if len(failed) > 0 {
+		return types.ADCExecutionError{Name: config.Name, FailedErrors: failed}
+	}
+	return nil
+}
+
+func (e *endpointExecutor) Validate(context.Context, adctypes.Config, ADCRequest) error { return nil }
+
+var endpointAddrs = []string{"http://apisix-0:9180", "http://apisix-1:9180", "http://apisix-2:9180"}
+
+func newEndpointClient(t *testing.T, exec ADCExecutor) *Client {
+	t.Helper()
+	c := newTestClient(exec)
+	c.ConfigManager = common.NewConfigManager[types.NamespacedNameKind, adctypes.Config]()
+	c.ConfigManager.UpdateConfig(deltaGatewayProxy, adctypes.Config{
+		Name:        syncTaskCacheKey,
+		ServerAddrs: endpointAddrs,
+		BackendType: "apisix",
+	})
+	updateRoute(t, c, "a", "a.example.com")
+	return c
+}
+
+func endpointStates(c *Client) map[string]string {
+	states := make(map[string]string)
+	for _, ep := range c.EndpointStatuses()[deltaGatewayProxy] {
+		states[ep.Address] = ep.State
+	}
+	return states
+}
+
+func TestClientSyncToleratesAMinorityOfFailedEndpoints(t *testing.T) {
+	exec := &endpointExecutor{down: map[string]bool{endpointAddrs[2]: true}}
+	c := newEndpointClient(t, exec)
+
+	_, err := c.Sync(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		endpointAddrs[0]: v1alpha1.GatewayProxyEndpointHealthy,
+		endpointAddrs[1]: v1alpha1.GatewayProxyEndpointHealthy,
+		endpointAddrs[2]: v1alpha1.GatewayProxyEndpointUnhealthy,
+	}, endpointStates(c))
+
+	exec.down[endpointAddrs[1]] = true
+	_, err = c.Sync(context.Background())
+	assert.Error(t, err, "a push most endpoints failed is a failed push")
+}
+
+func TestClientSyncSkipsAnEndpointWithAnOpenCircuit(t *testing.T) {
+	exec := &endpointExecutor{down: map[string]bool{endpointAddrs[2]: true}}
+	c := newEndpointClient(t, exec)
+
+	for range endpointFailureThreshold {
+		_, err := c.Sync(context.Background())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, v1alpha1.GatewayProxyEndpointCircuitOpen, endpointStates(c)[endpointAddrs[2]])
+
+	_, err := c.Sync(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, endpointAddrs[:2], exec.pushes[len(exec.pushes)-1])
+}
+
+func TestCatchUpEndpointsRestoresAnEndpointThatCameBack(t *testing.T) {
+	exec := &endpointExecutor{down: map[string]bool{endpointAddrs[2]: true}}
+	c := newEndpointClient(t, exec)
+	_, err := c.Sync(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, endpointAddrs[2:], c.LaggingEndpoints(syncTaskCacheKey), "the push went on without it")
+
+	// Not due yet.
+	assert.False(t, c.CatchUpEndpoints(context.Background()))
+	require.Len(t, exec.pushes, 1)
+
+	c.endpointsMu.Lock()
+	c.endpoints[syncTaskCacheKey][endpointAddrs[2]].retryAt = time.Time{}
+	c.endpointsMu.Unlock()
+	delete(exec.down, endpointAddrs[2])
+
+	assert.True(t, c.CatchUpEndpoints(context.Background()))
+	require.Len(t, exec.pushes, 2)
+	assert.Equal(t, []string{endpointAddrs[2]}, exec.pushes[1])
+
+	statuses := c.EndpointStatuses()[deltaGatewayProxy]
+	require.Len(t, statuses, 3)
+	for _, ep := range statuses {
+		assert.Equal(t, v1alpha1.GatewayProxyEndpointHealthy, ep.State, ep.Address)
+		assert.Equal(t, int64(1), ep.SyncedVersion, ep.Address)
+	}
+	assert.Empty(t, c.LaggingEndpoints(syncTaskCacheKey))
+}
@@ -26,7 +26,6 @@ import (
 	"io"
 	"net"
 	"net/http"
-	"os"
 	"strings"
 	"time"
 
@@ -46,23 +45,40 @@ const (
 	backendAPISIXStandalone = "apisix-standalone"
 )
 
-type ADCExecutor interface {
-	Execute(ctx context.Context, config adctypes.Config, args []string) error
-	Validate(ctx context.Context, config adctypes.Config, args []string) error
+// The executors a GatewayProxy or the controller selects to sync its configs with: the
+// ADC server, or the Admin API of APISIX directly.
+const (
+	ExecutorADC    = "adc"
+	ExecutorNative = "native"
+)
+
+// ADCRequest is what one sync or validation of a config hands to an executor, built
+// straight from the client's memory.
+type ADCRequest struct {
+	// Resources is the desired state. It is nil for a request that only removes.
+	Resources *adctypes.Resources
+	// Labels scopes the request to the objects carrying all of them.
+	Labels map[string]string
+	// ResourceTypes limits the request to these resource types, or to none when empty.
+	ResourceTypes []string
+	// CacheKey names the baseline the request is diffed against.
+	CacheKey string
 }
 
-func BuildADCExecuteArgs(filePath string, labels map[string]string, types []string) []string {
-	args := []string{
-		"sync",
-		"-f", filePath,
-	}
-	for k, v := range labels {
-		args = append(args, "--label-selector", k+"="+v)
-	}
-	for _, t := range types {
-		args = append(args, "--include-resource-type", t)
+// MarshalLog implements logr.Marshaler so logging a request never dumps the
+// secret-bearing Resources bodies.
+func (r ADCRequest) MarshalLog() any {
+	return map[string]any{
+		"labels":        r.Labels,
+		"resourceTypes": r.ResourceTypes,
+		"cacheKey":      r.CacheKey,
+		"resources":     r.Resources.MarshalLog(),
 	}
-	return args
+}
+
+type ADCExecutor interface {
+	Execute(ctx context.Context, config adctypes.Config, req ADCRequest) error
+	Validate(ctx context.Context, config adctypes.Config, req ADCRequest) error
 }
 
 // ADCServerRequest represents the request body for ADC Server /sync endpoint
@@ -152,16 +168,16 @@ func NewHTTPADCExecutor(log logr.Logger, serverURL string, timeout time.Duration
 }
 
 // Execute implements the ADCExecutor interface using HTTP calls
-func (e *HTTPADCExecutor) Execute(ctx context.Context, config adctypes.Config, args []string) error {
-	return e.runHTTPSync(ctx, config, args)
+func (e *HTTPADCExecutor) Execute(ctx context.Context, config adctypes.Config, req ADCRequest) error {
+	return e.runHTTPSync(ctx, config, req)
 }
 
-func (e *HTTPADCExecutor) Validate(ctx context.Context, config adctypes.Config, args []string) error {
-	return e.runHTTPValidate(ctx, config, args)
+func (e *HTTPADCExecutor) Validate(ctx context.Context, config adctypes.Config, req ADCRequest) error {
+	return e.runHTTPValidate(ctx, config, req)
 }
 
 // runHTTPSync performs HTTP sync to ADC Server for each server address
-func (e *HTTPADCExecutor) runHTTPSync(ctx context.Context, config adctypes.Config, args []string) error {
+func (e *HTTPADCExecutor) runHTTPSync(ctx context.Context, config adctypes.Config, req ADCRequest) error {
 	var execErrs = types.ADCExecutionError{
 		Name: config.Name,
 	}
@@ -175,7 +191,7 @@ func (e *HTTPADCExecutor) runHTTPSync(ctx context.Context, config adctypes.Confi
 	e.log.V(1).Info("running http sync", "serverAddrs", serverAddrs)
 
 	for _, addr := range serverAddrs {
-		if err := e.runHTTPSyncForSingleServer(ctx, addr, config, args); err != nil {
+		if err := e.runHTTPSyncForSingleServer(ctx, addr, config, req); err != nil {
 			e.log.Error(err, "failed to run http sync for server", "server", addr)
 			var execErr types.ADCExecutionServerAddrError
 			if errors.As(err, &execErr) {
@@ -194,7 +210,7 @@ func (e *HTTPADCExecutor) runHTTPSync(ctx context.Context, config adctypes.Confi
 	return nil
 }
 
-func (e *HTTPADCExecutor) runHTTPValidate(ctx context.Context, config adctypes.Config, args []string) error {
+func (e *HTTPADCExecutor) runHTTPValidate(ctx context.Context, config adctypes.Config, req ADCRequest) error {
 	var validationErr = types.ADCValidationError{
 		Name: config.Name,
 	}
@@ -206,7 +222,7 @@ func (e *HTTPADCExecutor) runHTTPValidate(ctx context.Context, config adctypes.C
 	e.log.V(1).Info("running http validate", "serverAddrs", serverAddrs)
 
 	for _, addr := range serverAddrs {
-		if err := e.runHTTPValidateForSingleServer(ctx, addr, config, args); err != nil {
+		if err := e.runHTTPValidateForSingleServer(ctx, addr, config, req); err != nil {
 			e.log.Error(err, "failed to run http validate for server", "server", addr)
 			var validationServerErr types.ADCValidationServerAddrError
 			if errors.As(err, &validationServerErr) {
@@ -227,30 +243,18 @@ func (e *HTTPADCExecutor) runHTTPValidate(ctx context.Context, config adctypes.C
 }
 
 // runHTTPSyncForSingleServer performs HTTP sync to a single ADC Server
-func (e *HTTPADCExecutor) runHTTPSyncForSingleServer(ctx context.Context, serverAddr string, config adctypes.Config, args []string) error {
+func (e *HTTPADCExecutor) runHTTPSyncForSingleServer(ctx context.Context, serverAddr string, config adctypes.Config, req ADCRequest) error {
 	ctx, cancel := context.WithTimeout(ctx, e.httpClient.Timeout)
 	defer cancel()
 
-	// Parse args to extract labels, types, and file path
-	labels, types, filePath, err := e.parseArgs(args)
-	if err != nil {
-		return fmt.Errorf("failed to parse args: %w", err)
-	}
-
-	// Load resources from file
-	resources, err := e.loadResourcesFromFile(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to load resources from file %s: %w", filePath, err)
-	}
-
 	// Build HTTP request
-	req, err := e.buildHTTPRequest(ctx, serverAddr, config, labels, types, resources, http.MethodPut, pathSync)
+	httpReq, err := e.buildHTTPRequest(ctx, serverAddr, config, req, http.MethodPut, pathSync)
 	if err != nil {
 		return fmt.Errorf("failed to build HTTP request: %w", err)
 	}
 
 	// Send HTTP request
-	resp, err := e.httpClient.Do(req)
+	resp, err := e.httpClient.Do(httpReq)
 	if err != nil {
 		return fmt.Errorf("failed to send HTTP request: %w", err)
 	}
@@ -264,26 +268,16 @@ func (e *HTTPADCExecutor) runHTTPSyncForSingleServer(ctx context.Context, server
 	return e.handleHTTPResponse(resp, serverAddr)
 }
 
-func (e *HTTPADCExecutor) runHTTPValidateForSingleServer(ctx context.Context, serverAddr string, config adctypes.Config, args []string) error {
+func (e *HTTPADCExecutor) runHTTPValidateForSingleServer(ctx context.Context, serverAddr string, config adctypes.Config, req ADCRequest) error {
 	ctx, cancel := context.WithTimeout(ctx, e.httpClient.Timeout)
 	defer cancel()
 
-	labels, types, filePath, err := e.parseArgs(args)
-	if err != nil {
-		return fmt.Errorf("failed to parse args: %w", err)
-	}
-
-	resources, err := e.loadResourcesFromFile(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to load resources from file %s: %w", filePath, err)
-	}
-
-	req, err := e.buildHTTPRequest(ctx, serverAddr, config, labels, types, resources, http.MethodPut, pathValidate)
+	httpReq, err := e.buildHTTPRequest(ctx, serverAddr, config, req, http.MethodPut, pathValidate)
 	if err != nil {
 		return fmt.Errorf("failed to build validate request: %w", err)
 	}
 
-	resp, err := e.httpClient.Do(req)
+	resp, err := e.httpClient.Do(httpReq)
 	if err != nil {
 		return fmt.Errorf("failed to send HTTP request: %w", err)
 	}
@@ -296,61 +290,17 @@ func (e *HTTPADCExecutor) runHTTPValidateForSingleServer(ctx context.Context, se
 	return e.handleHTTPValidateResponse(resp, serverAddr)
 }
 
-// parseArgs parses the command line arguments to extract labels, types, and file path
-func (e *HTTPADCExecutor) parseArgs(args []string) (map[string]string, []string, string, error) {
-	labels := make(map[string]string)
-	var types []string
-	var filePath string
-
-	for i := 0; i < len(args); i++ {
-		switch args[i] {
-		case "-f":
-			if i+1 < len(args) {
-				filePath = args[i+1]
-				i++
-			}
-		case "--label-selector":
-			if i+1 < len(args) {
-				labelPair := args[i+1]
-				parts := strings.SplitN(labelPair, "=", 2)
-				if len(parts) == 2 {
-					labels[parts[0]] = parts[1]
-				}
-				i++
-			}
-		case "--include-resource-type":
-			if i+1 < len(args) {
-				types = append(types, args[i+1])
-				i++
-			}
-		}
-	}
-
-	if filePath == "" {
-		return nil, nil, "", errors.New("file path not found in args")
-	}
-
-	return labels, types, filePath, nil
-}
-
-// loadResourcesFromFile loads ADC resources from the specified file
-func (e *HTTPADCExecutor) loadResourcesFromFile(filePath string) (*adctypes.Resources, error) {
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %w", err)
-	}
-
-	var resources adctypes.Resources
-	if err := json.Unmarshal(data, &resources); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal resources: %w", err)
-	}
-
-	return &resources, nil
-}
-
 // buildHTTPRequest builds the HTTP request for ADC Server
-func (e *HTTPADCExecutor) buildHTTPRequest(ctx context.Context, serverAddr string, config adctypes.Config, labels map[string]string, types []string, resources *adctypes.Resources, method string, path string) (*http.Request, error) {
+func (e *HTTPADCExecutor) buildHTTPRequest(ctx context.Context, serverAddr string, config adctypes.Config, req ADCRequest, method string, path string) (*http.Request, error) {
 	// Prepare request body
+	resources := req.Resources
+	if resources == nil {
+		resources = &adctypes.Resources{}
+	}
+	cacheKey := req.CacheKey
+	if cacheKey == "" {
+		cacheKey = config.Name
+	}
 	tlsVerify := config.TlsVerify
 	bypassCache := path == pathSync && config.BypassCache
 	reqBody := ADCServerRequest{
@@ -359,10 +309,10 @@ func (e *HTTPADCExecutor) buildHTTPRequest(ctx context.Context, serverAddr strin
 				Backend:             config.BackendType,
 				Server:              strings.Split(serverAddr, ","),
 				Token:               config.Token,
-				LabelSelector:       labels,
-				IncludeResourceType: types,
+				LabelSelector:       req.Labels,
+				IncludeResourceType: req.ResourceTypes,
 				TlsSkipVerify:       ptr.To(!tlsVerify),
-				CacheKey:            config.Name,
+				CacheKey:            cacheKey,
 				BypassCache:         bypassCache,
 			},
 			Config: *resources,
@@ -380,21 +330,21 @@ func (e *HTTPADCExecutor) buildHTTPRequest(ctx context.Context, serverAddr strin
 		"url", e.serverURL+path,
 		"server", serverAddr,
 		"mode", config.BackendType,
-		"cacheKey", config.Name,
+		"cacheKey", cacheKey,
 		"bypassCache", bypassCache,
-		"labelSelector", labels,
-		"includeResourceType", types,
+		"labelSelector", req.Labels,
+		"includeResourceType", req.ResourceTypes,
 		"tlsSkipVerify", !tlsVerify,
 	)
 
 	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, method, e.serverURL+path, bytes.NewBuffer(jsonData))
+	httpReq, err := http.NewRequestWithContext(ctx, method, e.serverURL+path, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	return req, nil
+	httpReq.Header.Set("Content-Type", "application/json")
+	return httpReq, nil
 }
 
 // handleHTTPResponse handles the HTTP response from ADC Server
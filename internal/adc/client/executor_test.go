@@ -23,13 +23,17 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
 	"github.com/apache/apisix-ingress-controller/internal/types"
 )
 
@@ -40,8 +44,8 @@ func TestHTTPADCExecutorBuildHTTPRequestBypassCache(t *testing.T) {
 	}
 
 	build := func(config adctypes.Config, path string) (ADCServerOpts, string) {
-		req, err := e.buildHTTPRequest(context.Background(), "http://apisix:9180", config, nil, nil,
-			&adctypes.Resources{}, http.MethodPut, path)
+		req, err := e.buildHTTPRequest(context.Background(), "http://apisix:9180", config,
+			ADCRequest{Resources: &adctypes.Resources{}, CacheKey: config.Name}, http.MethodPut, path)
 		require.NoError(t, err)
 		body, err := io.ReadAll(req.Body)
 		require.NoError(t, err)
@@ -87,14 +91,16 @@ func rejection(reason string) error {
 }
 
 // fakeExecutor answers each Execute call with the next error in errs, and records the
-// BypassCache flag it was called with.
+// BypassCache flag and the request it was called with.
 type fakeExecutor struct {
 	errs      []error
 	bypassSeq []bool
+	reqs      []ADCRequest
 }
 
-func (f *fakeExecutor) Execute(_ context.Context, config adctypes.Config, _ []string) error {
+func (f *fakeExecutor) Execute(_ context.Context, config adctypes.Config, req ADCRequest) error {
 	f.bypassSeq = append(f.bypassSeq, config.BypassCache)
+	f.reqs = append(f.reqs, req)
 	if len(f.errs) == 0 {
 		return nil
 	}
@@ -103,14 +109,20 @@ func (f *fakeExecutor) Execute(_ context.Context, config adctypes.Config, _ []st
 	return err
 }
 
-func (f *fakeExecutor) Validate(context.Context, adctypes.Config, []string) error { return nil }
+func (f *fakeExecutor) Validate(context.Context, adctypes.Config, ADCRequest) error { return nil }
 
 // newTestClient starts out as a controller that has just been elected: no ADC baseline is
 // known to be current, so the first sync of a cacheKey rebuilds it.
 func newTestClient(exec ADCExecutor) *Client {
 	return &Client{
+		Store:            cache.NewStore(logr.Discard()),
 		executor:         exec,
 		rebuiltBaselines: make(map[string]struct{}),
+		states:           make(map[string]*configState),
+		quarantine:       make(map[quarantineKey]*QuarantineEntry),
+		contents:         make(map[types.NamespacedNameKind]string),
+		endpoints:        make(map[string]map[string]*endpointState),
+		pushes:           make(map[string]Push),
 		log:              logr.Discard(),
 	}
 }
@@ -265,3 +277,84 @@ func TestIsConfVersionRejection(t *testing.T) {
 	assert.True(t, isConfVersionRejection(rejection("routes_conf_version has moved backwards")),
 		"the field is what names the rejection, not the sentence")
 }
+
+func TestClientSyncHandsTheTaskToTheExecutorFromMemory(t *testing.T) {
+	exec := &fakeExecutor{}
+	c := afterFirstSync(exec)
+
+	task := newSyncTask()
+	task.Labels = map[string]string{"k8s/kind": "HTTPRoute"}
+	task.ResourceTypes = []string{adctypes.TypeService}
+	require.NoError(t, c.sync(context.Background(), task))
+
+	require.Len(t, exec.reqs, 1)
+	req := exec.reqs[0]
+	assert.Same(t, task.Resources, req.Resources, "resources are handed over, not serialized and re-read")
+	assert.Equal(t, task.Labels, req.Labels)
+	assert.Equal(t, task.ResourceTypes, req.ResourceTypes)
+	assert.Equal(t, syncTaskCacheKey, req.CacheKey)
+}
+
+func TestClientDumpsRequestsOnlyWhenAskedTo(t *testing.T) {
+	exec := &fakeExecutor{}
+	c := afterFirstSync(exec)
+
+	require.NoError(t, c.sync(context.Background(), newSyncTask()))
+
+	c.dumpDir = t.TempDir()
+	task := newSyncTask()
+	task.Labels = map[string]string{"k8s/kind": "HTTPRoute"}
+	require.NoError(t, c.sync(context.Background(), task))
+
+	entries, err := os.ReadDir(c.dumpDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	data, err := os.ReadFile(filepath.Join(c.dumpDir, entries[0].Name()))
+	require.NoError(t, err)
+	var dump requestDump
+	require.NoError(t, json.Unmarshal(data, &dump))
+	assert.Equal(t, syncTaskCacheKey, dump.CacheKey)
+	assert.Equal(t, task.Labels, dump.Labels)
+}
+
+func TestRequestDumpsAreRedacted(t *testing.T) {
+	dir := t.TempDir()
+	resources := adminAPIResources()
+	resources.Services[0].Upstream.TLS = &adctypes.ClientTLS{Cert: "cert", Key: "UPSTREAM KEY"}
+
+	path, err := writeRequestDump(dir, operationSync, ADCRequest{CacheKey: "config", Resources: resources})
+	require.NoError(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "httpbin.org")
+	for _, secret := range []string{`"key2"`, "jack-key", "UPSTREAM KEY"} {
+		assert.NotContains(t, string(data), secret)
+	}
+	assert.Equal(t, "key2", resources.SSLs[0].Certificates[1].Key, "the request sent is left as it is")
+}
+
+func TestPruneRequestDumpsKeepsTheNewest(t *testing.T) {
+	dir := t.TempDir()
+	other := filepath.Join(dir, "notes.txt")
+	require.NoError(t, os.WriteFile(other, nil, 0o600))
+	var paths []string
+	for i := range 5 {
+		path, err := writeRequestDump(dir, operationSync, ADCRequest{CacheKey: "config"})
+		require.NoError(t, err)
+		at := time.Now().Add(time.Duration(i) * time.Minute)
+		require.NoError(t, os.Chtimes(path, at, at))
+		paths = append(paths, path)
+	}
+
+	require.NoError(t, pruneRequestDumps(dir, 2))
+
+	for i, path := range paths {
+		_, err := os.Stat(path)
+		if i < 3 {
+			assert.ErrorIs(t, err, os.ErrNotExist, "dump %d", i)
+		} else {
+			assert.NoError(t, err, "dump %d", i)
+		}
+	}
+	assert.FileExists(t, other, "only dumps are removed")
+}
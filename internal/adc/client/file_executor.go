@@ -0,0 +1,230 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/yaml"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+	pkgmetrics "github.com/apache/apisix-ingress-controller/pkg/metrics"
+)
+
+const (
+	// FileFormatAPISIX renders a config as the apisix.yaml an APISIX data plane in
+	// standalone file mode loads.
+	FileFormatAPISIX = "apisix"
+	// FileFormatADC renders a config as an ADC declarative configuration.
+	FileFormatADC = "adc"
+)
+
+// standaloneFileEnd is how APISIX tells an apisix.yaml that was written whole from one
+// still being written: it does not load a file without it.
+const standaloneFileEnd = "#END\n"
+
+// FileSink stores the files a FileExecutor renders.
+type FileSink interface {
+	// Write replaces the file name with data. It reports where the file is, and whether
+	// it held anything else before.
+	Write(ctx context.Context, name string, data []byte) (location string, changed bool, err error)
+}
+
+// FileWrite is how the last write of the file of one config went.
+type FileWrite struct {
+	Config       string                   `json:"config"`
+	GatewayProxy types.NamespacedNameKind `json:"gatewayProxy"`
+	Location     string                   `json:"location,omitempty"`
+	// Hash is the SHA-256 of the file content.
+	Hash      string    `json:"hash,omitempty"`
+	WrittenAt time.Time `json:"writtenAt,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// FileExecutor implements ADCExecutor by rendering each config whole as a file, for a
+// GitOps pipeline or an air-gapped data plane to pick up, instead of syncing it to a
+// data plane. A file is only written when its content changed.
+type FileExecutor struct {
+	format string
+	sink   FileSink
+	log    logr.Logger
+
+	mu     sync.Mutex
+	writes map[string]FileWrite
+}
+
+// NewFileExecutor creates a FileExecutor rendering configs in format to sink.
+func NewFileExecutor(log logr.Logger, format string, sink FileSink) *FileExecutor {
+	return &FileExecutor{
+		format: format,
+		sink:   sink,
+		log:    log.WithName("file"),
+		writes: make(map[string]FileWrite),
+	}
+}
+
+// Execute implements the ADCExecutor interface. A file can only be written whole, so req
+// must hold the whole config: one scoped by labels or resource types is refused.
+func (e *FileExecutor) Execute(ctx context.Context, config adctypes.Config, req ADCRequest) error {
+	if len(req.Labels) > 0 || len(req.ResourceTypes) > 0 {
+		return fmt.Errorf("config %s: a file is only written whole", config.Name)
+	}
+
+	start := time.Now()
+	write, err := e.write(ctx, config, req.Resources)
+	status := adctypes.StatusSuccess
+	if err != nil {
+		status = "failure"
+		write.Error = err.Error()
+	}
+	pkgmetrics.RecordFileIODuration("write_config_file", status, time.Since(start).Seconds())
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.writes[config.Name] = write
+	return err
+}
+
+func (e *FileExecutor) write(ctx context.Context, config adctypes.Config, resources *adctypes.Resources) (FileWrite, error) {
+	write := FileWrite{Config: config.Name}
+	if err := write.GatewayProxy.FromString(config.Name); err != nil {
+		return write, err
+	}
+	data, err := RenderFile(e.format, resources)
+	if err != nil {
+		return write, err
+	}
+	sum := sha256.Sum256(data)
+	write.Hash = hex.EncodeToString(sum[:])
+
+	e.mu.Lock()
+	last, ok := e.writes[config.Name]
+	e.mu.Unlock()
+	if ok && last.Error == "" && last.Hash == write.Hash {
+		return last, nil
+	}
+
+	location, changed, err := e.sink.Write(ctx, FileName(write.GatewayProxy), data)
+	if err != nil {
+		return write, err
+	}
+	write.Location = location
+	write.WrittenAt = time.Now()
+	if changed {
+		e.log.Info("wrote config file", "config", config.Name, "location", location, "hash", write.Hash)
+	}
+	return write, nil
+}
+
+// Validate implements the ADCExecutor interface. No data plane is there to ask, so every
+// configuration is accepted.
+func (e *FileExecutor) Validate(context.Context, adctypes.Config, ADCRequest) error {
+	return nil
+}
+
+// Writes reports how the last write of the file of each config went, by config name.
+func (e *FileExecutor) Writes() map[string]FileWrite {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return maps.Clone(e.writes)
+}
+
+// Forget drops what is kept about the file of every config not named. The file itself is
+// left in place.
+func (e *FileExecutor) Forget(keep ...string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for name := range e.writes {
+		if !slices.Contains(keep, name) {
+			delete(e.writes, name)
+		}
+	}
+}
+
+// FileName is the base name of the file of the config of a GatewayProxy. A namespace
+// cannot hold a dot, so no two GatewayProxies share one.
+func FileName(gatewayProxy types.NamespacedNameKind) string {
+	return gatewayProxy.Namespace + "." + gatewayProxy.Name
+}
+
+// RenderFile renders resources as a file in format.
+func RenderFile(format string, resources *adctypes.Resources) ([]byte, error) {
+	if resources == nil {
+		resources = &adctypes.Resources{}
+	}
+	switch format {
+	case FileFormatADC:
+		return yaml.Marshal(resources)
+	case FileFormatAPISIX, "":
+		objects, err := buildAdminAPIObjects(resources, nil)
+		if err != nil {
+			return nil, err
+		}
+		byCollection := standaloneItems(objects)
+		document := make(map[string]any, len(adminAPICreateOrder))
+		for _, collection := range adminAPICreateOrder {
+			if items, ok := byCollection[collection]; ok {
+				document[collection] = items
+			}
+		}
+		data, err := yaml.Marshal(document)
+		if err != nil {
+			return nil, err
+		}
+		return append(data, standaloneFileEnd...), nil
+	default:
+		return nil, fmt.Errorf("unknown file format %q", format)
+	}
+}
+
+// DirectorySink writes files to a directory, each replaced atomically.
+type DirectorySink struct {
+	Dir string
+}
+
+func (s *DirectorySink) Write(_ context.Context, name string, data []byte) (string, bool, error) {
+	path := filepath.Join(s.Dir, name+".yaml")
+	current, err := os.ReadFile(path)
+	if err == nil && bytes.Equal(current, data) {
+		return path, false, nil
+	}
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return path, false, err
+	}
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return path, false, err
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		return path, false, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return path, true, nil
+}
@@ -0,0 +1,130 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+)
+
+// countingSink counts the writes that reach a DirectorySink.
+type countingSink struct {
+	DirectorySink
+	writes int
+}
+
+func (s *countingSink) Write(ctx context.Context, name string, data []byte) (string, bool, error) {
+	s.writes++
+	return s.DirectorySink.Write(ctx, name, data)
+}
+
+func TestRenderFileAPISIX(t *testing.T) {
+	data, err := RenderFile(FileFormatAPISIX, &adctypes.Resources{Services: []*adctypes.Service{{
+		Metadata: adctypes.Metadata{ID: "svc", Name: "svc"},
+		Hosts:    []string{"a.example.com"},
+		Routes: []*adctypes.Route{{
+			Metadata: adctypes.Metadata{ID: "route", Name: "route"},
+			Uris:     []string{"/a"},
+		}},
+	}}})
+	require.NoError(t, err)
+	require.True(t, strings.HasSuffix(string(data), "\n#END\n"), "APISIX only loads a file that ends with #END")
+
+	var document map[string][]map[string]any
+	require.NoError(t, yaml.Unmarshal(data, &document))
+	require.Len(t, document["services"], 1)
+	require.Len(t, document["routes"], 1)
+	assert.Equal(t, "route", document["routes"][0]["id"])
+	assert.Equal(t, "svc", document["routes"][0]["service_id"])
+}
+
+func TestRenderFileADC(t *testing.T) {
+	resources := &adctypes.Resources{Services: []*adctypes.Service{{
+		Metadata: adctypes.Metadata{ID: "svc", Name: "svc"},
+		Hosts:    []string{"a.example.com"},
+	}}}
+	data, err := RenderFile(FileFormatADC, resources)
+	require.NoError(t, err)
+
+	var rendered adctypes.Resources
+	require.NoError(t, yaml.Unmarshal(data, &rendered))
+	assert.Equal(t, resources, &rendered)
+}
+
+func TestFileExecutorWritesOnlyWhatChanged(t *testing.T) {
+	sink := &countingSink{DirectorySink: DirectorySink{Dir: filepath.Join(t.TempDir(), "out")}}
+	exec := NewFileExecutor(logr.Discard(), FileFormatAPISIX, sink)
+	config := adctypes.Config{Name: syncTaskCacheKey}
+	resources := &adctypes.Resources{Services: []*adctypes.Service{{
+		Metadata: adctypes.Metadata{ID: "svc", Name: "svc"},
+	}}}
+
+	require.NoError(t, exec.Execute(context.Background(), config, ADCRequest{Resources: resources}))
+	write := exec.Writes()[syncTaskCacheKey]
+	assert.Equal(t, filepath.Join(sink.Dir, "ns.name.yaml"), write.Location)
+	data, err := os.ReadFile(write.Location)
+	require.NoError(t, err)
+	sum := sha256.Sum256(data)
+	assert.Equal(t, hex.EncodeToString(sum[:]), write.Hash)
+
+	require.NoError(t, exec.Execute(context.Background(), config, ADCRequest{Resources: resources}))
+	assert.Equal(t, 1, sink.writes, "a file that would not change is not written again")
+
+	resources.Services[0].Hosts = []string{"a.example.com"}
+	require.NoError(t, exec.Execute(context.Background(), config, ADCRequest{Resources: resources}))
+	assert.Equal(t, 2, sink.writes)
+	assert.NotEqual(t, write.Hash, exec.Writes()[syncTaskCacheKey].Hash)
+
+	err = exec.Execute(context.Background(), config, ADCRequest{
+		Resources:     resources,
+		ResourceTypes: []string{adctypes.TypeService},
+	})
+	require.Error(t, err, "a file cannot hold only part of a config")
+	assert.Empty(t, exec.Writes()[syncTaskCacheKey].Error, "the file still holds the last config written")
+}
+
+func TestClientWithFileExecutorWritesConfigsWhole(t *testing.T) {
+	dir := t.TempDir()
+	c := newDeltaClient(t, &fakeExecutor{}, "a", "b")
+	c.UseFileExecutor(NewFileExecutor(logr.Discard(), FileFormatADC, &DirectorySink{Dir: dir}))
+	_, err := c.SyncChanges(context.Background())
+	require.NoError(t, err)
+
+	updateRoute(t, c, "b", "b2.example.com")
+	_, err = c.SyncChanges(context.Background())
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, "ns.name.yaml"))
+	require.NoError(t, err)
+	var written adctypes.Resources
+	require.NoError(t, yaml.Unmarshal(data, &written))
+	require.Len(t, written.Services, 2, "a change to one owner rewrites the whole file")
+	assert.Contains(t, string(data), "b2.example.com")
+}
@@ -0,0 +1,218 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
+	"github.com/apache/apisix-ingress-controller/internal/controller/label"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+// snapshot is the last version of a config the data plane accepted. Its version counts the
+// pushes it was recorded from, so a log line can tell which one a rollback went back to.
+type snapshot struct {
+	version   int64
+	resources *adctypes.Resources
+}
+
+// labeled is what every top-level object of a config is.
+type labeled interface {
+	GetLabels() map[string]string
+}
+
+// recordLastGood keeps resources as the last known good version of the config.
+func (st *configState) recordLastGood(resources *adctypes.Resources) {
+	var version int64
+	if st.lastGood != nil {
+		version = st.lastGood.version
+	}
+	st.lastGood = &snapshot{
+		version: version + 1,
+		resources: &adctypes.Resources{
+			ConsumerGroups: slices.Clone(resources.ConsumerGroups),
+			Consumers:      slices.Clone(resources.Consumers),
+			GlobalRules:    resources.GlobalRules,
+			PluginMetadata: resources.PluginMetadata,
+			Services:       slices.Clone(resources.Services),
+			SSLs:           slices.Clone(resources.SSLs),
+		},
+	}
+}
+
+// recordLastGoodChange folds one owner change the data plane accepted into the last
+// known good version of the config.
+func (st *configState) recordLastGoodChange(change cache.OwnerChange) {
+	prev := st.lastGood
+	if prev == nil {
+		return
+	}
+	resources := *prev.resources
+	for _, resourceType := range change.ResourceTypes {
+		switch resourceType {
+		case adctypes.TypeService:
+			resources.Services = replaceOwned(resources.Services, change.Owner, change.Resources.Services)
+		case adctypes.TypeSSL:
+			resources.SSLs = replaceOwned(resources.SSLs, change.Owner, change.Resources.SSLs)
+		case adctypes.TypeConsumer:
+			resources.Consumers = replaceOwned(resources.Consumers, change.Owner, change.Resources.Consumers)
+		}
+	}
+	st.lastGood = &snapshot{version: prev.version + 1, resources: &resources}
+}
+
+func replaceOwned[T labeled](objects []T, owner cache.Owner, with []T) []T {
+	kept := slices.DeleteFunc(slices.Clone(objects), func(obj T) bool {
+		labels := obj.GetLabels()
+		return labels[label.LabelKind] == owner.Kind &&
+			labels[label.LabelNamespace] == owner.Namespace &&
+			labels[label.LabelName] == owner.Name
+	})
+	return append(kept, with...)
+}
+
+// rollBackRejected answers a whole push of resources for config the data plane rejected
+// with err. It pushes the config again with the objects the rejection names put back to
+// their last known good version, or left out if they never had one, so that one bad object
+// does not hold back every other change in the config. It returns the objects it put back,
+// and reports whether that push was accepted. It must be called with st, the state of
+// config, locked.
+func (c *Client) rollBackRejected(
+	ctx context.Context,
+	st *configState,
+	config adctypes.Config,
+	resources *adctypes.Resources,
+	err error,
+) ([]cache.ObjectKey, bool) {
+	name := config.Name
+	rejected, ok := rejectedObjects(resources, err)
+	if !ok {
+		return nil, false
+	}
+	last := st.lastGoodVersion()
+	c.log.Info("data plane rejected a sync, rolling the rejected objects back to their last known good version",
+		"config", name, "rejected", rejected, "version", last.version)
+
+	resources = withLastGood(resources, last.resources, rejected)
+	if err := c.sync(ctx, Task{
+		Name:      name + "-rollback",
+		Configs:   map[types.NamespacedNameKind]adctypes.Config{{}: config},
+		Resources: resources,
+	}); err != nil {
+		c.log.Error(err, "failed to sync the last known good version of rejected objects", "config", name)
+		return nil, false
+	}
+	st.recordLastGood(resources)
+	return rejected, true
+}
+
+// lastGoodVersion is the last known good version of the config, which is empty when the
+// data plane never accepted one.
+func (st *configState) lastGoodVersion() *snapshot {
+	if st.lastGood != nil {
+		return st.lastGood
+	}
+	return &snapshot{resources: &adctypes.Resources{}}
+}
+
+// rejectedObjects names the services, SSLs and consumers of resources that the sync
+// failures in err point at; a rejected route stands for the service it belongs to. It
+// reports false unless every failure points at one of them, as a failure with no details
+// -- an unreachable server, or APISIX standalone, which reports none -- does not.
+func rejectedObjects(resources *adctypes.Resources, err error) ([]cache.ObjectKey, bool) {
+	var execErrs types.ADCExecutionErrors
+	if !errors.As(err, &execErrs) || len(execErrs.Errors) == 0 {
+		return nil, false
+	}
+	var keys []cache.ObjectKey
+	for _, execErr := range execErrs.Errors {
+		if len(execErr.FailedErrors) == 0 {
+			return nil, false
+		}
+		for _, failed := range execErr.FailedErrors {
+			if len(failed.FailedStatuses) == 0 {
+				return nil, false
+			}
+			for _, status := range failed.FailedStatuses {
+				key, ok := rejectedObject(resources, status.Event)
+				if !ok {
+					return nil, false
+				}
+				if !slices.Contains(keys, key) {
+					keys = append(keys, key)
+				}
+			}
+		}
+	}
+	return keys, true
+}
+
+func rejectedObject(resources *adctypes.Resources, event adctypes.StatusEvent) (cache.ObjectKey, bool) {
+	id := event.ResourceID
+	switch event.ResourceType {
+	case adctypes.TypeService:
+		return cache.ObjectKey{Type: adctypes.TypeService, ID: id},
+			slices.ContainsFunc(resources.Services, func(s *adctypes.Service) bool { return s.ID == id })
+	case adctypes.TypeSSL:
+		return cache.ObjectKey{Type: adctypes.TypeSSL, ID: id},
+			slices.ContainsFunc(resources.SSLs, func(s *adctypes.SSL) bool { return s.ID == id })
+	case adctypes.TypeConsumer:
+		return cache.ObjectKey{Type: adctypes.TypeConsumer, ID: id},
+			slices.ContainsFunc(resources.Consumers, func(c *adctypes.Consumer) bool { return c.Username == id })
+	case adctypes.TypeRoute, "stream_route":
+		for _, service := range resources.Services {
+			if slices.ContainsFunc(service.Routes, func(r *adctypes.Route) bool { return r.ID == id }) ||
+				slices.ContainsFunc(service.StreamRoutes, func(r *adctypes.StreamRoute) bool { return r.ID == id }) {
+				return cache.ObjectKey{Type: adctypes.TypeService, ID: service.ID}, true
+			}
+		}
+	}
+	return cache.ObjectKey{}, false
+}
+
+// withLastGood is resources with each object keys names swapped for its version in
+// lastGood, or dropped when lastGood has none.
+func withLastGood(resources, lastGood *adctypes.Resources, keys []cache.ObjectKey) *adctypes.Resources {
+	out := *resources
+	out.Services = swapRejected(resources.Services, lastGood.Services, adctypes.TypeService, keys,
+		func(s *adctypes.Service) string { return s.ID })
+	out.SSLs = swapRejected(resources.SSLs, lastGood.SSLs, adctypes.TypeSSL, keys,
+		func(s *adctypes.SSL) string { return s.ID })
+	out.Consumers = swapRejected(resources.Consumers, lastGood.Consumers, adctypes.TypeConsumer, keys,
+		func(c *adctypes.Consumer) string { return c.Username })
+	return &out
+}
+
+func swapRejected[T any](objects, lastGood []T, resourceType string, keys []cache.ObjectKey, id func(T) string) []T {
+	out := make([]T, 0, len(objects))
+	for _, obj := range objects {
+		if !slices.Contains(keys, cache.ObjectKey{Type: resourceType, ID: id(obj)}) {
+			out = append(out, obj)
+			continue
+		}
+		if i := slices.IndexFunc(lastGood, func(good T) bool { return id(good) == id(obj) }); i >= 0 {
+			out = append(out, lastGood[i])
+		}
+	}
+	return out
+}
@@ -0,0 +1,144 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+// objectRejection is the error ADC answers a sync with when the data plane refused the object
+// of resourceType named id.
+func objectRejection(resourceType, id string) error {
+	return types.ADCExecutionError{
+		Name: syncTaskCacheKey,
+		FailedErrors: []types.ADCExecutionServerAddrError{{
+			Err:        "invalid configuration",
+			ServerAddr: "http://apisix:9180",
+			FailedStatuses: []adctypes.SyncStatus{{
+				Event:  adctypes.StatusEvent{ResourceType: resourceType, ResourceID: id},
+				Reason: "invalid configuration",
+			}},
+		}},
+	}
+}
+
+func hostsOf(resources *adctypes.Resources) map[string][]string {
+	hosts := make(map[string][]string)
+	for _, service := range resources.Services {
+		hosts[service.ID] = service.Hosts
+	}
+	return hosts
+}
+
+func TestClientSyncRollsRejectedObjectsBackToTheirLastGoodVersion(t *testing.T) {
+	exec := &fakeExecutor{}
+	c := newDeltaClient(t, exec, "a", "b")
+	_, err := c.Sync(context.Background())
+	require.NoError(t, err)
+
+	updateRoute(t, c, "a", "a2.example.com")
+	updateRoute(t, c, "b", "b2.example.com")
+	exec.errs = []error{objectRejection(adctypes.TypeService, "a")}
+	_, err = c.Sync(context.Background())
+	require.NoError(t, err, "the rejected object is quarantined, which leaves nothing to retry")
+
+	require.Len(t, exec.reqs, 3)
+	assert.Equal(t, map[string][]string{
+		"a": {"a.example.com"},
+		"b": {"b2.example.com"},
+	}, hostsOf(exec.reqs[2].Resources), "only the rejected object is held back")
+
+	_, err = c.SyncChanges(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, exec.reqs, 3)
+}
+
+func TestClientSyncLeavesOutARejectedObjectThatWasNeverAccepted(t *testing.T) {
+	exec := &fakeExecutor{}
+	c := newDeltaClient(t, exec, "a")
+	_, err := c.Sync(context.Background())
+	require.NoError(t, err)
+
+	updateRoute(t, c, "b", "b.example.com")
+	exec.errs = []error{objectRejection(adctypes.TypeService, "b")}
+	_, err = c.Sync(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, exec.reqs, 3)
+	assert.Equal(t, map[string][]string{"a": {"a.example.com"}}, hostsOf(exec.reqs[2].Resources))
+}
+
+func TestClientSyncRollsBackToAcceptedChanges(t *testing.T) {
+	exec := &fakeExecutor{}
+	c := newDeltaClient(t, exec, "a")
+	_, err := c.Sync(context.Background())
+	require.NoError(t, err)
+	updateRoute(t, c, "a", "a2.example.com")
+	_, err = c.SyncChanges(context.Background())
+	require.NoError(t, err)
+
+	updateRoute(t, c, "a", "a3.example.com")
+	exec.errs = []error{objectRejection(adctypes.TypeService, "a")}
+	_, err = c.Sync(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, exec.reqs, 4)
+	assert.Equal(t, map[string][]string{"a": {"a2.example.com"}}, hostsOf(exec.reqs[3].Resources))
+}
+
+func TestClientSyncDoesNotRollBackWithoutDetails(t *testing.T) {
+	exec := &fakeExecutor{}
+	c := newDeltaClient(t, exec, "a")
+	_, err := c.Sync(context.Background())
+	require.NoError(t, err)
+
+	updateRoute(t, c, "a", "a2.example.com")
+	exec.errs = []error{types.ADCExecutionError{
+		Name:         syncTaskCacheKey,
+		FailedErrors: []types.ADCExecutionServerAddrError{{Err: "connection refused"}},
+	}}
+	_, err = c.Sync(context.Background())
+	require.Error(t, err)
+	assert.Len(t, exec.reqs, 2, "there is nothing to tell the rejected objects by")
+}
+
+func TestRejectedObjectsMapsARouteToItsService(t *testing.T) {
+	resources := &adctypes.Resources{Services: []*adctypes.Service{{
+		Metadata: adctypes.Metadata{ID: "svc"},
+		Routes:   []*adctypes.Route{{Metadata: adctypes.Metadata{ID: "route"}}},
+	}}}
+
+	keys, ok := rejectedObjects(resources, types.ADCExecutionErrors{
+		Errors: []types.ADCExecutionError{objectRejection(adctypes.TypeRoute, "route").(types.ADCExecutionError)},
+	})
+	require.True(t, ok)
+	assert.Len(t, keys, 1)
+	assert.Equal(t, "svc", keys[0].ID)
+
+	_, ok = rejectedObjects(resources, types.ADCExecutionErrors{
+		Errors: []types.ADCExecutionError{objectRejection(adctypes.TypeGlobalRule, "cors").(types.ADCExecutionError)},
+	})
+	assert.False(t, ok, "a global rule cannot be held back on its own")
+}
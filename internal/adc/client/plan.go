@@ -0,0 +1,45 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
+)
+
+// Plan works out what applying task would push to the data plane of each of its configs,
+// without changing anything. A config the store holds nothing for yet, as on a replica
+// that is not the leader, is left out. The plans are ordered by config.
+func (c *Client) Plan(task Task) ([]*cache.Plan, error) {
+	var plans []*cache.Plan
+	for _, config := range task.Configs {
+		plan, ok, err := c.Store.Plan(config.Name, task.ResourceTypes, task.Resources, task.Labels)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to plan config %s", config.Name)
+		}
+		if ok {
+			plans = append(plans, plan)
+		}
+	}
+	slices.SortFunc(plans, func(a, b *cache.Plan) int { return strings.Compare(a.Config, b.Config) })
+	return plans, nil
+}
@@ -0,0 +1,52 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import "time"
+
+// Push is the last push of a config the data plane accepted.
+type Push struct {
+	// Sequence is the version of the last known good config, see snapshot, it pushed.
+	// It counts the pushes of this controller since it started, and is not the
+	// conf_version the data plane holds.
+	Sequence int64     `json:"sequence"`
+	At       time.Time `json:"at"`
+}
+
+// recordPush records that version of config name was pushed.
+func (c *Client) recordPush(name string, version int64) {
+	c.pushesMu.Lock()
+	defer c.pushesMu.Unlock()
+	c.pushes[name] = Push{Sequence: version, At: time.Now()}
+}
+
+// LastPush returns the last push of config name the data plane accepted, and reports
+// whether there was one.
+func (c *Client) LastPush(name string) (Push, bool) {
+	c.pushesMu.Lock()
+	defer c.pushesMu.Unlock()
+	push, ok := c.pushes[name]
+	return push, ok
+}
+
+// forgetPush drops the last push of config name.
+func (c *Client) forgetPush(name string) {
+	c.pushesMu.Lock()
+	defer c.pushesMu.Unlock()
+	delete(c.pushes, name)
+}
@@ -0,0 +1,64 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+func TestLastPushFollowsAcceptedPushes(t *testing.T) {
+	exec := &fakeExecutor{}
+	c := newDeltaClient(t, exec, "a")
+	_, ok := c.LastPush(syncTaskCacheKey)
+	assert.False(t, ok, "nothing was pushed yet")
+
+	_, err := c.Sync(context.Background())
+	require.NoError(t, err)
+	first, ok := c.LastPush(syncTaskCacheKey)
+	require.True(t, ok)
+	assert.Equal(t, int64(1), first.Sequence)
+
+	// Nothing changed, so nothing was pushed.
+	_, err = c.SyncChanges(context.Background())
+	require.NoError(t, err)
+	push, _ := c.LastPush(syncTaskCacheKey)
+	assert.Equal(t, first, push)
+
+	updateRoute(t, c, "a", "a2.example.com")
+	_, err = c.SyncChanges(context.Background())
+	require.NoError(t, err)
+	push, _ = c.LastPush(syncTaskCacheKey)
+	assert.Equal(t, int64(2), push.Sequence)
+
+	// A push that did not land leaves the last one in place.
+	updateRoute(t, c, "a", "a3.example.com")
+	exec.errs = []error{types.ADCExecutionError{
+		Name:         syncTaskCacheKey,
+		FailedErrors: []types.ADCExecutionServerAddrError{{Err: "connection refused"}},
+	}}
+	_, err = c.SyncChanges(context.Background())
+	require.Error(t, err)
+	failed, _ := c.LastPush(syncTaskCacheKey)
+	assert.Equal(t, push, failed)
+}
@@ -0,0 +1,256 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
+	"github.com/apache/apisix-ingress-controller/internal/controller/label"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+	pkgmetrics "github.com/apache/apisix-ingress-controller/pkg/metrics"
+)
+
+// QuarantineEntry is a Kubernetes object one config's data plane rejected what was
+// translated from. Until what the object translates to changes, none of that is pushed to
+// that config again: its data plane keeps the last version of it that was accepted, if
+// any. The same object is pushed to other configs as usual.
+type QuarantineEntry struct {
+	Key types.NamespacedNameKind `json:"key"`
+	// Config is the config the rejection came from.
+	Config string `json:"config"`
+	// ContentHash is the hash of what the object translated to when it was rejected. The
+	// entry is released once an update of the object, or of anything the object refers
+	// to, brings any other.
+	ContentHash string    `json:"contentHash"`
+	Reason      string    `json:"reason"`
+	Since       time.Time `json:"since"`
+}
+
+// quarantineKey names an object within one config.
+type quarantineKey struct {
+	config string
+	object types.NamespacedNameKind
+}
+
+// Quarantined lists the objects held out of pushes, ordered by config and key.
+func (c *Client) Quarantined() []QuarantineEntry {
+	c.quarantineMu.Lock()
+	defer c.quarantineMu.Unlock()
+	entries := make([]QuarantineEntry, 0, len(c.quarantine))
+	for _, key := range slices.SortedFunc(maps.Keys(c.quarantine), compareQuarantineKeys) {
+		entries = append(entries, *c.quarantine[key])
+	}
+	return entries
+}
+
+func compareQuarantineKeys(a, b quarantineKey) int {
+	return cmp.Or(strings.Compare(a.config, b.config), strings.Compare(a.object.String(), b.object.String()))
+}
+
+func (c *Client) isQuarantined(config string, key types.NamespacedNameKind) bool {
+	c.quarantineMu.Lock()
+	defer c.quarantineMu.Unlock()
+	_, ok := c.quarantine[quarantineKey{config: config, object: key}]
+	return ok
+}
+
+// observeUpdate records what an update of key translated to, and releases key from
+// quarantine in every config where that is not what was rejected, or that key is no
+// longer pushed to. The generation of key is not enough to go by: a rejection may come
+// from a Secret or Service the object refers to, which changes what it translates to
+// and not its generation.
+func (c *Client) observeUpdate(
+	key types.NamespacedNameKind,
+	configs map[types.NamespacedNameKind]adctypes.Config,
+	resources *adctypes.Resources,
+) {
+	hash, err := hashAdminAPIBody(resources)
+	if err != nil {
+		// An empty hash matches no entry, which releases them all.
+		c.log.Error(err, "failed to hash translated resources", "key", key)
+	}
+	c.quarantineMu.Lock()
+	defer c.quarantineMu.Unlock()
+	c.contents[key] = hash
+
+	pushedTo := make(map[string]struct{}, len(configs))
+	for _, config := range configs {
+		pushedTo[config.Name] = struct{}{}
+	}
+	released := false
+	for qk, entry := range c.quarantine {
+		if qk.object != key {
+			continue
+		}
+		if _, ok := pushedTo[qk.config]; ok && hash != "" && entry.ContentHash == hash {
+			continue
+		}
+		c.log.Info("releasing object from quarantine", "key", key, "config", qk.config)
+		delete(c.quarantine, qk)
+		released = true
+	}
+	if released {
+		c.updateQuarantineMetrics()
+	}
+}
+
+// forget releases a deleted object from quarantine in every config.
+func (c *Client) forget(key types.NamespacedNameKind) {
+	c.quarantineMu.Lock()
+	defer c.quarantineMu.Unlock()
+	delete(c.contents, key)
+	released := false
+	for qk := range c.quarantine {
+		if qk.object == key {
+			delete(c.quarantine, qk)
+			released = true
+		}
+	}
+	if released {
+		c.updateQuarantineMetrics()
+	}
+}
+
+// updateQuarantineMetrics must be called with quarantineMu held.
+func (c *Client) updateQuarantineMetrics() {
+	counts := make(map[string]int)
+	for key := range c.quarantine {
+		counts[key.object.Kind]++
+	}
+	pkgmetrics.ResetQuarantinedResources()
+	for kind, count := range counts {
+		pkgmetrics.SetQuarantinedResources(kind, float64(count))
+	}
+}
+
+// ownerKey is the object labels say an ADC object was translated from, if they say.
+func ownerKey(labels map[string]string) (types.NamespacedNameKind, bool) {
+	key := types.NamespacedNameKind{
+		Kind:      labels[label.LabelKind],
+		Namespace: labels[label.LabelNamespace],
+		Name:      labels[label.LabelName],
+	}
+	return key, key.Kind != "" && key.Name != ""
+}
+
+// quarantinedObjects names the objects of resources translated from an object quarantined
+// in config.
+func (c *Client) quarantinedObjects(config string, resources *adctypes.Resources) []cache.ObjectKey {
+	c.quarantineMu.Lock()
+	defer c.quarantineMu.Unlock()
+	if len(c.quarantine) == 0 {
+		return nil
+	}
+	var keys []cache.ObjectKey
+	held := func(resourceType, id string, labels map[string]string) {
+		if key, ok := ownerKey(labels); ok {
+			if _, ok := c.quarantine[quarantineKey{config: config, object: key}]; ok {
+				keys = append(keys, cache.ObjectKey{Type: resourceType, ID: id})
+			}
+		}
+	}
+	for _, service := range resources.Services {
+		held(adctypes.TypeService, service.ID, service.Labels)
+	}
+	for _, ssl := range resources.SSLs {
+		held(adctypes.TypeSSL, ssl.ID, ssl.Labels)
+	}
+	for _, consumer := range resources.Consumers {
+		held(adctypes.TypeConsumer, consumer.Username, consumer.Labels)
+	}
+	return keys
+}
+
+// quarantineRejected quarantines the objects the sync failures in err name objects of
+// resources translated from. It reports whether every failure was accounted for that
+// way, which leaves nothing about the push to retry.
+func (c *Client) quarantineRejected(name string, resources *adctypes.Resources, err error) bool {
+	var execErrs types.ADCExecutionErrors
+	if !errors.As(err, &execErrs) || len(execErrs.Errors) == 0 {
+		return false
+	}
+	c.quarantineMu.Lock()
+	defer c.quarantineMu.Unlock()
+
+	all := true
+	for _, execErr := range execErrs.Errors {
+		if len(execErr.FailedErrors) == 0 {
+			all = false
+		}
+		for _, failed := range execErr.FailedErrors {
+			if len(failed.FailedStatuses) == 0 {
+				all = false
+			}
+			for _, status := range failed.FailedStatuses {
+				objectKey, ok := rejectedObject(resources, status.Event)
+				if !ok {
+					all = false
+					continue
+				}
+				key, ok := ownerKey(objectLabels(resources, objectKey))
+				if !ok {
+					all = false
+					continue
+				}
+				reason := fmt.Sprintf("ServerAddr: %s, Error: %s", failed.ServerAddr, status.Reason)
+				qk := quarantineKey{config: name, object: key}
+				if entry, ok := c.quarantine[qk]; ok {
+					entry.Reason = reason
+					continue
+				}
+				c.log.Info("quarantining object the data plane rejected", "key", key, "config", name, "reason", reason)
+				c.quarantine[qk] = &QuarantineEntry{
+					Key:         key,
+					Config:      name,
+					ContentHash: c.contents[key],
+					Reason:      reason,
+					Since:       time.Now(),
+				}
+			}
+		}
+	}
+	c.updateQuarantineMetrics()
+	return all
+}
+
+func objectLabels(resources *adctypes.Resources, key cache.ObjectKey) map[string]string {
+	switch key.Type {
+	case adctypes.TypeService:
+		if i := slices.IndexFunc(resources.Services, func(s *adctypes.Service) bool { return s.ID == key.ID }); i >= 0 {
+			return resources.Services[i].Labels
+		}
+	case adctypes.TypeSSL:
+		if i := slices.IndexFunc(resources.SSLs, func(s *adctypes.SSL) bool { return s.ID == key.ID }); i >= 0 {
+			return resources.SSLs[i].Labels
+		}
+	case adctypes.TypeConsumer:
+		if i := slices.IndexFunc(resources.Consumers, func(c *adctypes.Consumer) bool { return c.Username == key.ID }); i >= 0 {
+			return resources.Consumers[i].Labels
+		}
+	}
+	return nil
+}
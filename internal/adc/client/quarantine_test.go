@@ -0,0 +1,146 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+var routeA = types.NamespacedNameKind{Kind: "HTTPRoute", Namespace: "ns", Name: "a"}
+
+func TestClientQuarantinesWhatTheDataPlaneRejected(t *testing.T) {
+	exec := &fakeExecutor{}
+	c := newDeltaClient(t, exec, "a", "b")
+	_, err := c.Sync(context.Background())
+	require.NoError(t, err)
+
+	updateRoute(t, c, "a", "a2.example.com")
+	exec.errs = []error{objectRejection(adctypes.TypeService, "a")}
+	_, err = c.Sync(context.Background())
+	require.NoError(t, err)
+
+	entries := c.Quarantined()
+	require.Len(t, entries, 1)
+	assert.Equal(t, routeA, entries[0].Key)
+	assert.Equal(t, syncTaskCacheKey, entries[0].Config)
+	assert.NotEmpty(t, entries[0].ContentHash)
+	assert.Equal(t, "ServerAddr: http://apisix:9180, Error: invalid configuration", entries[0].Reason)
+
+	_, err = c.Sync(context.Background())
+	require.NoError(t, err)
+	require.Len(t, exec.reqs, 4)
+	assert.Equal(t, map[string][]string{
+		"a": {"a.example.com"},
+		"b": {"b.example.com"},
+	}, hostsOf(exec.reqs[3].Resources), "a quarantined object is held out of every push")
+}
+
+func TestClientReleasesQuarantineWhenTheTranslationChanges(t *testing.T) {
+	exec := &fakeExecutor{}
+	c := newDeltaClient(t, exec, "a")
+	_, err := c.Sync(context.Background())
+	require.NoError(t, err)
+
+	updateRoute(t, c, "a", "a2.example.com")
+	exec.errs = []error{objectRejection(adctypes.TypeService, "a")}
+	_, err = c.Sync(context.Background())
+	require.NoError(t, err)
+	require.Len(t, c.Quarantined(), 1)
+
+	// A reconcile that translates to the same resources changes nothing.
+	updateRoute(t, c, "a", "a2.example.com")
+	assert.Len(t, c.Quarantined(), 1)
+
+	// What it translates to may change without the object itself changing, when the
+	// rejection came from something the object refers to.
+	updateRoute(t, c, "a", "a3.example.com")
+	assert.Empty(t, c.Quarantined())
+	_, err = c.SyncChanges(context.Background())
+	require.NoError(t, err)
+	require.Len(t, exec.reqs, 4)
+	assert.Equal(t, httpRouteLabels("a"), exec.reqs[3].Labels)
+	assert.Equal(t, map[string][]string{"a": {"a3.example.com"}}, hostsOf(exec.reqs[3].Resources))
+}
+
+func TestClientQuarantinesARejectedChange(t *testing.T) {
+	exec := &fakeExecutor{}
+	c := newDeltaClient(t, exec, "a", "b")
+	_, err := c.Sync(context.Background())
+	require.NoError(t, err)
+
+	updateRoute(t, c, "a", "a2.example.com")
+	exec.errs = []error{objectRejection(adctypes.TypeService, "a")}
+	_, err = c.SyncChanges(context.Background())
+	require.NoError(t, err)
+	require.Len(t, c.Quarantined(), 1)
+
+	_, err = c.SyncChanges(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, exec.reqs, 2, "a quarantined change is not pushed again")
+
+	require.NoError(t, c.DeleteConfig(context.Background(), Task{
+		Key:           routeA,
+		Labels:        httpRouteLabels("a"),
+		ResourceTypes: []string{adctypes.TypeService},
+	}))
+	assert.Empty(t, c.Quarantined(), "a deleted object is released")
+}
+
+func TestClientQuarantinesPerConfig(t *testing.T) {
+	exec := &fakeExecutor{}
+	c := newDeltaClient(t, exec, "a")
+	other := adctypes.Config{Name: "GatewayProxy/ns/other", ServerAddrs: []string{"http://other:9180"}}
+	otherKey := types.NamespacedNameKind{Kind: "GatewayProxy", Namespace: "ns", Name: "other"}
+	c.ConfigManager.UpdateConfig(otherKey, other)
+	pushTo := func(configs ...types.NamespacedNameKind) {
+		t.Helper()
+		tasks := make(map[types.NamespacedNameKind]adctypes.Config, len(configs))
+		for _, key := range configs {
+			tasks[key] = c.ConfigManager.List()[key]
+		}
+		require.NoError(t, c.UpdateConfig(context.Background(), Task{
+			Key:           routeA,
+			Labels:        httpRouteLabels("a"),
+			ResourceTypes: []string{adctypes.TypeService},
+			Configs:       tasks,
+			Resources: &adctypes.Resources{Services: []*adctypes.Service{{
+				Metadata: adctypes.Metadata{ID: "a", Name: "a", Labels: httpRouteLabels("a")},
+				Hosts:    []string{"a.example.com"},
+			}}},
+		}))
+	}
+	pushTo(deltaGatewayProxy, otherKey)
+
+	exec.errs = []error{objectRejection(adctypes.TypeService, "a")}
+	_, err := c.SyncChanges(context.Background(), syncTaskCacheKey)
+	require.NoError(t, err)
+	require.Len(t, c.Quarantined(), 1)
+	assert.True(t, c.isQuarantined(syncTaskCacheKey, routeA))
+	assert.False(t, c.isQuarantined(other.Name, routeA), "a rejection by one data plane holds nothing out of another")
+
+	// An object no longer pushed to a config is no longer held out of it either.
+	pushTo(otherKey)
+	assert.Empty(t, c.Quarantined())
+}
@@ -0,0 +1,231 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
+	"github.com/apache/apisix-ingress-controller/internal/provider/common"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+	pkgmetrics "github.com/apache/apisix-ingress-controller/pkg/metrics"
+)
+
+// stateFileVersion is the layout of the state a state file holds. A file of another
+// version is not loaded.
+const stateFileVersion = 1
+
+// stateFile is the file layout of what SaveState writes: a header, and the state with
+// the SHA-256 checksum of its bytes, so that a file cut short or edited is not loaded.
+type stateFile struct {
+	Version  int             `json:"version"`
+	SavedAt  time.Time       `json:"savedAt"`
+	Checksum string          `json:"checksum"`
+	State    json.RawMessage `json:"state"`
+}
+
+// savedState is everything the client holds about what to push.
+type savedState struct {
+	Store   *cache.StoreState                                                 `json:"store"`
+	Configs *common.ConfigManagerState[types.NamespacedNameKind, savedConfig] `json:"configs"`
+}
+
+// savedConfig is adctypes.Config with its token, which its MarshalJSON leaves out.
+type savedConfig struct {
+	Name        string   `json:"name"`
+	ServerAddrs []string `json:"serverAddrs"`
+	Token       string   `json:"token"`
+	TlsVerify   bool     `json:"tlsVerify"`
+	BackendType string   `json:"backendType"`
+	Executor    string   `json:"executor,omitempty"`
+}
+
+// SaveState writes the store and the configs to path, so that LoadState can restore
+// them after a restart. The file replaces the previous one atomically, and holds TLS
+// keys, consumer credentials and Admin API keys, so only its owner can read it.
+func (c *Client) SaveState(path string) error {
+	start := time.Now()
+	err := c.saveState(path)
+	status := adctypes.StatusSuccess
+	if err != nil {
+		status = "failure"
+	}
+	pkgmetrics.RecordFileIODuration("save_state", status, time.Since(start).Seconds())
+	return err
+}
+
+func (c *Client) saveState(path string) error {
+	storeState, err := c.State()
+	if err != nil {
+		return err
+	}
+	configs := c.ConfigManager.State()
+	saved := savedState{
+		Store: storeState,
+		Configs: &common.ConfigManagerState[types.NamespacedNameKind, savedConfig]{
+			ConfigRefs:         configs.ConfigRefs,
+			ResourceConfigKeys: configs.ResourceConfigKeys,
+		},
+	}
+	for _, entry := range configs.Configs {
+		config := entry.Config
+		saved.Configs.Configs = append(saved.Configs.Configs, common.ConfigEntry[types.NamespacedNameKind, savedConfig]{
+			Key: entry.Key,
+			Config: savedConfig{
+				Name:        config.Name,
+				ServerAddrs: config.ServerAddrs,
+				Token:       config.Token,
+				TlsVerify:   config.TlsVerify,
+				BackendType: config.BackendType,
+				Executor:    config.Executor,
+			},
+		})
+	}
+	state, err := json.Marshal(saved)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(state)
+	data, err := json.Marshal(stateFile{
+		Version:  stateFileVersion,
+		SavedAt:  time.Now().UTC(),
+		Checksum: "sha256:" + hex.EncodeToString(sum[:]),
+		State:    state,
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(path, data)
+}
+
+// writeFileAtomic writes data to path, readable by its owner only. It is written to a
+// temporary file renamed over path once complete, so a reader sees either what path held
+// before or data.
+func writeFileAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// LoadState restores the store and the configs SaveState wrote to path, except what was
+// translated since the client was created, which is newer. It reports false if there is
+// no file. What it restores stays until DropRestored, unless it is translated again.
+func (c *Client) LoadState(path string) (bool, error) {
+	start := time.Now()
+	loaded, err := c.loadState(path)
+	status := adctypes.StatusSuccess
+	if err != nil {
+		status = "failure"
+	}
+	pkgmetrics.RecordFileIODuration("load_state", status, time.Since(start).Seconds())
+	return loaded, err
+}
+
+func (c *Client) loadState(path string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	var file stateFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return false, fmt.Errorf("failed to parse state file: %w", err)
+	}
+	if file.Version != stateFileVersion {
+		return false, fmt.Errorf("state file version %d is not %d", file.Version, stateFileVersion)
+	}
+	sum := sha256.Sum256(file.State)
+	if checksum := "sha256:" + hex.EncodeToString(sum[:]); checksum != file.Checksum {
+		return false, fmt.Errorf("state file checksum %s does not match its content, %s", file.Checksum, checksum)
+	}
+	var saved savedState
+	if err := json.Unmarshal(file.State, &saved); err != nil {
+		return false, fmt.Errorf("failed to parse state: %w", err)
+	}
+
+	if saved.Store != nil {
+		if err := c.Restore(saved.Store); err != nil {
+			return false, err
+		}
+	}
+	if saved.Configs != nil {
+		configs := &common.ConfigManagerState[types.NamespacedNameKind, adctypes.Config]{
+			ConfigRefs:         saved.Configs.ConfigRefs,
+			ResourceConfigKeys: saved.Configs.ResourceConfigKeys,
+		}
+		for _, entry := range saved.Configs.Configs {
+			config := entry.Config
+			configs.Configs = append(configs.Configs, common.ConfigEntry[types.NamespacedNameKind, adctypes.Config]{
+				Key: entry.Key,
+				Config: adctypes.Config{
+					Name:        config.Name,
+					ServerAddrs: config.ServerAddrs,
+					Token:       config.Token,
+					TlsVerify:   config.TlsVerify,
+					BackendType: config.BackendType,
+					Executor:    config.Executor,
+				},
+			})
+		}
+		c.ConfigManager.Restore(configs)
+	}
+	c.log.Info("restored state", "path", path, "savedAt", file.SavedAt)
+	return true, nil
+}
+
+// DropRestored removes what LoadState restored that was not translated again since: the
+// objects deleted, and the configs gone, while nothing was watching. It returns how many
+// owners it removed the objects of.
+func (c *Client) DropRestored() int {
+	dropped := 0
+	for name, owners := range c.Store.DropRestored() {
+		c.log.Info("dropped restored objects that were not translated again", "config", name, "owners", owners)
+		dropped += len(owners)
+	}
+	keys := c.ConfigManager.DropRestored()
+	if len(keys) > 0 {
+		c.log.Info("dropped restored configs that were not translated again", "keys", keys)
+	}
+	return dropped
+}
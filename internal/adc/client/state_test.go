@@ -0,0 +1,116 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/controller/label"
+	"github.com/apache/apisix-ingress-controller/internal/provider/common"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+func newStateTestClient() *Client {
+	c := newTestClient(nil)
+	c.ConfigManager = common.NewConfigManager[types.NamespacedNameKind, adctypes.Config]()
+	return c
+}
+
+func insertRoute(t *testing.T, c *Client, name string) {
+	t.Helper()
+	labels := map[string]string{label.LabelKind: "HTTPRoute", label.LabelNamespace: "default", label.LabelName: name}
+	require.NoError(t, c.Insert(syncTaskCacheKey, []string{adctypes.TypeService}, &adctypes.Resources{
+		Services: []*adctypes.Service{{Metadata: adctypes.Metadata{ID: name, Name: name, Labels: labels}}},
+	}, labels))
+	c.ConfigManager.Update(types.NamespacedNameKind{Kind: "HTTPRoute", Namespace: "default", Name: name},
+		map[types.NamespacedNameKind]adctypes.Config{
+			{Kind: "GatewayProxy", Namespace: "ns", Name: "name"}: {Name: syncTaskCacheKey, Token: "admin-key"},
+		})
+}
+
+func serviceIDs(t *testing.T, c *Client) []string {
+	t.Helper()
+	resources, err := c.GetResources(syncTaskCacheKey)
+	require.NoError(t, err)
+	var ids []string
+	for _, service := range resources.Services {
+		ids = append(ids, service.ID)
+	}
+	return ids
+}
+
+func TestClientStateSurvivesARestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	before := newStateTestClient()
+	insertRoute(t, before, "kept")
+	insertRoute(t, before, "deleted")
+	require.NoError(t, before.SaveState(path))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm(), "the file holds credentials")
+
+	after := newStateTestClient()
+	loaded, err := after.LoadState(path)
+	require.NoError(t, err)
+	require.True(t, loaded)
+	assert.ElementsMatch(t, []string{"kept", "deleted"}, serviceIDs(t, after))
+	configs := after.ConfigManager.List()
+	require.Len(t, configs, 1)
+	for _, config := range configs {
+		assert.Equal(t, "admin-key", config.Token, "the token is saved with the config")
+	}
+
+	// Only "kept" is translated again once the controller is ready; "deleted" went
+	// while the controller was down.
+	insertRoute(t, after, "kept")
+	assert.Equal(t, 1, after.DropRestored())
+	assert.Equal(t, []string{"kept"}, serviceIDs(t, after))
+	assert.Len(t, after.ConfigManager.Get(types.NamespacedNameKind{Kind: "HTTPRoute", Namespace: "default", Name: "kept"}), 1)
+	assert.Empty(t, after.ConfigManager.Get(types.NamespacedNameKind{Kind: "HTTPRoute", Namespace: "default", Name: "deleted"}))
+}
+
+func TestClientLoadStateRejectsDamagedFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	loaded, err := newStateTestClient().LoadState(filepath.Join(dir, "missing.json"))
+	require.NoError(t, err)
+	assert.False(t, loaded, "no file is not an error")
+
+	path := filepath.Join(dir, "state.json")
+	c := newStateTestClient()
+	insertRoute(t, c, "route")
+	require.NoError(t, c.SaveState(path))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	tampered := []byte(string(data[:len(data)-20]) + `"id":"other"}]}}}}}}`)
+	require.NoError(t, os.WriteFile(path, tampered, 0o600))
+	_, err = newStateTestClient().LoadState(path)
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"version":2,"checksum":"","state":{}}`), 0o600))
+	_, err = newStateTestClient().LoadState(path)
+	assert.ErrorContains(t, err, "version 2")
+}
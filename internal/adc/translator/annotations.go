@@ -23,10 +23,12 @@ import (
 
 	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
 	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations"
+	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations/oidc"
 	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations/pluginconfig"
 	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations/plugins"
 	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations/regex"
 	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations/servicenamespace"
+	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations/timeout"
 	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations/upstream"
 	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations/websocket"
 )
@@ -34,31 +36,80 @@ import (
 // Structure extracted by Ingress Resource
 type IngressConfig struct {
 	Upstream         upstream.Upstream
+	Timeout          timeout.Timeout
 	Plugins          adctypes.Plugins
 	EnableWebsocket  bool
 	ServiceNamespace string
 	PluginConfigName string
 	UseRegex         bool
+	OIDCClientSecret oidc.ClientSecretRef
 }
 
 var ingressAnnotationParsers = map[string]annotations.IngressAnnotationsParser{
 	"upstream":         upstream.NewParser(),
+	"timeout":          timeout.NewParser(),
 	"plugins":          plugins.NewParser(),
 	"EnableWebsocket":  websocket.NewParser(),
 	"PluginConfigName": pluginconfig.NewParser(),
 	"ServiceNamespace": servicenamespace.NewParser(),
 	"UseRegex":         regex.NewParser(),
+	"OIDCClientSecret": oidc.NewParser(),
 }
 
-func (t *Translator) TranslateIngressAnnotations(anno map[string]string) *IngressConfig {
+// TranslateIngressAnnotations extracts the config of an Ingress from its annotations.
+// An invalid value of an annotation that is validated strictly, such as a body size or
+// a timeout, is returned as an error, so that the Ingress is not programmed without
+// what it asks for. Other invalid annotations are left out of the config and logged,
+// see InvalidIngressAnnotations.
+func (t *Translator) TranslateIngressAnnotations(anno map[string]string) (*IngressConfig, error) {
 	if len(anno) == 0 {
-		return nil
+		return nil, nil
 	}
 	ing := &IngressConfig{}
-	if err := translateAnnotations(anno, ing); err != nil {
-		t.Log.Error(err, "failed to translate ingress annotations", "annotations", anno)
+	strict, ignored := splitAnnotationErrors(translateAnnotations(anno, ing))
+	if len(ignored) > 0 {
+		t.Log.Error(errors.Join(ignored...), "failed to translate ingress annotations", "annotations", anno)
+	}
+	return ing, errors.Join(strict...)
+}
+
+// InvalidIngressAnnotations returns the errors of the invalid annotations that the
+// translation of an Ingress leaves out rather than fails for.
+func InvalidIngressAnnotations(anno map[string]string) []error {
+	if len(anno) == 0 {
+		return nil
+	}
+	_, ignored := splitAnnotationErrors(translateAnnotations(anno, &IngressConfig{}))
+	return ignored
+}
+
+// splitAnnotationErrors separates the errors of the annotations validated strictly from
+// the others.
+func splitAnnotationErrors(err error) (strict, ignored []error) {
+	for _, err := range unjoin(err) {
+		if errors.As(err, new(*annotations.StrictError)) {
+			strict = append(strict, err)
+		} else {
+			ignored = append(ignored, err)
+		}
+	}
+	return strict, ignored
+}
+
+// unjoin returns the errors err joins, or err alone.
+func unjoin(err error) []error {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		if err == nil {
+			return nil
+		}
+		return []error{err}
+	}
+	var errs []error
+	for _, err := range joined.Unwrap() {
+		errs = append(errs, unjoin(err)...)
 	}
-	return ing
+	return errs
 }
 
 func translateAnnotations(anno map[string]string, dst any) error {
@@ -67,10 +118,10 @@ func translateAnnotations(anno map[string]string, dst any) error {
 	var errs []error
 
 	for name, parser := range ingressAnnotationParsers {
+		// A parser that fails may still return what it could parse.
 		out, err := parser.Parse(extractor)
-		if err != nil {
+		for _, err := range unjoin(err) {
 			errs = append(errs, fmt.Errorf("parse %s: %w", name, err))
-			continue
 		}
 		if out != nil {
 			data[name] = out
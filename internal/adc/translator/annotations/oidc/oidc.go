@@ -0,0 +1,45 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations"
+)
+
+func NewParser() annotations.IngressAnnotationsParser {
+	return &ClientSecretRef{}
+}
+
+// ClientSecretRef locates the OpenID Connect client secret, the Secret lives
+// in the namespace of the Ingress. It is set whenever auth-type is
+// openidConnect, even if the Secret name is missing, so that the translator
+// can refuse to program the route without authentication.
+type ClientSecretRef struct {
+	Name string
+	Key  string
+}
+
+func (r ClientSecretRef) Parse(e annotations.Extractor) (any, error) {
+	if e.GetStringAnnotation(annotations.AnnotationsAuthType) != annotations.AuthTypeOpenIDConnect {
+		return nil, nil
+	}
+	r.Name = e.GetStringAnnotation(annotations.AnnotationsOIDCClientSecretRef)
+	r.Key = e.GetStringAnnotation(annotations.AnnotationsOIDCClientSecretKey)
+	if r.Key == "" {
+		r.Key = annotations.DefaultOIDCClientSecretKey
+	}
+	return r, nil
+}
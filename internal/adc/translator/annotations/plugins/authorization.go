@@ -16,6 +16,9 @@
 package plugins
 
 import (
+	"fmt"
+	"strings"
+
 	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
 	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations"
 )
@@ -33,7 +36,7 @@ func (b *basicAuth) PluginName() string {
 }
 
 func (b *basicAuth) Handle(e annotations.Extractor) (any, error) {
-	if e.GetStringAnnotation(annotations.AnnotationsAuthType) != "basicAuth" {
+	if e.GetStringAnnotation(annotations.AnnotationsAuthType) != annotations.AuthTypeBasicAuth {
 		return nil, nil
 	}
 	plugin := adctypes.BasicAuthConfig{}
@@ -53,9 +56,84 @@ func (k *keyAuth) PluginName() string {
 }
 
 func (k *keyAuth) Handle(e annotations.Extractor) (any, error) {
-	if e.GetStringAnnotation(annotations.AnnotationsAuthType) != "keyAuth" {
+	if e.GetStringAnnotation(annotations.AnnotationsAuthType) != annotations.AuthTypeKeyAuth {
 		return nil, nil
 	}
 	plugin := adctypes.KeyAuthConfig{}
 	return &plugin, nil
 }
+
+type jwtAuth struct{}
+
+// NewJwtAuthHandler creates a handler to convert
+// annotations about jwtAuth control to APISIX jwt-auth plugin.
+func NewJwtAuthHandler() PluginAnnotationsHandler {
+	return &jwtAuth{}
+}
+
+func (j *jwtAuth) PluginName() string {
+	return "jwt-auth"
+}
+
+func (j *jwtAuth) Handle(e annotations.Extractor) (any, error) {
+	if e.GetStringAnnotation(annotations.AnnotationsAuthType) != annotations.AuthTypeJwtAuth {
+		return nil, nil
+	}
+	return &adctypes.JwtAuthRouteConfig{
+		Header: e.GetStringAnnotation(annotations.AnnotationsJwtAuthHeader),
+		Query:  e.GetStringAnnotation(annotations.AnnotationsJwtAuthQuery),
+		Cookie: e.GetStringAnnotation(annotations.AnnotationsJwtAuthCookie),
+	}, nil
+}
+
+type hmacAuth struct{}
+
+// NewHMACAuthHandler creates a handler to convert
+// annotations about hmacAuth control to APISIX hmac-auth plugin.
+func NewHMACAuthHandler() PluginAnnotationsHandler {
+	return &hmacAuth{}
+}
+
+func (h *hmacAuth) PluginName() string {
+	return "hmac-auth"
+}
+
+func (h *hmacAuth) Handle(e annotations.Extractor) (any, error) {
+	if e.GetStringAnnotation(annotations.AnnotationsAuthType) != annotations.AuthTypeHMACAuth {
+		return nil, nil
+	}
+	return &adctypes.HMACAuthRouteConfig{}, nil
+}
+
+type openIDConnect struct{}
+
+// NewOpenIDConnectHandler creates a handler to convert
+// annotations about openidConnect control to APISIX openid-connect plugin.
+// The client secret is not part of the annotations, it is filled in by the
+// translator from the Secret referenced by oidc-client-secret-ref.
+func NewOpenIDConnectHandler() PluginAnnotationsHandler {
+	return &openIDConnect{}
+}
+
+func (o *openIDConnect) PluginName() string {
+	return "openid-connect"
+}
+
+func (o *openIDConnect) Handle(e annotations.Extractor) (any, error) {
+	if e.GetStringAnnotation(annotations.AnnotationsAuthType) != annotations.AuthTypeOpenIDConnect {
+		return nil, nil
+	}
+	discovery := e.GetStringAnnotation(annotations.AnnotationsOIDCDiscovery)
+	clientID := e.GetStringAnnotation(annotations.AnnotationsOIDCClientID)
+	if discovery == "" || clientID == "" {
+		return nil, fmt.Errorf("annotations %q and %q are required when %q is %q",
+			annotations.AnnotationsOIDCDiscovery, annotations.AnnotationsOIDCClientID,
+			annotations.AnnotationsAuthType, annotations.AuthTypeOpenIDConnect)
+	}
+	return &adctypes.OpenIDConnectConfig{
+		Discovery:  discovery,
+		ClientID:   clientID,
+		Scope:      strings.Join(e.GetStringsAnnotation(annotations.AnnotationsOIDCScope), " "),
+		BearerOnly: e.GetBoolAnnotation(annotations.AnnotationsOIDCBearerOnly),
+	}, nil
+}
@@ -0,0 +1,77 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations"
+)
+
+func TestAuthHandlers(t *testing.T) {
+	anno := map[string]string{
+		annotations.AnnotationsAuthType:      "jwtAuth",
+		annotations.AnnotationsJwtAuthQuery:  "token",
+		annotations.AnnotationsJwtAuthCookie: "jwt",
+	}
+	e := annotations.NewExtractor(anno)
+
+	out, err := NewJwtAuthHandler().Handle(e)
+	assert.Nil(t, err, "checking given error")
+	assert.Equal(t, &adctypes.JwtAuthRouteConfig{Query: "token", Cookie: "jwt"}, out)
+
+	// only the handler matching the auth type produces a plugin
+	for _, h := range []PluginAnnotationsHandler{
+		NewKeyAuthHandler(), NewBasicAuthHandler(), NewHMACAuthHandler(), NewOpenIDConnectHandler(),
+	} {
+		out, err = h.Handle(e)
+		assert.Nil(t, err, "checking given error of %s", h.PluginName())
+		assert.Nil(t, out, "checking given output of %s", h.PluginName())
+	}
+
+	anno[annotations.AnnotationsAuthType] = "hmacAuth"
+	out, err = NewHMACAuthHandler().Handle(annotations.NewExtractor(anno))
+	assert.Nil(t, err, "checking given error")
+	assert.Equal(t, &adctypes.HMACAuthRouteConfig{}, out)
+}
+
+func TestOpenIDConnectHandler(t *testing.T) {
+	anno := map[string]string{
+		annotations.AnnotationsAuthType:            "openidConnect",
+		annotations.AnnotationsOIDCDiscovery:       "https://idp.example.com/.well-known/openid-configuration",
+		annotations.AnnotationsOIDCClientID:        "dashboard",
+		annotations.AnnotationsOIDCScope:           "openid email",
+		annotations.AnnotationsOIDCBearerOnly:      "true",
+		annotations.AnnotationsOIDCClientSecretRef: "oidc-secret",
+	}
+	p := NewOpenIDConnectHandler()
+	out, err := p.Handle(annotations.NewExtractor(anno))
+	assert.Nil(t, err, "checking given error")
+	config := out.(*adctypes.OpenIDConnectConfig)
+	assert.Equal(t, "dashboard", config.ClientID)
+	assert.Equal(t, "openid email", config.Scope)
+	assert.True(t, config.BearerOnly)
+	assert.Empty(t, config.ClientSecret, "the client secret is never read from annotations")
+	assert.Equal(t, "openid-connect", p.PluginName())
+
+	delete(anno, annotations.AnnotationsOIDCClientID)
+	out, err = p.Handle(annotations.NewExtractor(anno))
+	assert.Error(t, err, "expecting an error when the client id is missing")
+	assert.Nil(t, out, "checking given output")
+}
@@ -0,0 +1,81 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations"
+)
+
+func TestClientControlHandler(t *testing.T) {
+	anno := map[string]string{
+		annotations.AnnotationsClientMaxBodySize: "10m",
+	}
+	p := NewClientControlHandler()
+	out, err := p.Handle(annotations.NewExtractor(anno))
+	assert.Nil(t, err, "checking given error")
+	config := out.(*adctypes.ClientControlConfig)
+	assert.Equal(t, int64(10*1024*1024), config.MaxBodySize)
+
+	assert.Equal(t, "client-control", p.PluginName())
+
+	// Zero disables the limit in APISIX and is accepted as is
+	anno[annotations.AnnotationsClientMaxBodySize] = "0"
+	out, err = p.Handle(annotations.NewExtractor(anno))
+	assert.Nil(t, err, "checking given error")
+	config = out.(*adctypes.ClientControlConfig)
+	assert.Equal(t, int64(0), config.MaxBodySize)
+
+	// Invalid unit
+	anno[annotations.AnnotationsClientMaxBodySize] = "10mb"
+	out, err = p.Handle(annotations.NewExtractor(anno))
+	assert.Error(t, err, "expecting an error for an invalid unit")
+	assert.Nil(t, out, "checking given output")
+
+	// Annotation missing
+	delete(anno, annotations.AnnotationsClientMaxBodySize)
+	out, err = p.Handle(annotations.NewExtractor(anno))
+	assert.Nil(t, err, "checking given error")
+	assert.Nil(t, out, "checking given output")
+}
+
+func TestProxyControlHandler(t *testing.T) {
+	anno := map[string]string{
+		annotations.AnnotationsRequestBuffering: "false",
+	}
+	p := NewProxyControlHandler()
+	out, err := p.Handle(annotations.NewExtractor(anno))
+	assert.Nil(t, err, "checking given error")
+	config := out.(*adctypes.ProxyControlConfig)
+	assert.False(t, config.RequestBuffering)
+
+	assert.Equal(t, "proxy-control", p.PluginName())
+
+	anno[annotations.AnnotationsRequestBuffering] = "true"
+	out, err = p.Handle(annotations.NewExtractor(anno))
+	assert.Nil(t, err, "checking given error")
+	config = out.(*adctypes.ProxyControlConfig)
+	assert.True(t, config.RequestBuffering)
+
+	anno[annotations.AnnotationsRequestBuffering] = "maybe"
+	out, err = p.Handle(annotations.NewExtractor(anno))
+	assert.Error(t, err, "expecting an error for an invalid boolean")
+	assert.Nil(t, out, "checking given output")
+}
@@ -15,7 +15,8 @@
 package plugins
 
 import (
-	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"errors"
+	"fmt"
 
 	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
 	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations"
@@ -33,8 +34,6 @@ type PluginAnnotationsHandler interface {
 }
 
 var (
-	log = logf.Log.WithName("annotations").WithName("plugins").WithName("parser")
-
 	handlers = []PluginAnnotationsHandler{
 		NewRedirectHandler(),
 		NewRewriteHandler(),
@@ -43,9 +42,14 @@ var (
 		NewFaultInjectionHandler(),
 		NewBasicAuthHandler(),
 		NewKeyAuthHandler(),
+		NewJwtAuthHandler(),
+		NewHMACAuthHandler(),
+		NewOpenIDConnectHandler(),
 		NewResponseRewriteHandler(),
 		NewIPRestrictionHandler(),
 		NewForwardAuthHandler(),
+		NewClientControlHandler(),
+		NewProxyControlHandler(),
 	}
 )
 
@@ -55,12 +59,15 @@ func NewParser() annotations.IngressAnnotationsParser {
 	return &plugins{}
 }
 
+// Parse converts the annotations of every handler to plugins. A handler that fails
+// does not hold back the others, but its error is returned with what they produced.
 func (p *plugins) Parse(e annotations.Extractor) (any, error) {
 	plugins := make(adctypes.Plugins)
+	var errs []error
 	for _, handler := range handlers {
 		out, err := handler.Handle(e)
 		if err != nil {
-			log.Error(err, "Failed to handle annotation", "handler", handler.PluginName())
+			errs = append(errs, fmt.Errorf("plugin %s: %w", handler.PluginName(), err))
 			continue
 		}
 		if out != nil {
@@ -68,7 +75,7 @@ func (p *plugins) Parse(e annotations.Extractor) (any, error) {
 		}
 	}
 	if len(plugins) > 0 {
-		return plugins, nil
+		return plugins, errors.Join(errs...)
 	}
-	return nil, nil
+	return nil, errors.Join(errs...)
 }
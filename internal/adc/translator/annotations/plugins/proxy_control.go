@@ -0,0 +1,55 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugins
+
+import (
+	"fmt"
+	"strconv"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations"
+)
+
+type proxyControl struct{}
+
+// NewProxyControlHandler creates a handler to convert annotations about
+// request buffering to APISIX proxy-control plugin.
+func NewProxyControlHandler() PluginAnnotationsHandler {
+	return &proxyControl{}
+}
+
+func (p *proxyControl) PluginName() string {
+	return "proxy-control"
+}
+
+func (p *proxyControl) Handle(e annotations.Extractor) (any, error) {
+	value := e.GetStringAnnotation(annotations.AnnotationsRequestBuffering)
+	if value == "" {
+		return nil, nil
+	}
+
+	buffering, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, &annotations.StrictError{
+			Annotation: annotations.AnnotationsRequestBuffering,
+			Err:        fmt.Errorf("invalid boolean %q", value),
+		}
+	}
+
+	return &adctypes.ProxyControlConfig{
+		RequestBuffering: buffering,
+	}, nil
+}
@@ -0,0 +1,64 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timeout
+
+import (
+	"errors"
+
+	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations"
+)
+
+func NewParser() annotations.IngressAnnotationsParser {
+	return &Timeout{}
+}
+
+// Timeout holds the route level timeouts in seconds, a zero value means
+// the timeout is not set.
+type Timeout struct {
+	Connect int
+	Read    int
+	Send    int
+}
+
+// Parse parses each timeout on its own: an invalid one is reported, and leaves the
+// others set.
+func (t Timeout) Parse(e annotations.Extractor) (any, error) {
+	var errs []error
+	for _, item := range []struct {
+		name string
+		dst  *int
+	}{
+		{annotations.AnnotationsTimeoutConnect, &t.Connect},
+		{annotations.AnnotationsTimeoutRead, &t.Read},
+		{annotations.AnnotationsTimeoutSend, &t.Send},
+	} {
+		value := e.GetStringAnnotation(item.name)
+		if value == "" {
+			continue
+		}
+		seconds, err := annotations.ParseDurationSeconds(value)
+		if err != nil {
+			errs = append(errs, &annotations.StrictError{Annotation: item.name, Err: err})
+			continue
+		}
+		*item.dst = seconds
+	}
+
+	if t == (Timeout{}) {
+		return nil, errors.Join(errs...)
+	}
+	return t, errors.Join(errs...)
+}
@@ -0,0 +1,52 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timeout
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations"
+)
+
+func TestTimeoutParsing(t *testing.T) {
+	anno := map[string]string{
+		annotations.AnnotationsTimeoutConnect: "5s",
+		annotations.AnnotationsTimeoutRead:    "2m",
+		annotations.AnnotationsTimeoutSend:    "30",
+	}
+	p := NewParser()
+	out, err := p.Parse(annotations.NewExtractor(anno))
+	assert.Nil(t, err, "checking given error")
+
+	timeout, ok := out.(Timeout)
+	if !ok {
+		t.Fatalf("could not parse timeout")
+	}
+	assert.Equal(t, 5, timeout.Connect)
+	assert.Equal(t, 120, timeout.Read)
+	assert.Equal(t, 30, timeout.Send)
+
+	anno[annotations.AnnotationsTimeoutRead] = "500ms"
+	out, err = p.Parse(annotations.NewExtractor(anno))
+	assert.NotNil(t, err, "checking given error")
+	assert.Equal(t, Timeout{Connect: 5, Send: 30}, out, "the valid timeouts are kept")
+
+	out, err = p.Parse(annotations.NewExtractor(map[string]string{}))
+	assert.Nil(t, err, "checking given error")
+	assert.Nil(t, out, "checking given output")
+}
@@ -28,12 +28,18 @@ const (
 	AnnotationsEnableWebSocket  = AnnotationsPrefix + "enable-websocket"
 	AnnotationsPluginConfigName = AnnotationsPrefix + "plugin-config-name"
 	AnnotationsUpstreamScheme   = AnnotationsPrefix + "upstream-scheme"
+	AnnotationsBackendProtocol  = AnnotationsPrefix + "backend-protocol"
 
 	// Support retries and timeouts on upstream
 	AnnotationsUpstreamRetry          = AnnotationsPrefix + "upstream-retries"
 	AnnotationsUpstreamTimeoutConnect = AnnotationsPrefix + "upstream-connect-timeout"
 	AnnotationsUpstreamTimeoutRead    = AnnotationsPrefix + "upstream-read-timeout"
 	AnnotationsUpstreamTimeoutSend    = AnnotationsPrefix + "upstream-send-timeout"
+
+	// Support timeouts on route, same as the ApisixRoute timeout field
+	AnnotationsTimeoutConnect = AnnotationsPrefix + "timeout-connect"
+	AnnotationsTimeoutRead    = AnnotationsPrefix + "timeout-read"
+	AnnotationsTimeoutSend    = AnnotationsPrefix + "timeout-send"
 )
 
 const (
@@ -83,16 +89,50 @@ const (
 	AnnotationsHttpAllowMethods = AnnotationsPrefix + "http-allow-methods"
 	AnnotationsHttpBlockMethods = AnnotationsPrefix + "http-block-methods"
 
-	// key-auth plugin and basic-auth plugin
-	// auth-type: keyAuth | basicAuth
+	// key-auth, basic-auth, jwt-auth, hmac-auth and openid-connect plugins
+	// auth-type: keyAuth | basicAuth | jwtAuth | hmacAuth | openidConnect
 	AnnotationsAuthType = AnnotationsPrefix + "auth-type"
 
+	// jwt-auth plugin
+	AnnotationsJwtAuthHeader = AnnotationsPrefix + "jwt-auth-header"
+	AnnotationsJwtAuthQuery  = AnnotationsPrefix + "jwt-auth-query"
+	AnnotationsJwtAuthCookie = AnnotationsPrefix + "jwt-auth-cookie"
+
+	// openid-connect plugin, the client secret is read from the
+	// oidc-client-secret-key (default "client_secret") of the Secret named
+	// by oidc-client-secret-ref in the Ingress namespace.
+	AnnotationsOIDCDiscovery       = AnnotationsPrefix + "oidc-discovery"
+	AnnotationsOIDCClientID        = AnnotationsPrefix + "oidc-client-id"
+	AnnotationsOIDCClientSecretRef = AnnotationsPrefix + "oidc-client-secret-ref"
+	AnnotationsOIDCClientSecretKey = AnnotationsPrefix + "oidc-client-secret-key"
+	AnnotationsOIDCScope           = AnnotationsPrefix + "oidc-scope"
+	AnnotationsOIDCBearerOnly      = AnnotationsPrefix + "oidc-bearer-only"
+
+	// client-control plugin
+	AnnotationsClientMaxBodySize = AnnotationsPrefix + "client-max-body-size"
+
+	// proxy-control plugin
+	AnnotationsRequestBuffering = AnnotationsPrefix + "request-buffering"
+
 	// support backend service cross namespace
 	AnnotationsSvcNamespace = AnnotationsPrefix + "svc-namespace"
 )
 
 const (
 	FalseString = "false"
+
+	// DefaultOIDCClientSecretKey is the Secret data key holding the OpenID
+	// Connect client secret when oidc-client-secret-key is not set.
+	DefaultOIDCClientSecretKey = "client_secret"
+)
+
+// Supported values of the auth-type annotation.
+const (
+	AuthTypeKeyAuth       = "keyAuth"
+	AuthTypeBasicAuth     = "basicAuth"
+	AuthTypeJwtAuth       = "jwtAuth"
+	AuthTypeHMACAuth      = "hmacAuth"
+	AuthTypeOpenIDConnect = "openidConnect"
 )
 
 // Handler abstracts the behavior so that the apisix-ingress-controller knows
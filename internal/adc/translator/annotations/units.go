@@ -0,0 +1,104 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package annotations
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseSize parses a size value in the nginx style, i.e. an integer optionally
+// followed by one of the k, m or g units (case-insensitive), and returns the
+// size in bytes.
+func ParseSize(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("empty size")
+	}
+
+	number := value
+	multiplier := int64(1)
+	switch value[len(value)-1] {
+	case 'k', 'K':
+		multiplier = 1 << 10
+	case 'm', 'M':
+		multiplier = 1 << 20
+	case 'g', 'G':
+		multiplier = 1 << 30
+	}
+	if multiplier > 1 {
+		number = value[:len(value)-1]
+	}
+
+	size, err := strconv.ParseInt(number, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q: must be an integer optionally followed by k, m or g", value)
+	}
+	if size < 0 {
+		return 0, fmt.Errorf("invalid size %q: must not be negative", value)
+	}
+	if size > (1<<63-1)/multiplier {
+		return 0, fmt.Errorf("invalid size %q: out of range", value)
+	}
+	return size * multiplier, nil
+}
+
+// ParseDurationSeconds parses a duration such as "30s" or "1m30s" and returns
+// it in whole seconds. A bare integer is treated as seconds. Durations shorter
+// than one second or not a multiple of a second are rejected since APISIX only
+// accepts second precision.
+func ParseDurationSeconds(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		value += "s"
+		if seconds < 0 {
+			return 0, fmt.Errorf("invalid duration %q: must be at least 1s", value)
+		}
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", value, err)
+	}
+	if d < time.Second {
+		return 0, fmt.Errorf("invalid duration %q: must be at least 1s", value)
+	}
+	if d%time.Second != 0 {
+		return 0, fmt.Errorf("invalid duration %q: must be a whole number of seconds", value)
+	}
+	return int(d / time.Second), nil
+}
+
+// StrictError is the error of an annotation whose value is validated strictly, such as
+// a size or a duration: an Ingress with an invalid value is not programmed, rather
+// than programmed without what it asks for.
+type StrictError struct {
+	Annotation string
+	Err        error
+}
+
+func (e *StrictError) Error() string {
+	return fmt.Sprintf("annotation %q: %v", e.Annotation, e.Err)
+}
+
+func (e *StrictError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,85 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package annotations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSize(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected int64
+		err      bool
+	}{
+		{value: "0", expected: 0},
+		{value: "1024", expected: 1024},
+		{value: "8k", expected: 8 << 10},
+		{value: "8K", expected: 8 << 10},
+		{value: "10m", expected: 10 << 20},
+		{value: "1G", expected: 1 << 30},
+		{value: " 2m ", expected: 2 << 20},
+		{value: "", err: true},
+		{value: "m", err: true},
+		{value: "1.5m", err: true},
+		{value: "10mb", err: true},
+		{value: "-1k", err: true},
+		{value: "99999999999999999g", err: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			size, err := ParseSize(tc.value)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, size)
+		})
+	}
+}
+
+func TestParseDurationSeconds(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected int
+		err      bool
+	}{
+		{value: "30", expected: 30},
+		{value: "30s", expected: 30},
+		{value: "2m", expected: 120},
+		{value: "1m30s", expected: 90},
+		{value: "", err: true},
+		{value: "0", err: true},
+		{value: "-5", err: true},
+		{value: "500ms", err: true},
+		{value: "1500ms", err: true},
+		{value: "ten", err: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			seconds, err := ParseDurationSeconds(tc.value)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, seconds)
+		})
+	}
+}
@@ -43,6 +43,33 @@ var validSchemes = map[string]struct{}{
 	apiv2.SchemeGRPCS: {},
 }
 
+// backendProtocolSchemes maps the values of the backend-protocol annotation to
+// upstream schemes.
+var backendProtocolSchemes = map[string]string{
+	"HTTP":  apiv2.SchemeHTTP,
+	"HTTPS": apiv2.SchemeHTTPS,
+	"GRPC":  apiv2.SchemeGRPC,
+	"GRPCS": apiv2.SchemeGRPCS,
+}
+
+// ParseBackendProtocol returns the upstream scheme of a backend-protocol
+// annotation value, case-insensitive. H2C is refused rather than proxied as
+// HTTP/1.1: APISIX only speaks HTTP/2 over cleartext to upstreams through the
+// grpc scheme, which would turn every request into a gRPC one.
+func ParseBackendProtocol(protocol string) (string, error) {
+	if strings.EqualFold(protocol, "H2C") {
+		return "", fmt.Errorf("backend protocol %s is not supported: APISIX only proxies HTTP/2 over cleartext to gRPC upstreams, use GRPC for those", protocol)
+	}
+	scheme, ok := backendProtocolSchemes[strings.ToUpper(protocol)]
+	if !ok {
+		return "", fmt.Errorf("invalid backend protocol: %s", protocol)
+	}
+	return scheme, nil
+}
+
+// Parse parses the upstream annotations. The backend-protocol annotation takes
+// precedence over upstream-scheme when both are set. An invalid backend-protocol
+// is reported with the rest of the upstream, which it does not hold back.
 func (u Upstream) Parse(e annotations.Extractor) (any, error) {
 	if scheme := strings.ToLower(e.GetStringAnnotation(annotations.AnnotationsUpstreamScheme)); scheme != "" {
 		if _, ok := validSchemes[scheme]; ok {
@@ -52,6 +79,16 @@ func (u Upstream) Parse(e annotations.Extractor) (any, error) {
 		}
 	}
 
+	var protocolErr error
+	if protocol := e.GetStringAnnotation(annotations.AnnotationsBackendProtocol); protocol != "" {
+		scheme, err := ParseBackendProtocol(protocol)
+		if err != nil {
+			protocolErr = fmt.Errorf("annotation %q: %w", annotations.AnnotationsBackendProtocol, err)
+		} else {
+			u.Scheme = scheme
+		}
+	}
+
 	if retry := e.GetStringAnnotation(annotations.AnnotationsUpstreamRetry); retry != "" {
 		t, err := strconv.Atoi(retry)
 		if err != nil {
@@ -84,5 +121,5 @@ func (u Upstream) Parse(e annotations.Extractor) (any, error) {
 		u.TimeoutSend = t
 	}
 
-	return u, nil
+	return u, protocolErr
 }
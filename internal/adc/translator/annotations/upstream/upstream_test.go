@@ -96,3 +96,43 @@ func TestTimeoutParsing(t *testing.T) {
 	assert.NotNil(t, err, "checking given error")
 	assert.Nil(t, out, "checking given output")
 }
+
+func TestBackendProtocolParsing(t *testing.T) {
+	u := NewParser()
+	for protocol, scheme := range map[string]string{
+		"HTTP":  "http",
+		"https": "https",
+		"GRPC":  "grpc",
+		"grpcs": "grpcs",
+	} {
+		out, err := u.Parse(annotations.NewExtractor(map[string]string{
+			annotations.AnnotationsBackendProtocol: protocol,
+		}))
+		assert.Nil(t, err, "checking given error")
+		assert.Equal(t, scheme, out.(Upstream).Scheme, protocol)
+	}
+
+	// H2C would be proxied as HTTP/1.1
+	_, err := u.Parse(annotations.NewExtractor(map[string]string{
+		annotations.AnnotationsBackendProtocol: "h2c",
+	}))
+	assert.ErrorContains(t, err, "backend protocol h2c is not supported")
+
+	// backend-protocol takes precedence over upstream-scheme
+	out, err := u.Parse(annotations.NewExtractor(map[string]string{
+		annotations.AnnotationsUpstreamScheme:  "https",
+		annotations.AnnotationsBackendProtocol: "GRPCS",
+	}))
+	assert.Nil(t, err, "checking given error")
+	assert.Equal(t, "grpcs", out.(Upstream).Scheme)
+
+	// an invalid backend-protocol leaves the rest of the upstream set
+	out, err = u.Parse(annotations.NewExtractor(map[string]string{
+		annotations.AnnotationsBackendProtocol:     "FCGI",
+		annotations.AnnotationsUpstreamScheme:      "https",
+		annotations.AnnotationsUpstreamRetry:       "3",
+		annotations.AnnotationsUpstreamTimeoutRead: "5s",
+	}))
+	assert.NotNil(t, err, "checking given error")
+	assert.Equal(t, Upstream{Scheme: "https", Retries: 3, TimeoutRead: 5}, out)
+}
@@ -21,9 +21,12 @@ import (
 
 	"github.com/incubator4/go-resty-expr/expr"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
 	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations"
+	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations/oidc"
+	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations/timeout"
 	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations/upstream"
 	"github.com/apache/apisix-ingress-controller/internal/controller/config"
 )
@@ -90,9 +93,10 @@ func TestTranslateAnnotations(t *testing.T) {
 
 func TestTranslateIngressAnnotations(t *testing.T) {
 	tests := []struct {
-		name     string
-		anno     map[string]string
-		expected *IngressConfig
+		name      string
+		anno      map[string]string
+		expected  *IngressConfig
+		expectErr bool
 	}{
 		{
 			name:     "no matching annotations",
@@ -280,6 +284,54 @@ func TestTranslateIngressAnnotations(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "auth type jwtAuth",
+			anno: map[string]string{
+				annotations.AnnotationsAuthType:      "jwtAuth",
+				annotations.AnnotationsJwtAuthHeader: "X-JWT",
+			},
+			expected: &IngressConfig{
+				Plugins: adctypes.Plugins{
+					"jwt-auth": &adctypes.JwtAuthRouteConfig{Header: "X-JWT"},
+				},
+			},
+		},
+		{
+			name: "auth type hmacAuth",
+			anno: map[string]string{
+				annotations.AnnotationsAuthType: "hmacAuth",
+			},
+			expected: &IngressConfig{
+				Plugins: adctypes.Plugins{
+					"hmac-auth": &adctypes.HMACAuthRouteConfig{},
+				},
+			},
+		},
+		{
+			name: "auth type openidConnect",
+			anno: map[string]string{
+				annotations.AnnotationsAuthType:            "openidConnect",
+				annotations.AnnotationsOIDCDiscovery:       "https://idp.example.com/.well-known/openid-configuration",
+				annotations.AnnotationsOIDCClientID:        "dashboard",
+				annotations.AnnotationsOIDCClientSecretRef: "oidc-secret",
+				annotations.AnnotationsOIDCScope:           "openid,profile",
+				annotations.AnnotationsOIDCBearerOnly:      "true",
+			},
+			expected: &IngressConfig{
+				Plugins: adctypes.Plugins{
+					"openid-connect": &adctypes.OpenIDConnectConfig{
+						Discovery:  "https://idp.example.com/.well-known/openid-configuration",
+						ClientID:   "dashboard",
+						Scope:      "openid profile",
+						BearerOnly: true,
+					},
+				},
+				OIDCClientSecret: oidc.ClientSecretRef{
+					Name: "oidc-secret",
+					Key:  "client_secret",
+				},
+			},
+		},
 		{
 			name: "service namespace",
 			anno: map[string]string{
@@ -339,12 +391,80 @@ func TestTranslateIngressAnnotations(t *testing.T) {
 				UseRegex: true,
 			},
 		},
+		{
+			name: "client max body size and request buffering",
+			anno: map[string]string{
+				annotations.AnnotationsClientMaxBodySize: "8m",
+				annotations.AnnotationsRequestBuffering:  "false",
+			},
+			expected: &IngressConfig{
+				Plugins: adctypes.Plugins{
+					"client-control": &adctypes.ClientControlConfig{
+						MaxBodySize: 8 * 1024 * 1024,
+					},
+					"proxy-control": &adctypes.ProxyControlConfig{
+						RequestBuffering: false,
+					},
+				},
+			},
+		},
+		{
+			name: "invalid client max body size",
+			anno: map[string]string{
+				annotations.AnnotationsClientMaxBodySize: "8mb",
+			},
+			expected:  &IngressConfig{},
+			expectErr: true,
+		},
+		{
+			name: "route timeouts",
+			anno: map[string]string{
+				annotations.AnnotationsTimeoutConnect: "5s",
+				annotations.AnnotationsTimeoutRead:    "1m",
+			},
+			expected: &IngressConfig{
+				Timeout: timeout.Timeout{
+					Connect: 5,
+					Read:    60,
+				},
+			},
+		},
+		{
+			name: "invalid route timeout",
+			anno: map[string]string{
+				annotations.AnnotationsTimeoutSend:    "100ms",
+				annotations.AnnotationsTimeoutConnect: "5s",
+			},
+			expected: &IngressConfig{
+				Timeout: timeout.Timeout{Connect: 5},
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid plugin annotation next to a body size",
+			anno: map[string]string{
+				annotations.AnnotationsEnableCsrf:        "true",
+				annotations.AnnotationsClientMaxBodySize: "1k",
+			},
+			expected: &IngressConfig{
+				Plugins: adctypes.Plugins{
+					"client-control": &adctypes.ClientControlConfig{
+						MaxBodySize: 1024,
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			translator := &Translator{ListenerPortMatchMode: config.ListenerPortMatchModeAuto}
-			result := translator.TranslateIngressAnnotations(tt.anno)
+			result, err := translator.TranslateIngressAnnotations(tt.anno)
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
 
 			assert.NotNil(t, result)
 			assert.Equal(t, tt.expected, result)
@@ -352,6 +472,23 @@ func TestTranslateIngressAnnotations(t *testing.T) {
 	}
 }
 
+func TestInvalidIngressAnnotations(t *testing.T) {
+	errs := InvalidIngressAnnotations(map[string]string{
+		annotations.AnnotationsUpstreamScheme:    "invalid",
+		annotations.AnnotationsEnableCsrf:        "true",
+		annotations.AnnotationsClientMaxBodySize: "8mb",
+	})
+	require.Len(t, errs, 2)
+	for _, err := range errs {
+		assert.NotContains(t, err.Error(), annotations.AnnotationsClientMaxBodySize,
+			"an annotation the translation fails for is not ignored")
+	}
+
+	assert.Empty(t, InvalidIngressAnnotations(map[string]string{
+		annotations.AnnotationsUpstreamScheme: "https",
+	}))
+}
+
 func TestAddServerPortVars(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -78,6 +78,11 @@ func (t *Translator) translateHTTPRule(tctx *provider.TranslateContext, ar *apiv
 	service := t.buildService(ar, rule, ruleIndex)
 	t.buildUpstream(tctx, service, ar, rule, ruleIndex, &enableWebsocket)
 	t.buildRoute(ar, service, rule, plugins, timeout, vars, &enableWebsocket)
+	if pc := rulePluginConfig(tctx, ar, rule); pc != nil {
+		for _, route := range service.Routes {
+			route.Labels = label.WithPolicy(route.Labels, internaltypes.KindApisixPluginConfig, pc.Namespace, pc.Name)
+		}
+	}
 	return service, nil
 }
 
@@ -113,18 +118,8 @@ func (t *Translator) buildPlugins(tctx *provider.TranslateContext, ar *apiv2.Api
 }
 
 func (t *Translator) loadPluginConfigPlugins(tctx *provider.TranslateContext, ar *apiv2.ApisixRoute, rule apiv2.ApisixRouteHTTP, plugins adc.Plugins) error {
-	if rule.PluginConfigName == "" {
-		return nil
-	}
-
-	pcNamespace := ar.Namespace
-	if rule.PluginConfigNamespace != "" {
-		pcNamespace = rule.PluginConfigNamespace
-	}
-
-	pcKey := types.NamespacedName{Namespace: pcNamespace, Name: rule.PluginConfigName}
-	pc, ok := tctx.ApisixPluginConfigs[pcKey]
-	if !ok || pc == nil {
+	pc := rulePluginConfig(tctx, ar, rule)
+	if pc == nil {
 		return nil
 	}
 
@@ -141,6 +136,22 @@ func (t *Translator) loadPluginConfigPlugins(tctx *provider.TranslateContext, ar
 	return nil
 }
 
+// rulePluginConfig returns the ApisixPluginConfig rule refers to, or nil if it refers to
+// none.
+func rulePluginConfig(tctx *provider.TranslateContext, ar *apiv2.ApisixRoute, rule apiv2.ApisixRouteHTTP) *apiv2.ApisixPluginConfig {
+	if rule.PluginConfigName == "" {
+		return nil
+	}
+
+	pcNamespace := ar.Namespace
+	if rule.PluginConfigNamespace != "" {
+		pcNamespace = rule.PluginConfigNamespace
+	}
+
+	pcKey := types.NamespacedName{Namespace: pcNamespace, Name: rule.PluginConfigName}
+	return tctx.ApisixPluginConfigs[pcKey]
+}
+
 func (t *Translator) loadRoutePlugins(tctx *provider.TranslateContext, ar *apiv2.ApisixRoute, routePlugins []apiv2.ApisixRoutePlugin, plugins adc.Plugins) error {
 	for _, plugin := range routePlugins {
 		if !plugin.Enable {
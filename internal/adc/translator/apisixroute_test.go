@@ -34,6 +34,7 @@ import (
 	adc "github.com/apache/apisix-ingress-controller/api/adc"
 	apiv2 "github.com/apache/apisix-ingress-controller/api/v2"
 	"github.com/apache/apisix-ingress-controller/internal/provider"
+	internaltypes "github.com/apache/apisix-ingress-controller/internal/types"
 )
 
 func TestBuildRoute_HostsNotSet(t *testing.T) {
@@ -252,3 +253,87 @@ func TestTranslateApisixRouteStreamUpstreamScheme(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslateApisixRouteHTTPAppProtocolScheme(t *testing.T) {
+	const (
+		namespace   = "default"
+		serviceName = "backend"
+		portName    = "web"
+		portNumber  = int32(8080)
+	)
+
+	tests := []struct {
+		appProtocol string
+		wantScheme  string
+	}{
+		{appProtocol: internaltypes.AppProtocolWSS, wantScheme: apiv2.SchemeHTTPS},
+		// Only an Ingress resolves grpc, see ingressAppProtocolToUpstreamScheme.
+		{appProtocol: internaltypes.AppProtocolGRPC, wantScheme: ""},
+		{appProtocol: internaltypes.AppProtocolH2C, wantScheme: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.appProtocol, func(t *testing.T) {
+			translator := NewTranslator(logr.Discard(), "")
+			tctx := provider.NewDefaultTranslateContext(context.Background())
+
+			serviceKey := k8stypes.NamespacedName{Namespace: namespace, Name: serviceName}
+			tctx.Services[serviceKey] = &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      serviceName,
+					Namespace: namespace,
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{
+						Name:        portName,
+						Port:        portNumber,
+						AppProtocol: ptr.To(tt.appProtocol),
+					}},
+				},
+			}
+			tctx.EndpointSlices[serviceKey] = []discoveryv1.EndpointSlice{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      serviceName + "-1",
+					Namespace: namespace,
+				},
+				Ports: []discoveryv1.EndpointPort{{
+					Name:        ptr.To(portName),
+					Port:        ptr.To(portNumber),
+					AppProtocol: ptr.To(tt.appProtocol),
+				}},
+				Endpoints: []discoveryv1.Endpoint{{
+					Addresses: []string{"10.0.0.1"},
+					Conditions: discoveryv1.EndpointConditions{
+						Ready: ptr.To(true),
+					},
+				}},
+			}}
+
+			ar := &apiv2.ApisixRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-route",
+					Namespace: namespace,
+				},
+				Spec: apiv2.ApisixRouteSpec{
+					HTTP: []apiv2.ApisixRouteHTTP{{
+						Name: "rule1",
+						Match: apiv2.ApisixRouteHTTPMatch{
+							Paths: []string{"/*"},
+						},
+						Backends: []apiv2.ApisixRouteHTTPBackend{{
+							ServiceName: serviceName,
+							ServicePort: intstr.FromInt32(portNumber),
+						}},
+					}},
+				},
+			}
+
+			result, err := translator.TranslateApisixRoute(tctx, ar)
+			require.NoError(t, err)
+			require.Len(t, result.Services, 1)
+			require.NotNil(t, result.Services[0].Upstream)
+
+			assert.Equal(t, tt.wantScheme, result.Services[0].Upstream.Scheme)
+		})
+	}
+}
@@ -18,6 +18,7 @@
 package translator
 
 import (
+	"cmp"
 	"fmt"
 	"net"
 	"strconv"
@@ -36,7 +37,11 @@ import (
 	"github.com/apache/apisix-ingress-controller/internal/utils"
 )
 
-func (t *Translator) TranslateGatewayProxyToConfig(tctx *provider.TranslateContext, gatewayProxy *v1alpha1.GatewayProxy, resolveEndpoints bool) (*types.Config, error) {
+// TranslateGatewayProxyToConfig translates the control plane of gatewayProxy to the config
+// its resources are synced with. A control plane without a mode of its own runs in
+// defaultMode, which decides, like the mode of one that has, whether the endpoints of its
+// Service are resolved: a standalone data plane is pushed to each instance.
+func (t *Translator) TranslateGatewayProxyToConfig(tctx *provider.TranslateContext, gatewayProxy *v1alpha1.GatewayProxy, defaultMode string) (*types.Config, error) {
 	if gatewayProxy == nil || gatewayProxy.Spec.Provider == nil {
 		return nil, nil
 	}
@@ -50,6 +55,7 @@ func (t *Translator) TranslateGatewayProxyToConfig(tctx *provider.TranslateConte
 	cfg := types.Config{
 		Name:        utils.NamespacedNameKind(gatewayProxy).String(),
 		BackendType: cp.Mode,
+		Executor:    cp.Executor,
 	}
 
 	if cp.TlsVerify != nil {
@@ -84,11 +90,7 @@ func (t *Translator) TranslateGatewayProxyToConfig(tctx *provider.TranslateConte
 		return &cfg, nil
 	}
 
-	// If Mode is empty, use the default static configuration.
-	// If Mode is set, resolve endpoints only when the ControlPlane is in standalone mode.
-	if cp.Mode != "" {
-		resolveEndpoints = cp.Mode == string(config.ProviderTypeStandalone)
-	}
+	resolveEndpoints := cmp.Or(cp.Mode, defaultMode) == string(config.ProviderTypeStandalone)
 
 	if cp.Service != nil {
 		namespacedName := k8stypes.NamespacedName{
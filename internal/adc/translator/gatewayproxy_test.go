@@ -0,0 +1,126 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package translator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	"github.com/apache/apisix-ingress-controller/internal/controller/config"
+	"github.com/apache/apisix-ingress-controller/internal/provider"
+)
+
+func TestTranslateGatewayProxyResolvesEndpointsInStandaloneMode(t *testing.T) {
+	const (
+		namespace   = "default"
+		serviceName = "apisix-admin"
+		portName    = "admin"
+		portNumber  = int32(9180)
+	)
+
+	tctx := provider.NewDefaultTranslateContext(context.Background())
+	serviceKey := k8stypes.NamespacedName{Namespace: namespace, Name: serviceName}
+	tctx.Services[serviceKey] = &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceName,
+			Namespace: namespace,
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{
+				Name: portName,
+				Port: portNumber,
+			}},
+		},
+	}
+	tctx.EndpointSlices[serviceKey] = []discoveryv1.EndpointSlice{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceName + "-1",
+			Namespace: namespace,
+		},
+		Ports: []discoveryv1.EndpointPort{{
+			Name: ptr.To(portName),
+			Port: ptr.To(portNumber),
+		}},
+		Endpoints: []discoveryv1.Endpoint{{
+			Addresses: []string{"10.0.0.1"},
+			Conditions: discoveryv1.EndpointConditions{
+				Ready: ptr.To(true),
+			},
+		}},
+	}}
+
+	var (
+		standalone = string(config.ProviderTypeStandalone)
+		apisix     = string(config.ProviderTypeAPISIX)
+		serviceURL = "http://apisix-admin.default.svc:9180"
+		podURL     = "http://10.0.0.1:9180"
+	)
+	tests := []struct {
+		name        string
+		mode        string
+		defaultMode string
+		want        string
+	}{
+		{name: "standalone by default", defaultMode: standalone, want: podURL},
+		{name: "apisix by default", defaultMode: apisix, want: serviceURL},
+		{name: "standalone over an apisix default", mode: standalone, defaultMode: apisix, want: podURL},
+		{name: "apisix over a standalone default", mode: apisix, defaultMode: standalone, want: serviceURL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gatewayProxy := &v1alpha1.GatewayProxy{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "gp",
+				},
+				Spec: v1alpha1.GatewayProxySpec{
+					Provider: &v1alpha1.GatewayProxyProvider{
+						Type: v1alpha1.ProviderTypeControlPlane,
+						ControlPlane: &v1alpha1.ControlPlaneProvider{
+							Mode: tt.mode,
+							Service: &v1alpha1.ProviderService{
+								Name: serviceName,
+								Port: portNumber,
+							},
+							Auth: v1alpha1.ControlPlaneAuth{
+								Type:     v1alpha1.AuthTypeAdminKey,
+								AdminKey: &v1alpha1.AdminKeyAuth{Value: "key"},
+							},
+						},
+					},
+				},
+			}
+
+			cfg, err := NewTranslator(logr.Discard(), "").TranslateGatewayProxyToConfig(tctx, gatewayProxy, tt.defaultMode)
+			require.NoError(t, err)
+			require.NotNil(t, cfg)
+			assert.Equal(t, []string{tt.want}, cfg.ServerAddrs)
+			assert.Equal(t, tt.mode, cfg.BackendType)
+		})
+	}
+}
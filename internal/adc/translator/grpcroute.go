@@ -196,7 +196,7 @@ func (t *Translator) TranslateGRPCRoute(tctx *provider.TranslateContext, grpcRou
 				continue
 			}
 
-			t.AttachBackendTrafficPolicyToUpstream(backend.BackendRef, tctx.BackendTrafficPolicies, upstream, tctx.Services)
+			withBackendTrafficPolicy(service, t.AttachBackendTrafficPolicyToUpstream(backend.BackendRef, tctx.BackendTrafficPolicies, upstream, tctx.Services))
 			upstream.Nodes = upNodes
 
 			var (
@@ -288,6 +288,14 @@ func (t *Translator) TranslateGRPCRoute(tctx *provider.TranslateContext, grpcRou
 		}
 
 		t.fillPluginsFromGRPCRouteFilters(service.Plugins, grpcRoute.GetNamespace(), rule.Filters, tctx)
+		for _, filter := range rule.Filters {
+			if filter.Type != gatewayv1.GRPCRouteFilterExtensionRef {
+				continue
+			}
+			if pc := extensionRefPluginConfig(tctx, grpcRoute.Namespace, filter.ExtensionRef); pc != nil {
+				service.Labels = label.WithPolicy(service.Labels, internaltypes.KindPluginConfig, pc.Namespace, pc.Name)
+			}
+		}
 
 		matches := rule.Matches
 		if len(matches) == 0 {
@@ -68,30 +68,34 @@ func (t *Translator) fillPluginsFromHTTPRouteFilters(
 }
 
 func (t *Translator) fillPluginFromExtensionRef(plugins adctypes.Plugins, namespace string, extensionRef *gatewayv1.LocalObjectReference, tctx *provider.TranslateContext) {
-	if extensionRef == nil {
+	pluginconfig := extensionRefPluginConfig(tctx, namespace, extensionRef)
+	if pluginconfig == nil {
 		return
 	}
-	if extensionRef.Kind == internaltypes.KindPluginConfig {
-		pluginconfig := tctx.PluginConfigs[types.NamespacedName{
-			Namespace: namespace,
-			Name:      string(extensionRef.Name),
-		}]
-		if pluginconfig == nil {
-			return
-		}
-		for _, plugin := range pluginconfig.Spec.Plugins {
-			pluginName := plugin.Name
-			pluginconfig := make(map[string]any)
-			if len(plugin.Config.Raw) > 0 {
-				if err := json.Unmarshal(plugin.Config.Raw, &pluginconfig); err != nil {
-					t.Log.Error(err, "plugin config unmarshal failed", "plugin", plugin.Name)
-					continue
-				}
+	for _, plugin := range pluginconfig.Spec.Plugins {
+		pluginName := plugin.Name
+		pluginconfig := make(map[string]any)
+		if len(plugin.Config.Raw) > 0 {
+			if err := json.Unmarshal(plugin.Config.Raw, &pluginconfig); err != nil {
+				t.Log.Error(err, "plugin config unmarshal failed", "plugin", plugin.Name)
+				continue
 			}
-			plugins[pluginName] = pluginconfig
 		}
-		t.Log.V(1).Info("fill plugin from extension ref", "plugins", plugins)
+		plugins[pluginName] = pluginconfig
+	}
+	t.Log.V(1).Info("fill plugin from extension ref", "plugins", plugins)
+}
+
+// extensionRefPluginConfig returns the PluginConfig an extensionRef filter in namespace
+// refers to, or nil if it refers to none.
+func extensionRefPluginConfig(tctx *provider.TranslateContext, namespace string, extensionRef *gatewayv1.LocalObjectReference) *v1alpha1.PluginConfig {
+	if extensionRef == nil || extensionRef.Kind != internaltypes.KindPluginConfig {
+		return nil
 	}
+	return tctx.PluginConfigs[types.NamespacedName{
+		Namespace: namespace,
+		Name:      string(extensionRef.Name),
+	}]
 }
 
 func (t *Translator) fillPluginFromURLRewriteFilter(plugins adctypes.Plugins, urlRewrite *gatewayv1.HTTPURLRewriteFilter, matches []gatewayv1.HTTPRouteMatch) {
@@ -341,6 +345,7 @@ func (t *Translator) fillHTTPRoutePoliciesForIngress(tctx *provider.TranslateCon
 func (t *Translator) fillHTTPRoutePolicies(routes []*adctypes.Route, policies []v1alpha1.HTTPRoutePolicy) {
 	for _, policy := range policies {
 		for _, route := range routes {
+			route.Labels = label.WithPolicy(route.Labels, internaltypes.KindHTTPRoutePolicy, policy.Namespace, policy.Name)
 			route.Priority = policy.Spec.Priority
 			for _, data := range policy.Spec.Vars {
 				var v []adctypes.StringOrSlice
@@ -576,7 +581,7 @@ func (t *Translator) translateBackendsToUpstreams(
 			enableWebsocket = ptr.To(true)
 		}
 
-		t.AttachBackendTrafficPolicyToUpstream(backend.BackendRef, tctx.BackendTrafficPolicies, upstream, tctx.Services)
+		withBackendTrafficPolicy(service, t.AttachBackendTrafficPolicyToUpstream(backend.BackendRef, tctx.BackendTrafficPolicies, upstream, tctx.Services))
 		upstream.Nodes = upNodes
 		if upstream.Scheme == "" {
 			upstream.Scheme = appProtocolToUpstreamScheme(protocol)
@@ -707,6 +712,14 @@ func (t *Translator) TranslateHTTPRoute(tctx *provider.TranslateContext, httpRou
 		enableWebsocket, _ := t.translateBackendsToUpstreams(tctx, rule, httpRoute, service)
 
 		t.fillPluginsFromHTTPRouteFilters(service.Plugins, httpRoute.GetNamespace(), rule.Filters, rule.Matches, tctx)
+		for _, filter := range rule.Filters {
+			if filter.Type != gatewayv1.HTTPRouteFilterExtensionRef {
+				continue
+			}
+			if pc := extensionRefPluginConfig(tctx, httpRoute.Namespace, filter.ExtensionRef); pc != nil {
+				service.Labels = label.WithPolicy(service.Labels, internaltypes.KindPluginConfig, pc.Namespace, pc.Name)
+			}
+		}
 
 		matches := rule.Matches
 		if len(matches) == 0 {
@@ -249,6 +249,17 @@ func TestTranslateHTTPRouteUpstreamScheme(t *testing.T) {
 			appProtocol: internaltypes.AppProtocolWSS,
 			wantScheme:  apiv2.SchemeHTTPS,
 		},
+		{
+			// Only an Ingress resolves grpc, see ingressAppProtocolToUpstreamScheme.
+			name:        "leaves grpc app protocol unresolved",
+			appProtocol: internaltypes.AppProtocolGRPC,
+			wantScheme:  "",
+		},
+		{
+			name:        "leaves h2c app protocol unresolved",
+			appProtocol: internaltypes.AppProtocolH2C,
+			wantScheme:  "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -713,8 +724,14 @@ func TestAttachBackendTrafficPolicyToUpstreamSectionName(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			upstream := adctypes.NewDefaultUpstream()
-			translator.AttachBackendTrafficPolicyToUpstream(tt.ref, tt.policies, upstream, services)
+			policy := translator.AttachBackendTrafficPolicyToUpstream(tt.ref, tt.policies, upstream, services)
 			assert.Equal(t, tt.wantScheme, upstream.Scheme)
+			if tt.wantScheme == "" {
+				assert.Nil(t, policy)
+			} else {
+				require.NotNil(t, policy)
+				assert.Equal(t, tt.wantScheme, policy.Spec.Scheme, "the policy attached is returned")
+			}
 		})
 	}
 }
@@ -20,6 +20,7 @@ package translator
 import (
 	"cmp"
 	"fmt"
+	"math"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -30,12 +31,26 @@ import (
 	"k8s.io/utils/ptr"
 
 	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
 	apiv2 "github.com/apache/apisix-ingress-controller/api/v2"
+	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations"
+	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations/oidc"
+	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations/timeout"
 	"github.com/apache/apisix-ingress-controller/internal/controller/label"
 	"github.com/apache/apisix-ingress-controller/internal/id"
 	"github.com/apache/apisix-ingress-controller/internal/provider"
 	sslutils "github.com/apache/apisix-ingress-controller/internal/ssl"
 	internaltypes "github.com/apache/apisix-ingress-controller/internal/types"
+	"github.com/apache/apisix-ingress-controller/internal/utils"
+)
+
+const (
+	// IngressDefaultBackendRule is the rule index used to name the Service and
+	// Route generated from the defaultBackend of an Ingress.
+	IngressDefaultBackendRule = "default-backend"
+	// IngressDefaultBackendPriority is the priority of the catch-all route of the
+	// defaultBackend, lower than any route generated from Ingress rules.
+	IngressDefaultBackendPriority int64 = math.MinInt32
 )
 
 func (t *Translator) translateIngressTLS(namespace, name string, tlsIndex int, ingressTLS *networkingv1.IngressTLS, secret *corev1.Secret, labels map[string]string) (*adctypes.SSL, error) {
@@ -83,7 +98,10 @@ func (t *Translator) TranslateIngress(
 
 	labels := label.GenLabel(obj)
 
-	config := t.TranslateIngressAnnotations(obj.Annotations)
+	config, err := t.TranslateIngressAnnotations(obj.Annotations)
+	if err != nil {
+		return nil, fmt.Errorf("invalid annotations: %w", err)
+	}
 
 	t.Log.V(1).Info("translating Ingress Annotations", "config", config)
 
@@ -116,9 +134,46 @@ func (t *Translator) TranslateIngress(
 		}
 	}
 
+	// process the default backend, convert to a catch-all Service and Route
+	if obj.Spec.DefaultBackend != nil && !tctx.SkipIngressDefaultBackend {
+		svc, err := t.buildServiceFromIngressDefaultBackend(tctx, obj, config, labels)
+		if err != nil {
+			return nil, err
+		}
+		if svc != nil {
+			result.Services = append(result.Services, svc)
+		}
+	}
+
 	return result, nil
 }
 
+// buildServiceFromIngressDefaultBackend translates the defaultBackend of the
+// Ingress into a route matching any host and path, with the lowest priority so
+// that it only serves requests that no other route matches.
+func (t *Translator) buildServiceFromIngressDefaultBackend(
+	tctx *provider.TranslateContext,
+	obj *networkingv1.Ingress,
+	config *IngressConfig,
+	labels map[string]string,
+) (*adctypes.Service, error) {
+	path := networkingv1.HTTPIngressPath{
+		Path:     "/",
+		PathType: ptr.To(networkingv1.PathTypePrefix),
+		Backend:  *obj.Spec.DefaultBackend,
+	}
+	svc, err := t.buildServiceFromIngressPath(tctx, obj, config, &path, IngressDefaultBackendRule, nil, labels)
+	if err != nil || svc == nil {
+		return svc, err
+	}
+	for _, route := range svc.Routes {
+		if route.Priority == nil {
+			route.Priority = ptr.To(IngressDefaultBackendPriority)
+		}
+	}
+	return svc, nil
+}
+
 func (t *Translator) translateIngressTLSSection(
 	tctx *provider.TranslateContext,
 	obj *networkingv1.Ingress,
@@ -154,7 +209,21 @@ func (t *Translator) buildServiceFromIngressPath(
 	hosts []string,
 	labels map[string]string,
 ) (*adctypes.Service, error) {
-	if path.Backend.Service == nil {
+	var (
+		upstream *adctypes.Upstream
+		protocol string
+		policy   *v1alpha1.BackendTrafficPolicy
+	)
+	switch {
+	case path.Backend.Service != nil:
+		upstream = adctypes.NewDefaultUpstream()
+		protocol, policy = t.resolveIngressUpstream(tctx, obj, config, path.Backend.Service, upstream)
+	case path.Backend.Resource != nil:
+		var err error
+		if upstream, err = t.resolveIngressResourceBackend(tctx, obj, path.Backend.Resource); err != nil || upstream == nil {
+			return nil, err
+		}
+	default:
 		return nil, nil
 	}
 
@@ -163,10 +232,8 @@ func (t *Translator) buildServiceFromIngressPath(
 	service.Name = adctypes.ComposeServiceNameWithRule(obj.Namespace, obj.Name, index)
 	service.ID = id.GenID(service.Name)
 	service.Hosts = hosts
-
-	upstream := adctypes.NewDefaultUpstream()
-	protocol := t.resolveIngressUpstream(tctx, obj, config, path.Backend.Service, upstream)
 	service.Upstream = upstream
+	withBackendTrafficPolicy(service, policy)
 
 	route, err := t.buildRouteFromIngressPath(tctx, obj, path, config, index, labels)
 	if err != nil {
@@ -184,19 +251,41 @@ func (t *Translator) buildServiceFromIngressPath(
 	return service, nil
 }
 
+// ingressAppProtocolToUpstreamScheme is appProtocolToUpstreamScheme, which also serves
+// HTTPRoute and ApisixRoute, with the appProtocol only an Ingress resolves: grpc is
+// proxied as grpc. kubernetes.io/h2c is left unresolved, as APISIX cannot proxy HTTP/2
+// over cleartext but to gRPC upstreams.
+func ingressAppProtocolToUpstreamScheme(appProtocol string) string {
+	switch appProtocol {
+	case internaltypes.AppProtocolGRPC:
+		return apiv2.SchemeGRPC
+	default:
+		return appProtocolToUpstreamScheme(appProtocol)
+	}
+}
+
+// resolveIngressUpstream fills the upstream of an Ingress Service backend and
+// returns the appProtocol of the Service port and the BackendTrafficPolicy applied
+// to it, if any. The upstream scheme is resolved
+// with the following precedence, the first one set wins:
+//  1. the backend-protocol annotation;
+//  2. the upstream-scheme annotation;
+//  3. the scheme of the BackendTrafficPolicy targeting the Service;
+//  4. the appProtocol of the Service port, see ingressAppProtocolToUpstreamScheme;
+//  5. unset, which APISIX treats as http.
 func (t *Translator) resolveIngressUpstream(
 	tctx *provider.TranslateContext,
 	obj *networkingv1.Ingress,
 	config *IngressConfig,
 	backendService *networkingv1.IngressServiceBackend,
 	upstream *adctypes.Upstream,
-) string {
+) (string, *v1alpha1.BackendTrafficPolicy) {
 	ns := obj.Namespace
 	if config != nil && config.ServiceNamespace != "" {
 		ns = config.ServiceNamespace
 	}
 	backendRef := convertBackendRef(ns, backendService.Name, internaltypes.KindService)
-	t.AttachBackendTrafficPolicyToUpstream(backendRef, tctx.BackendTrafficPolicies, upstream, tctx.Services)
+	policy := t.AttachBackendTrafficPolicyToUpstream(backendRef, tctx.BackendTrafficPolicies, upstream, tctx.Services)
 	if config != nil {
 		upConfig := config.Upstream
 		if upConfig.Scheme != "" {
@@ -227,13 +316,13 @@ func (t *Translator) resolveIngressUpstream(
 		Name:      backendService.Name,
 	}]
 	if getService == nil {
-		return protocol
+		return protocol, policy
 	}
 	getServicePort, _ := findMatchingServicePort(getService, port)
 	if getServicePort != nil && getServicePort.AppProtocol != nil {
 		protocol = *getServicePort.AppProtocol
 		if upstream.Scheme == "" {
-			upstream.Scheme = appProtocolToUpstreamScheme(*getServicePort.AppProtocol)
+			upstream.Scheme = ingressAppProtocolToUpstreamScheme(*getServicePort.AppProtocol)
 		}
 	}
 	if getService.Spec.Type == corev1.ServiceTypeExternalName {
@@ -248,7 +337,7 @@ func (t *Translator) resolveIngressUpstream(
 				Weight: 1,
 			},
 		}
-		return protocol
+		return protocol, policy
 	}
 
 	endpointSlices := tctx.EndpointSlices[types.NamespacedName{
@@ -259,7 +348,39 @@ func (t *Translator) resolveIngressUpstream(
 		upstream.Nodes = t.translateEndpointSliceForIngress(1, endpointSlices, getServicePort)
 	}
 
-	return protocol
+	return protocol, policy
+}
+
+// resolveIngressResourceBackend builds the upstream of a Resource backend, only
+// ApisixUpstream with externalNodes in the same namespace is supported. A nil
+// upstream is returned when the backend can not be resolved, and the path is
+// skipped like a path without backend.
+func (t *Translator) resolveIngressResourceBackend(
+	tctx *provider.TranslateContext,
+	obj *networkingv1.Ingress,
+	ref *corev1.TypedLocalObjectReference,
+) (*adctypes.Upstream, error) {
+	if !IsSupportedIngressResourceBackend(ref) {
+		t.Log.V(1).Info("skipping unsupported resource backend", "ingress", utils.NamespacedName(obj),
+			"apiGroup", ptr.Deref(ref.APIGroup, ""), "kind", ref.Kind, "name", ref.Name)
+		return nil, nil
+	}
+	upsNN := types.NamespacedName{Namespace: obj.Namespace, Name: ref.Name}
+	au, ok := tctx.Upstreams[upsNN]
+	if !ok || au == nil {
+		t.Log.V(1).Info("failed to retrieve ApisixUpstream from tctx", "ApisixUpstream", upsNN.String())
+		return nil, nil
+	}
+	if len(au.Spec.ExternalNodes) == 0 {
+		return nil, fmt.Errorf("ApisixUpstream %s referenced by resource backend has no externalNodes", upsNN)
+	}
+	return t.translateApisixUpstream(tctx, au)
+}
+
+// IsSupportedIngressResourceBackend reports whether the Resource backend of an
+// Ingress can be translated.
+func IsSupportedIngressResourceBackend(ref *corev1.TypedLocalObjectReference) bool {
+	return ref != nil && ptr.Deref(ref.APIGroup, "") == apiv2.GroupVersion.Group && ref.Kind == internaltypes.KindApisixUpstream
 }
 
 func (t *Translator) buildRouteFromIngressPath(
@@ -291,28 +412,13 @@ func (t *Translator) buildRouteFromIngressPath(
 			prefix := strings.TrimSuffix(path.Path, "/") + "/*"
 			uris = append(uris, prefix)
 		case networkingv1.PathTypeImplementationSpecific:
-			if config != nil && config.UseRegex {
-				uris = []string{"/*"}
-				vars := apiv2.ApisixRouteHTTPMatchExprs{
-					apiv2.ApisixRouteHTTPMatchExpr{
-						Subject: apiv2.ApisixRouteHTTPMatchExprSubject{
-							Scope: apiv2.ScopePath,
-						},
-						Op:    apiv2.OpRegexMatch,
-						Value: &path.Path,
-					},
-				}
-				routeVars, err := vars.ToVars()
-				if err != nil {
-					t.Log.Error(err, "failed to convert regex match exprs to vars", "exprs", vars)
-				} else {
-					route.Vars = append(route.Vars, routeVars...)
-				}
-			}
+			uris = t.buildImplementationSpecificPath(tctx, route, path.Path, config)
 		}
 	}
 
 	if config != nil {
+		route.Timeout = buildIngressRouteTimeout(config.Timeout)
+
 		// check if PluginConfig is specified
 		if config.PluginConfigName != "" {
 			plugins, err := t.loadPluginConfigPluginsForIngress(tctx, obj.Namespace, config.PluginConfigName)
@@ -320,6 +426,10 @@ func (t *Translator) buildRouteFromIngressPath(
 				return nil, err
 			}
 			route.Plugins = plugins
+			pcKey := types.NamespacedName{Namespace: obj.Namespace, Name: config.PluginConfigName}
+			if pc := tctx.ApisixPluginConfigs[pcKey]; pc != nil {
+				route.Labels = label.WithPolicy(route.Labels, internaltypes.KindApisixPluginConfig, pc.Namespace, pc.Name)
+			}
 		}
 
 		// apply plugins from annotations
@@ -331,12 +441,143 @@ func (t *Translator) buildRouteFromIngressPath(
 				route.Plugins[k] = v
 			}
 		}
+
+		if err := t.fillOpenIDConnectClientSecret(tctx, obj.Namespace, config, route.Plugins); err != nil {
+			return nil, err
+		}
 	}
 
 	route.Uris = uris
 	return route, nil
 }
 
+// buildImplementationSpecificPath returns the uris of an ImplementationSpecific
+// path and appends the vars it needs to the route. The use-regex annotation of
+// the Ingress takes precedence over the ImplementationSpecificPathType of the
+// GatewayProxy referenced by the IngressClass, which defaults to Exact.
+func (t *Translator) buildImplementationSpecificPath(
+	tctx *provider.TranslateContext,
+	route *adctypes.Route,
+	path string,
+	config *IngressConfig,
+) []string {
+	pathType := ingressImplementationSpecificPathType(tctx)
+	if config != nil && config.UseRegex {
+		pathType = v1alpha1.ImplementationSpecificPathTypeRegularExpression
+	}
+
+	var (
+		op    string
+		regex string
+	)
+	switch pathType {
+	case v1alpha1.ImplementationSpecificPathTypePrefix:
+		return []string{path, strings.TrimSuffix(path, "/") + "/*"}
+	case v1alpha1.ImplementationSpecificPathTypeRegularExpression:
+		op, regex = apiv2.OpRegexMatch, path
+	case v1alpha1.ImplementationSpecificPathTypeNginxPrefix:
+		if !strings.ContainsAny(path, nginxRegexChars) {
+			// NGINX prefix locations match any request path starting
+			// with the string, not only by path elements.
+			return []string{path + "*"}
+		}
+		op, regex = apiv2.OpRegexMatchCaseInsensitive, path
+		if !strings.HasPrefix(regex, "^") {
+			regex = "^" + regex
+		}
+	default:
+		return []string{path}
+	}
+
+	vars := apiv2.ApisixRouteHTTPMatchExprs{
+		apiv2.ApisixRouteHTTPMatchExpr{
+			Subject: apiv2.ApisixRouteHTTPMatchExprSubject{
+				Scope: apiv2.ScopePath,
+			},
+			Op:    op,
+			Value: &regex,
+		},
+	}
+	routeVars, err := vars.ToVars()
+	if err != nil {
+		t.Log.Error(err, "failed to convert regex match exprs to vars", "exprs", vars)
+	} else {
+		route.Vars = append(route.Vars, routeVars...)
+	}
+	return []string{"/*"}
+}
+
+// nginxRegexChars are the characters which make NginxPrefix treat a path as a
+// regular expression. The dot is left out as it is common in plain paths.
+const nginxRegexChars = `^$*+?()[]{}|\`
+
+// ingressImplementationSpecificPathType returns the ImplementationSpecificPathType
+// configured by the GatewayProxy of the IngressClass. The translate context of
+// an Ingress holds at most one GatewayProxy.
+func ingressImplementationSpecificPathType(tctx *provider.TranslateContext) v1alpha1.ImplementationSpecificPathType {
+	for _, gp := range tctx.GatewayProxies {
+		if gp.Spec.Ingress != nil && gp.Spec.Ingress.ImplementationSpecificPathType != "" {
+			return gp.Spec.Ingress.ImplementationSpecificPathType
+		}
+	}
+	return v1alpha1.ImplementationSpecificPathTypeExact
+}
+
+// fillOpenIDConnectClientSecret sets the client secret of the openid-connect
+// plugin from the Secret referenced by the annotations. Incomplete settings
+// are reported as an error instead of leaving the route unauthenticated. The
+// plugin config built from annotations is shared by every path of the Ingress,
+// so a copy is stored.
+func (t *Translator) fillOpenIDConnectClientSecret(
+	tctx *provider.TranslateContext,
+	namespace string,
+	config *IngressConfig,
+	plugins adctypes.Plugins,
+) error {
+	ref := config.OIDCClientSecret
+	if ref == (oidc.ClientSecretRef{}) {
+		return nil
+	}
+	oidcConfig, ok := plugins["openid-connect"].(*adctypes.OpenIDConnectConfig)
+	if !ok {
+		return fmt.Errorf("annotations %q and %q are required when %q is %q",
+			annotations.AnnotationsOIDCDiscovery, annotations.AnnotationsOIDCClientID,
+			annotations.AnnotationsAuthType, annotations.AuthTypeOpenIDConnect)
+	}
+	if ref.Name == "" {
+		return fmt.Errorf("annotation %q is required when %q is %q",
+			annotations.AnnotationsOIDCClientSecretRef, annotations.AnnotationsAuthType, annotations.AuthTypeOpenIDConnect)
+	}
+	secret := tctx.Secrets[types.NamespacedName{Namespace: namespace, Name: ref.Name}]
+	if secret == nil {
+		return fmt.Errorf("secret %s/%s referenced by annotation %q not found",
+			namespace, ref.Name, annotations.AnnotationsOIDCClientSecretRef)
+	}
+	clientSecret, ok := secret.Data[ref.Key]
+	if !ok || len(clientSecret) == 0 {
+		return fmt.Errorf("key %q not found in secret %s/%s", ref.Key, namespace, ref.Name)
+	}
+
+	cp := oidcConfig.DeepCopy()
+	cp.ClientSecret = string(clientSecret)
+	plugins["openid-connect"] = cp
+	return nil
+}
+
+// buildIngressRouteTimeout converts the route timeout annotations, unset
+// timeouts fall back to the APISIX default like the ApisixRoute timeout field.
+func buildIngressRouteTimeout(timeout timeout.Timeout) *adctypes.Timeout {
+	if timeout.Connect == 0 && timeout.Read == 0 && timeout.Send == 0 {
+		return nil
+	}
+	defaultTimeout := int(apiv2.DefaultUpstreamTimeout.Seconds())
+	return &adctypes.Timeout{
+		Connect: cmp.Or(timeout.Connect, defaultTimeout),
+		Read:    cmp.Or(timeout.Read, defaultTimeout),
+		Send:    cmp.Or(timeout.Send, defaultTimeout),
+	}
+}
+
 func (t *Translator) loadPluginConfigPluginsForIngress(tctx *provider.TranslateContext, namespace, pluginConfigName string) (adctypes.Plugins, error) {
 	plugins := make(adctypes.Plugins)
 
@@ -27,8 +27,13 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations"
 	"github.com/apache/apisix-ingress-controller/internal/provider"
+	internaltypes "github.com/apache/apisix-ingress-controller/internal/types"
 )
 
 func TestTranslateIngress_ImplementationSpecificPathWithoutAnnotations(t *testing.T) {
@@ -76,3 +81,376 @@ func TestTranslateIngress_ImplementationSpecificPathWithoutAnnotations(t *testin
 	assert.Equal(t, []string{"/api/(.*)"}, route.Uris)
 	assert.Empty(t, route.Vars)
 }
+
+func TestTranslateIngress_RouteTimeoutAnnotations(t *testing.T) {
+	translator := NewTranslator(logr.Discard(), "")
+	pathType := networkingv1.PathTypeExact
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-ingress",
+			Annotations: map[string]string{
+				annotations.AnnotationsTimeoutRead:       "2m",
+				annotations.AnnotationsClientMaxBodySize: "1g",
+			},
+		},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{{
+				IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{
+						Path:     "/upload",
+						PathType: &pathType,
+						Backend: networkingv1.IngressBackend{
+							Service: &networkingv1.IngressServiceBackend{
+								Name: "test-svc",
+								Port: networkingv1.ServiceBackendPort{Number: 80},
+							},
+						},
+					}},
+				}},
+			}},
+		},
+	}
+
+	result, err := translator.TranslateIngress(&provider.TranslateContext{}, ingress)
+	require.NoError(t, err)
+	require.Len(t, result.Services, 1)
+
+	route := result.Services[0].Routes[0]
+	assert.Equal(t, &adctypes.Timeout{Connect: 60, Read: 120, Send: 60}, route.Timeout)
+	assert.Equal(t, &adctypes.ClientControlConfig{MaxBodySize: 1 << 30}, route.Plugins["client-control"])
+}
+
+func TestTranslateIngress_OpenIDConnectClientSecret(t *testing.T) {
+	translator := NewTranslator(logr.Discard(), "")
+	pathType := networkingv1.PathTypePrefix
+
+	newIngress := func(anno map[string]string) *networkingv1.Ingress {
+		return &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "dashboard",
+				Annotations: anno,
+			},
+			Spec: networkingv1.IngressSpec{
+				Rules: []networkingv1.IngressRule{{
+					IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path:     "/",
+							PathType: &pathType,
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{
+									Name: "dashboard",
+									Port: networkingv1.ServiceBackendPort{Number: 80},
+								},
+							},
+						}},
+					}},
+				}},
+			},
+		}
+	}
+	anno := map[string]string{
+		annotations.AnnotationsAuthType:            annotations.AuthTypeOpenIDConnect,
+		annotations.AnnotationsOIDCDiscovery:       "https://idp.example.com/.well-known/openid-configuration",
+		annotations.AnnotationsOIDCClientID:        "dashboard",
+		annotations.AnnotationsOIDCClientSecretRef: "oidc-secret",
+	}
+	tctx := &provider.TranslateContext{
+		Secrets: map[types.NamespacedName]*corev1.Secret{
+			{Namespace: "default", Name: "oidc-secret"}: {
+				Data: map[string][]byte{"client_secret": []byte("s3cr3t")},
+			},
+		},
+	}
+
+	result, err := translator.TranslateIngress(tctx, newIngress(anno))
+	require.NoError(t, err)
+	require.Len(t, result.Services, 1)
+	plugin, ok := result.Services[0].Routes[0].Plugins["openid-connect"].(*adctypes.OpenIDConnectConfig)
+	require.True(t, ok)
+	assert.Equal(t, "dashboard", plugin.ClientID)
+	assert.Equal(t, "s3cr3t", plugin.ClientSecret)
+
+	// the Secret is missing: the route must not be programmed without authentication
+	_, err = translator.TranslateIngress(&provider.TranslateContext{}, newIngress(anno))
+	assert.Error(t, err)
+
+	// the Secret lacks the configured key
+	anno[annotations.AnnotationsOIDCClientSecretKey] = "secret"
+	_, err = translator.TranslateIngress(tctx, newIngress(anno))
+	assert.Error(t, err)
+
+	// the plugin cannot be built without a discovery URL
+	delete(anno, annotations.AnnotationsOIDCClientSecretKey)
+	delete(anno, annotations.AnnotationsOIDCDiscovery)
+	_, err = translator.TranslateIngress(tctx, newIngress(anno))
+	assert.Error(t, err)
+}
+
+func TestTranslateIngress_DefaultBackend(t *testing.T) {
+	translator := NewTranslator(logr.Discard(), "")
+	pathType := networkingv1.PathTypeExact
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-ingress"},
+		Spec: networkingv1.IngressSpec{
+			DefaultBackend: &networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{
+					Name: "fallback",
+					Port: networkingv1.ServiceBackendPort{Number: 80},
+				},
+			},
+			Rules: []networkingv1.IngressRule{{
+				Host: "example.com",
+				IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{
+						Path:     "/api",
+						PathType: &pathType,
+						Backend: networkingv1.IngressBackend{
+							Service: &networkingv1.IngressServiceBackend{
+								Name: "api",
+								Port: networkingv1.ServiceBackendPort{Number: 80},
+							},
+						},
+					}},
+				}},
+			}},
+		},
+	}
+
+	result, err := translator.TranslateIngress(&provider.TranslateContext{}, ingress)
+	require.NoError(t, err)
+	require.Len(t, result.Services, 2)
+
+	svc := result.Services[1]
+	assert.Equal(t, adctypes.ComposeServiceNameWithRule("default", "test-ingress", IngressDefaultBackendRule), svc.Name)
+	assert.Empty(t, svc.Hosts)
+	require.Len(t, svc.Routes, 1)
+	assert.Equal(t, []string{"/", "/*"}, svc.Routes[0].Uris)
+	assert.Equal(t, IngressDefaultBackendPriority, *svc.Routes[0].Priority)
+
+	// another Ingress of the IngressClass owns the default backend
+	result, err = translator.TranslateIngress(&provider.TranslateContext{SkipIngressDefaultBackend: true}, ingress)
+	require.NoError(t, err)
+	assert.Len(t, result.Services, 1)
+
+	// unsupported Resource backends are skipped
+	ingress.Spec.DefaultBackend = &networkingv1.IngressBackend{
+		Resource: &corev1.TypedLocalObjectReference{Kind: "ConfigMap", Name: "static"},
+	}
+	result, err = translator.TranslateIngress(&provider.TranslateContext{}, ingress)
+	require.NoError(t, err)
+	assert.Len(t, result.Services, 1)
+}
+
+func TestTranslateIngress_ImplementationSpecificPathType(t *testing.T) {
+	translator := NewTranslator(logr.Discard(), "")
+	pathType := networkingv1.PathTypeImplementationSpecific
+
+	newIngress := func(path string, anno map[string]string) *networkingv1.Ingress {
+		return &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-ingress", Annotations: anno},
+			Spec: networkingv1.IngressSpec{
+				Rules: []networkingv1.IngressRule{{
+					IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path:     path,
+							PathType: &pathType,
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{
+									Name: "test-svc",
+									Port: networkingv1.ServiceBackendPort{Number: 80},
+								},
+							},
+						}},
+					}},
+				}},
+			},
+		}
+	}
+	newTranslateContext := func(pathType v1alpha1.ImplementationSpecificPathType) *provider.TranslateContext {
+		return &provider.TranslateContext{
+			GatewayProxies: map[internaltypes.NamespacedNameKind]v1alpha1.GatewayProxy{
+				{Name: "apisix", Kind: internaltypes.KindIngressClass}: {
+					Spec: v1alpha1.GatewayProxySpec{
+						Ingress: &v1alpha1.GatewayProxyIngress{ImplementationSpecificPathType: pathType},
+					},
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		name     string
+		pathType v1alpha1.ImplementationSpecificPathType
+		path     string
+		anno     map[string]string
+		uris     []string
+		vars     adctypes.Vars
+	}{
+		{
+			name: "unset defaults to exact",
+			path: "/api",
+			uris: []string{"/api"},
+		},
+		{
+			name:     "exact",
+			pathType: v1alpha1.ImplementationSpecificPathTypeExact,
+			path:     "/api",
+			uris:     []string{"/api"},
+		},
+		{
+			name:     "prefix",
+			pathType: v1alpha1.ImplementationSpecificPathTypePrefix,
+			path:     "/api/",
+			uris:     []string{"/api/", "/api/*"},
+		},
+		{
+			name:     "regular expression",
+			pathType: v1alpha1.ImplementationSpecificPathTypeRegularExpression,
+			path:     "/api/v[0-9]+",
+			uris:     []string{"/*"},
+			vars:     adctypes.Vars{{{StrVal: "uri"}, {StrVal: "~~"}, {StrVal: "/api/v[0-9]+"}}},
+		},
+		{
+			name:     "nginx prefix matches plain paths as string prefix",
+			pathType: v1alpha1.ImplementationSpecificPathTypeNginxPrefix,
+			path:     "/static/app.js",
+			uris:     []string{"/static/app.js*"},
+		},
+		{
+			name:     "nginx prefix falls back to anchored regex",
+			pathType: v1alpha1.ImplementationSpecificPathTypeNginxPrefix,
+			path:     "/api/(.*)",
+			uris:     []string{"/*"},
+			vars:     adctypes.Vars{{{StrVal: "uri"}, {StrVal: "~*"}, {StrVal: "^/api/(.*)"}}},
+		},
+		{
+			name:     "use-regex annotation takes precedence",
+			pathType: v1alpha1.ImplementationSpecificPathTypePrefix,
+			path:     "/api/.*",
+			anno:     map[string]string{annotations.AnnotationsUseRegex: "true"},
+			uris:     []string{"/*"},
+			vars:     adctypes.Vars{{{StrVal: "uri"}, {StrVal: "~~"}, {StrVal: "/api/.*"}}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := translator.TranslateIngress(newTranslateContext(tc.pathType), newIngress(tc.path, tc.anno))
+			require.NoError(t, err)
+			require.Len(t, result.Services, 1)
+
+			route := result.Services[0].Routes[0]
+			assert.Equal(t, tc.uris, route.Uris)
+			assert.Equal(t, tc.vars, route.Vars)
+		})
+	}
+}
+
+func TestTranslateIngress_UpstreamScheme(t *testing.T) {
+	translator := NewTranslator(logr.Discard(), "")
+	pathType := networkingv1.PathTypePrefix
+
+	newIngress := func(anno map[string]string) *networkingv1.Ingress {
+		return &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-ingress", Annotations: anno},
+			Spec: networkingv1.IngressSpec{
+				Rules: []networkingv1.IngressRule{{
+					IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path:     "/",
+							PathType: &pathType,
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{
+									Name: "test-svc",
+									Port: networkingv1.ServiceBackendPort{Number: 50051},
+								},
+							},
+						}},
+					}},
+				}},
+			},
+		}
+	}
+	newTranslateContext := func(appProtocol *string) *provider.TranslateContext {
+		return &provider.TranslateContext{
+			Services: map[types.NamespacedName]*corev1.Service{
+				{Namespace: "default", Name: "test-svc"}: {
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-svc"},
+					Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{{
+						Port:        50051,
+						AppProtocol: appProtocol,
+					}}},
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		name        string
+		appProtocol *string
+		anno        map[string]string
+		scheme      string
+	}{
+		{name: "no appProtocol nor annotation", scheme: ""},
+		{name: "appProtocol https", appProtocol: ptr.To("https"), scheme: "https"},
+		{name: "appProtocol grpc", appProtocol: ptr.To("grpc"), scheme: "grpc"},
+		{name: "appProtocol h2c is left unresolved", appProtocol: ptr.To("kubernetes.io/h2c"), scheme: ""},
+		{
+			name:        "backend-protocol GRPC overrides appProtocol h2c",
+			appProtocol: ptr.To("kubernetes.io/h2c"),
+			anno:        map[string]string{annotations.AnnotationsBackendProtocol: "GRPC"},
+			scheme:      "grpc",
+		},
+		{name: "unknown appProtocol", appProtocol: ptr.To("example.com/custom"), scheme: ""},
+		{
+			name:        "upstream-scheme overrides appProtocol",
+			appProtocol: ptr.To("grpc"),
+			anno:        map[string]string{annotations.AnnotationsUpstreamScheme: "https"},
+			scheme:      "https",
+		},
+		{
+			name:        "backend-protocol overrides appProtocol",
+			appProtocol: ptr.To("https"),
+			anno:        map[string]string{annotations.AnnotationsBackendProtocol: "GRPCS"},
+			scheme:      "grpcs",
+		},
+		{
+			name: "backend-protocol overrides upstream-scheme",
+			anno: map[string]string{
+				annotations.AnnotationsUpstreamScheme:  "https",
+				annotations.AnnotationsBackendProtocol: "HTTP",
+			},
+			scheme: "http",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := translator.TranslateIngress(newTranslateContext(tc.appProtocol), newIngress(tc.anno))
+			require.NoError(t, err)
+			require.Len(t, result.Services, 1)
+			assert.Equal(t, tc.scheme, result.Services[0].Upstream.Scheme)
+		})
+	}
+}
+
+func TestTranslateIngress_InvalidAnnotationIsNotDropped(t *testing.T) {
+	translator := NewTranslator(logr.Discard(), "")
+
+	// A route programmed without the body size limit it asks for is worse than none.
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-ingress",
+			Annotations: map[string]string{
+				annotations.AnnotationsClientMaxBodySize: "8mb",
+			},
+		},
+	}
+
+	_, err := translator.TranslateIngress(&provider.TranslateContext{}, ingress)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), annotations.AnnotationsClientMaxBodySize)
+}
@@ -28,6 +28,7 @@ import (
 	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
 	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
 	apiv2 "github.com/apache/apisix-ingress-controller/api/v2"
+	"github.com/apache/apisix-ingress-controller/internal/controller/label"
 	internaltypes "github.com/apache/apisix-ingress-controller/internal/types"
 )
 
@@ -39,9 +40,11 @@ func convertBackendRef(namespace, name, kind string) gatewayv1.BackendRef {
 	return backendRef
 }
 
-func (t *Translator) AttachBackendTrafficPolicyToUpstream(ref gatewayv1.BackendRef, policies map[types.NamespacedName]*v1alpha1.BackendTrafficPolicy, upstream *adctypes.Upstream, services map[types.NamespacedName]*corev1.Service) {
+// AttachBackendTrafficPolicyToUpstream applies to upstream the BackendTrafficPolicy that
+// targets the backend of ref, and returns it, or nil if none does.
+func (t *Translator) AttachBackendTrafficPolicyToUpstream(ref gatewayv1.BackendRef, policies map[types.NamespacedName]*v1alpha1.BackendTrafficPolicy, upstream *adctypes.Upstream, services map[types.NamespacedName]*corev1.Service) *v1alpha1.BackendTrafficPolicy {
 	if len(policies) == 0 {
-		return
+		return nil
 	}
 	// Resolve the backend ref group/kind, applying the Gateway API defaults
 	// (empty group = core, Service kind) so a targetRef is only matched against
@@ -86,9 +89,19 @@ func (t *Translator) AttachBackendTrafficPolicyToUpstream(ref gatewayv1.BackendR
 		policy = genericPolicy
 	}
 	if policy == nil {
-		return
+		return nil
 	}
 	t.attachBackendTrafficPolicyToUpstream(policy, upstream)
+	return policy
+}
+
+// withBackendTrafficPolicy records in the labels of service that policy, if any, shaped
+// one of its upstreams.
+func withBackendTrafficPolicy(service *adctypes.Service, policy *v1alpha1.BackendTrafficPolicy) {
+	if policy == nil {
+		return
+	}
+	service.Labels = label.WithPolicy(service.Labels, internaltypes.KindBackendTrafficPolicy, policy.Namespace, policy.Name)
 }
 
 // backendRefMatchesSectionName reports whether the backend ref resolves to the
@@ -42,6 +42,7 @@ import (
 
 	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
 	apiv2 "github.com/apache/apisix-ingress-controller/api/v2"
+	"github.com/apache/apisix-ingress-controller/internal/controller/events"
 	"github.com/apache/apisix-ingress-controller/internal/controller/indexer"
 	"github.com/apache/apisix-ingress-controller/internal/controller/status"
 	"github.com/apache/apisix-ingress-controller/internal/manager/readiness"
@@ -59,6 +60,7 @@ type ApisixRouteReconciler struct {
 	Provider provider.Provider
 	Updater  status.Updater
 	Readier  readiness.ReadinessManager
+	Events   *events.Recorder
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -626,13 +628,16 @@ func (r *ApisixRouteReconciler) listApisixRouteForApisixUpstream(ctx context.Con
 }
 
 func (r *ApisixRouteReconciler) updateStatus(ar *apiv2.ApisixRoute, err error) {
+	if err != nil {
+		r.Events.Warning(ar, events.ReasonFor(err), "%s", err)
+	}
 	SetApisixCRDConditionAccepted(&ar.Status, ar.GetGeneration(), err)
 	r.Updater.Update(status.Update{
 		NamespacedName: utils.NamespacedName(ar),
 		Resource:       &apiv2.ApisixRoute{},
 		Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
 			cp := obj.(*apiv2.ApisixRoute).DeepCopy()
-			cp.Status = ar.Status
+			cp.Status = carryApisixCRDConditionProgrammed(cp.Status, ar.Status, ar.GetGeneration())
 			return cp
 		}),
 	})
@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"text/template"
 	"time"
@@ -52,9 +53,21 @@ func NewDefaultConfig() *Config {
 		LeaderElection:   NewLeaderElection(),
 		ExecADCTimeout:   types.TimeDuration{Duration: 15 * time.Second},
 		ProviderConfig: ProviderConfig{
-			Type:          ProviderTypeAPISIX,
-			SyncPeriod:    types.TimeDuration{Duration: 1 * time.Hour},
-			InitSyncDelay: types.TimeDuration{Duration: 20 * time.Minute},
+			Type:            ProviderTypeAPISIX,
+			SyncPeriod:      types.TimeDuration{Duration: 1 * time.Hour},
+			InitSyncDelay:   types.TimeDuration{Duration: 20 * time.Minute},
+			Executor:        ProviderExecutorADC,
+			BatchWindow:     types.TimeDuration{Duration: 1 * time.Second},
+			BatchMaxSize:    500,
+			SyncConcurrency: 4,
+			DriftDetection: DriftDetectionConfig{
+				Interval: types.TimeDuration{Duration: 5 * time.Minute},
+				Mode:     DriftDetectionModeAlert,
+			},
+			File: FileOutputConfig{
+				Format: FileOutputFormatAPISIX,
+				Target: FileOutputTargetDirectory,
+			},
 		},
 		Webhook:               NewWebhookConfig(),
 		ListenerPortMatchMode: ListenerPortMatchModeOff,
@@ -77,6 +90,8 @@ func NewWebhookConfig() *WebhookConfig {
 		TLSKeyFile:  DefaultWebhookTLSKey,
 		TLSCertDir:  DefaultWebhookTLSCertDir,
 		Port:        DefaultWebhookPort,
+
+		RouteConflicts: RouteConflictModeWarn,
 	}
 }
 
@@ -143,16 +158,94 @@ func (c *Config) Validate() error {
 
 func validateProvider(config ProviderConfig) error {
 	switch config.Type {
-	case ProviderTypeStandalone, ProviderTypeAPISIX:
+	case ProviderTypeStandalone, ProviderTypeAPISIX, ProviderTypeFile:
 		if config.SyncPeriod.Duration <= 0 {
 			return fmt.Errorf("sync_period must be greater than 0 for standalone provider")
 		}
+		switch config.Executor {
+		case "", ProviderExecutorADC, ProviderExecutorNative:
+		default:
+			return fmt.Errorf("invalid provider executor: %q (must be adc or native)", config.Executor)
+		}
+		if config.BatchWindow.Duration < 0 {
+			return fmt.Errorf("batch_window must not be negative")
+		}
+		if config.BatchMaxSize < 0 {
+			return fmt.Errorf("batch_max_size must not be negative")
+		}
+		if config.SyncConcurrency <= 0 {
+			return fmt.Errorf("sync_concurrency must be greater than 0")
+		}
+		if config.DriftDetection.Enable && config.DriftDetection.Interval.Duration <= 0 {
+			return fmt.Errorf("drift_detection.interval must be greater than 0")
+		}
+		switch config.DriftDetection.Mode {
+		case "", DriftDetectionModeAlert, DriftDetectionModeHeal:
+		default:
+			return fmt.Errorf("invalid drift_detection mode: %q (must be alert or heal)", config.DriftDetection.Mode)
+		}
+		if config.Type == ProviderTypeFile {
+			return validateFileOutput(config.File)
+		}
+		return nil
+	case ProviderTypeComposite:
+		if len(config.Providers) == 0 {
+			return fmt.Errorf("providers must be set for the composite provider")
+		}
+		var pushing ProviderType
+		for i, providerType := range config.Providers {
+			if slices.Contains(config.Providers[:i], providerType) {
+				return fmt.Errorf("provider %q is listed twice", providerType)
+			}
+			if providerType == ProviderTypeComposite {
+				return fmt.Errorf("a composite provider cannot mirror to another")
+			}
+			// Every provider that pushes to a control plane takes its endpoints from the
+			// controlPlane of the GatewayProxy, so two of them would push to the same one.
+			if providerType == ProviderTypeAPISIX || providerType == ProviderTypeStandalone {
+				if pushing != "" {
+					return fmt.Errorf("providers %q and %q would both push to the control plane of each GatewayProxy; list one of them",
+						pushing, providerType)
+				}
+				pushing = providerType
+			}
+			child := config
+			child.Type, child.Providers = providerType, nil
+			if err := validateProvider(child); err != nil {
+				return fmt.Errorf("provider %q: %w", providerType, err)
+			}
+		}
 		return nil
 	default:
 		return fmt.Errorf("unsupported provider type: %s", config.Type)
 	}
 }
 
+func validateFileOutput(config FileOutputConfig) error {
+	switch config.Format {
+	case FileOutputFormatAPISIX, FileOutputFormatADC:
+	default:
+		return fmt.Errorf("invalid file format: %q (must be apisix or adc)", config.Format)
+	}
+	switch config.Target {
+	case FileOutputTargetDirectory:
+		if config.Directory == "" {
+			return fmt.Errorf("file.directory must be set for the directory target")
+		}
+	case FileOutputTargetConfigMap, FileOutputTargetSecret:
+		if config.Namespace == "" {
+			return fmt.Errorf("file.namespace must be set for the %s target", config.Target)
+		}
+		if config.Target == FileOutputTargetConfigMap && !config.AllowConfigMap {
+			return fmt.Errorf("the configmap target keeps TLS keys and consumer credentials in plaintext; " +
+				"use the secret target, or set file.allow_configmap to accept that")
+		}
+	default:
+		return fmt.Errorf("invalid file target: %q (must be directory, configmap or secret)", config.Target)
+	}
+	return nil
+}
+
 func GetControllerName() string {
 	return ControllerConfig.ControllerName
 }
@@ -175,6 +268,11 @@ func validateWebhook(config *WebhookConfig) error {
 			return fmt.Errorf("tls_cert_dir is required for webhook")
 		}
 	}
+	switch config.RouteConflicts {
+	case "", RouteConflictModeOff, RouteConflictModeWarn, RouteConflictModeDeny:
+	default:
+		return fmt.Errorf("invalid route_conflicts mode: %q (must be off, warn or deny)", config.RouteConflicts)
+	}
 
 	return nil
 }
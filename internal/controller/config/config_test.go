@@ -79,3 +79,119 @@ func TestConfigValidateListenerPortMatchMode(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigValidateFileProvider(t *testing.T) {
+	tests := []struct {
+		name   string
+		output FileOutputConfig
+		errMsg string
+	}{
+		{
+			name:   "directory",
+			output: FileOutputConfig{Format: FileOutputFormatAPISIX, Target: FileOutputTargetDirectory, Directory: "/out"},
+		},
+		{
+			name:   "directory target without a directory",
+			output: FileOutputConfig{Format: FileOutputFormatAPISIX, Target: FileOutputTargetDirectory},
+			errMsg: "file.directory must be set",
+		},
+		{
+			name:   "secret",
+			output: FileOutputConfig{Format: FileOutputFormatADC, Target: FileOutputTargetSecret, Namespace: "gitops"},
+		},
+		{
+			name:   "configmap target without a namespace",
+			output: FileOutputConfig{Format: FileOutputFormatAPISIX, Target: FileOutputTargetConfigMap},
+			errMsg: "file.namespace must be set",
+		},
+		{
+			name:   "configmap",
+			output: FileOutputConfig{Format: FileOutputFormatAPISIX, Target: FileOutputTargetConfigMap, Namespace: "gitops"},
+			errMsg: "the configmap target keeps TLS keys and consumer credentials in plaintext",
+		},
+		{
+			name: "configmap allowed",
+			output: FileOutputConfig{
+				Format:         FileOutputFormatAPISIX,
+				Target:         FileOutputTargetConfigMap,
+				Namespace:      "gitops",
+				AllowConfigMap: true,
+			},
+		},
+		{
+			name:   "invalid format",
+			output: FileOutputConfig{Format: "json", Target: FileOutputTargetDirectory, Directory: "/out"},
+			errMsg: "invalid file format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			cfg.ProviderConfig.Type = ProviderTypeFile
+			cfg.ProviderConfig.File = tt.output
+
+			err := cfg.Validate()
+			if tt.errMsg != "" {
+				assert.ErrorContains(t, err, tt.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfigValidateCompositeProvider(t *testing.T) {
+	tests := []struct {
+		name      string
+		providers []ProviderType
+		directory string
+		errMsg    string
+	}{
+		{
+			name:      "apisix and standalone",
+			providers: []ProviderType{ProviderTypeAPISIX, ProviderTypeStandalone},
+			errMsg:    `providers "apisix" and "apisix-standalone" would both push to the control plane of each GatewayProxy`,
+		},
+		{
+			name:      "apisix and file",
+			providers: []ProviderType{ProviderTypeAPISIX, ProviderTypeFile},
+			directory: "/out",
+		},
+		{
+			name:   "no providers",
+			errMsg: "providers must be set",
+		},
+		{
+			name:      "provider listed twice",
+			providers: []ProviderType{ProviderTypeAPISIX, ProviderTypeAPISIX},
+			errMsg:    `provider "apisix" is listed twice`,
+		},
+		{
+			name:      "nested composite",
+			providers: []ProviderType{ProviderTypeAPISIX, ProviderTypeComposite},
+			errMsg:    "cannot mirror to another",
+		},
+		{
+			name:      "invalid member",
+			providers: []ProviderType{ProviderTypeAPISIX, ProviderTypeFile},
+			errMsg:    `provider "file": file.directory must be set`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			cfg.ProviderConfig.Type = ProviderTypeComposite
+			cfg.ProviderConfig.Providers = tt.providers
+			cfg.ProviderConfig.File.Directory = tt.directory
+
+			err := cfg.Validate()
+			if tt.errMsg != "" {
+				assert.ErrorContains(t, err, tt.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
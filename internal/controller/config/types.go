@@ -26,6 +26,59 @@ type ProviderType string
 const (
 	ProviderTypeStandalone ProviderType = "apisix-standalone"
 	ProviderTypeAPISIX     ProviderType = "apisix"
+	// ProviderTypeFile writes the configuration of each GatewayProxy out as a file
+	// instead of pushing it to a control plane.
+	ProviderTypeFile ProviderType = "file"
+	// ProviderTypeComposite mirrors the configuration to every provider listed in
+	// ProviderConfig.Providers.
+	ProviderTypeComposite ProviderType = "composite"
+)
+
+// FileOutputFormat selects what the file provider writes: an APISIX standalone
+// apisix.yaml, or an ADC declarative configuration.
+type FileOutputFormat string
+
+const (
+	FileOutputFormatAPISIX FileOutputFormat = "apisix"
+	FileOutputFormatADC    FileOutputFormat = "adc"
+)
+
+// FileOutputTarget selects where the file provider writes: files in a directory, or
+// ConfigMaps or Secrets in one namespace.
+type FileOutputTarget string
+
+const (
+	FileOutputTargetDirectory FileOutputTarget = "directory"
+	FileOutputTargetConfigMap FileOutputTarget = "configmap"
+	FileOutputTargetSecret    FileOutputTarget = "secret"
+)
+
+// ProviderExecutor selects how the provider writes to the data plane: through the ADC
+// server sidecar, or natively through the APISIX Admin API.
+type ProviderExecutor string
+
+const (
+	ProviderExecutorADC    ProviderExecutor = "adc"
+	ProviderExecutorNative ProviderExecutor = "native"
+)
+
+// DriftDetectionMode selects what the drift detector does about drift it finds: only
+// report it, or also push the affected config again.
+type DriftDetectionMode string
+
+const (
+	DriftDetectionModeAlert DriftDetectionMode = "alert"
+	DriftDetectionModeHeal  DriftDetectionMode = "heal"
+)
+
+// RouteConflictMode selects what admission does about a route that would conflict with
+// the route of another object: nothing, warn about it, or deny it.
+type RouteConflictMode string
+
+const (
+	RouteConflictModeOff  RouteConflictMode = "off"
+	RouteConflictModeWarn RouteConflictMode = "warn"
+	RouteConflictModeDeny RouteConflictMode = "deny"
 )
 
 // ListenerPortMatchMode selects when a Gateway listener port is turned into a
@@ -111,6 +164,43 @@ type ProviderConfig struct {
 	Type          ProviderType       `json:"type" yaml:"type"`
 	SyncPeriod    types.TimeDuration `json:"sync_period" yaml:"sync_period"`
 	InitSyncDelay types.TimeDuration `json:"init_sync_delay" yaml:"init_sync_delay"`
+	Executor      ProviderExecutor   `json:"executor" yaml:"executor"`
+	// BatchWindow is how long an update waits for others to be pushed with it.
+	BatchWindow types.TimeDuration `json:"batch_window" yaml:"batch_window"`
+	// BatchMaxSize is how many updated objects are pushed before the window is over.
+	BatchMaxSize int `json:"batch_max_size" yaml:"batch_max_size"`
+	// SyncConcurrency is how many GatewayProxy configs are synced at once.
+	SyncConcurrency int `json:"sync_concurrency" yaml:"sync_concurrency"`
+	// DriftDetection periodically reads the configuration back from the data plane.
+	DriftDetection DriftDetectionConfig `json:"drift_detection" yaml:"drift_detection"`
+	// StatePath is the file the translated configuration is saved to after each sync,
+	// and restored from on start. Empty disables it.
+	StatePath string `json:"state_path" yaml:"state_path"`
+	// File configures where and how the file provider writes.
+	File FileOutputConfig `json:"file" yaml:"file"`
+	// Providers are the providers the composite provider mirrors to, of which at most one
+	// pushes to a control plane. The first one is the primary: admission plans and route
+	// conflicts are taken from it.
+	Providers []ProviderType `json:"providers" yaml:"providers"`
+}
+
+type FileOutputConfig struct {
+	Format FileOutputFormat `json:"format" yaml:"format"`
+	Target FileOutputTarget `json:"target" yaml:"target"`
+	// Directory is where the files are written when Target is directory.
+	Directory string `json:"directory" yaml:"directory"`
+	// Namespace holds the ConfigMaps or Secrets when Target is configmap or secret.
+	Namespace string `json:"namespace" yaml:"namespace"`
+	// AllowConfigMap acknowledges that the configmap target keeps the TLS keys and
+	// consumer credentials of the configuration in plaintext, which it is refused
+	// without.
+	AllowConfigMap bool `json:"allow_configmap" yaml:"allow_configmap"`
+}
+
+type DriftDetectionConfig struct {
+	Enable   bool               `json:"enable" yaml:"enable"`
+	Interval types.TimeDuration `json:"interval" yaml:"interval"`
+	Mode     DriftDetectionMode `json:"mode" yaml:"mode"`
 }
 
 type WebhookConfig struct {
@@ -119,4 +209,10 @@ type WebhookConfig struct {
 	TLSKeyFile  string `json:"tls_key_file" yaml:"tls_key_file"`
 	TLSCertDir  string `json:"tls_cert_dir" yaml:"tls_cert_dir"`
 	Port        int    `json:"port" yaml:"port"`
+	// PlanWarnings adds to each admission response a summary of what applying the object
+	// would push to the data plane.
+	PlanWarnings bool `json:"plan_warnings" yaml:"plan_warnings"`
+	// RouteConflicts selects what is done about an Ingress, HTTPRoute, GRPCRoute or
+	// ApisixRoute whose routes would match the same requests as those of another object.
+	RouteConflicts RouteConflictMode `json:"route_conflicts" yaml:"route_conflicts"`
 }
@@ -0,0 +1,213 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package events reports what happens to the resources the controller translates as
+// Kubernetes Events, for `kubectl describe` to show.
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	apiv2 "github.com/apache/apisix-ingress-controller/api/v2"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+const (
+	// DefaultInterval is how long the same event on the same resource is held back for.
+	DefaultInterval = 10 * time.Minute
+	// DefaultBurst is how many events one resource may be reported per interval.
+	DefaultBurst = 10
+)
+
+type eventKey struct {
+	object  types.NamespacedNameKind
+	reason  apiv2.Reason
+	message string
+}
+
+// objectWindow counts the events reported on one resource since start.
+type objectWindow struct {
+	start time.Time
+	count int
+}
+
+// Recorder reports Events on resources, rate limited so that a resource that fails the
+// same way on every sync does not flood the API server: the same event on the same
+// resource is reported once per interval, and a resource gets at most burst events per
+// interval whatever they are. A nil Recorder reports nothing.
+type Recorder struct {
+	recorder record.EventRecorder
+	reader   client.Reader
+	interval time.Duration
+	burst    int
+	now      func() time.Time
+
+	mu      sync.Mutex
+	last    map[eventKey]time.Time
+	windows map[types.NamespacedNameKind]*objectWindow
+}
+
+// NewRecorder creates a Recorder reporting through recorder. The resources events are
+// reported on by name only are read through reader.
+func NewRecorder(recorder record.EventRecorder, reader client.Reader) *Recorder {
+	return &Recorder{
+		recorder: recorder,
+		reader:   reader,
+		interval: DefaultInterval,
+		burst:    DefaultBurst,
+		now:      time.Now,
+		last:     make(map[eventKey]time.Time),
+		windows:  make(map[types.NamespacedNameKind]*objectWindow),
+	}
+}
+
+// Normal reports an informational event on obj.
+func (r *Recorder) Normal(obj client.Object, reason apiv2.Reason, format string, args ...any) {
+	r.event(obj, corev1.EventTypeNormal, reason, fmt.Sprintf(format, args...))
+}
+
+// Warning reports an event on obj that says it does not work as specified.
+func (r *Recorder) Warning(obj client.Object, reason apiv2.Reason, format string, args ...any) {
+	r.event(obj, corev1.EventTypeWarning, reason, fmt.Sprintf(format, args...))
+}
+
+// WarningFor reports a warning on the resource nnk names, as it is now. Nothing is
+// reported on a resource that is gone.
+func (r *Recorder) WarningFor(ctx context.Context, nnk types.NamespacedNameKind, reason apiv2.Reason, format string, args ...any) {
+	if r == nil {
+		return
+	}
+	obj := newObject(nnk.Kind)
+	if obj == nil || r.reader.Get(ctx, nnk.NamespacedName(), obj) != nil {
+		return
+	}
+	r.Warning(obj, reason, format, args...)
+}
+
+func (r *Recorder) event(obj client.Object, eventType string, reason apiv2.Reason, message string) {
+	if r == nil || obj == nil {
+		return
+	}
+	nnk := types.NamespacedNameKind{
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Kind:      types.KindOf(obj),
+	}
+	if !r.allow(eventKey{object: nnk, reason: reason, message: message}) {
+		return
+	}
+	r.recorder.Event(obj, eventType, string(reason), message)
+}
+
+// allow reports whether the event key names may be reported now, and counts it if so.
+func (r *Recorder) allow(key eventKey) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	r.prune(now)
+
+	if last, ok := r.last[key]; ok && now.Sub(last) < r.interval {
+		return false
+	}
+	window, ok := r.windows[key.object]
+	if !ok || now.Sub(window.start) >= r.interval {
+		window = &objectWindow{start: now}
+		r.windows[key.object] = window
+	}
+	if window.count >= r.burst {
+		return false
+	}
+	window.count++
+	r.last[key] = now
+	return true
+}
+
+// prune forgets the events and windows older than an interval, once there are enough of
+// them to be worth the walk.
+func (r *Recorder) prune(now time.Time) {
+	if len(r.last) < 1024 {
+		return
+	}
+	for key, last := range r.last {
+		if now.Sub(last) >= r.interval {
+			delete(r.last, key)
+		}
+	}
+	for object, window := range r.windows {
+		if now.Sub(window.start) >= r.interval {
+			delete(r.windows, object)
+		}
+	}
+}
+
+// ReasonFor is the reason to report a failure to translate a resource with: an object
+// it refers to that is missing or not permitted is an unresolved reference.
+func ReasonFor(err error) apiv2.Reason {
+	if k8serrors.IsNotFound(err) || types.IsSomeReasonError(err,
+		gatewayv1.RouteReasonBackendNotFound,
+		gatewayv1.RouteReasonRefNotPermitted,
+		gatewayv1.RouteReasonInvalidKind,
+	) {
+		return apiv2.ReasonUnresolvedReference
+	}
+	return apiv2.ReasonTranslationFailed
+}
+
+// newObject returns an empty object of kind, for the resources the provider reports on.
+func newObject(kind string) client.Object {
+	switch kind {
+	case types.KindHTTPRoute:
+		return &gatewayv1.HTTPRoute{}
+	case types.KindGRPCRoute:
+		return &gatewayv1.GRPCRoute{}
+	case types.KindTCPRoute:
+		return &gatewayv1.TCPRoute{}
+	case types.KindUDPRoute:
+		return &gatewayv1.UDPRoute{}
+	case types.KindTLSRoute:
+		return &gatewayv1.TLSRoute{}
+	case types.KindGateway:
+		return &gatewayv1.Gateway{}
+	case types.KindIngress:
+		return &netv1.Ingress{}
+	case types.KindApisixRoute:
+		return &apiv2.ApisixRoute{}
+	case types.KindApisixGlobalRule:
+		return &apiv2.ApisixGlobalRule{}
+	case types.KindApisixTls:
+		return &apiv2.ApisixTls{}
+	case types.KindApisixConsumer:
+		return &apiv2.ApisixConsumer{}
+	case types.KindConsumer:
+		return &v1alpha1.Consumer{}
+	case types.KindGatewayProxy:
+		return &v1alpha1.GatewayProxy{}
+	default:
+		return nil
+	}
+}
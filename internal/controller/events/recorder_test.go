@@ -0,0 +1,108 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	apiv2 "github.com/apache/apisix-ingress-controller/api/v2"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+func drain(events chan string) []string {
+	var out []string
+	for {
+		select {
+		case e := <-events:
+			out = append(out, e)
+		default:
+			return out
+		}
+	}
+}
+
+func TestRecorderHoldsBackRepeatedEvents(t *testing.T) {
+	fakeRecorder := record.NewFakeRecorder(100)
+	r := NewRecorder(fakeRecorder, nil)
+	now := time.Now()
+	r.now = func() time.Time { return now }
+	route := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "httpbin"}}
+
+	r.Warning(route, apiv2.ReasonUnresolvedReference, "Service %s not found", "default/httpbin")
+	r.Warning(route, apiv2.ReasonUnresolvedReference, "Service %s not found", "default/httpbin")
+	r.Warning(route, apiv2.ReasonUnresolvedReference, "Service %s not found", "default/other")
+	assert.Equal(t, []string{
+		"Warning UnresolvedReference Service default/httpbin not found",
+		"Warning UnresolvedReference Service default/other not found",
+	}, drain(fakeRecorder.Events))
+
+	now = now.Add(DefaultInterval)
+	r.Warning(route, apiv2.ReasonUnresolvedReference, "Service %s not found", "default/httpbin")
+	assert.Len(t, drain(fakeRecorder.Events), 1, "the same event is reported again after an interval")
+}
+
+func TestRecorderLimitsEventsPerResource(t *testing.T) {
+	fakeRecorder := record.NewFakeRecorder(100)
+	r := NewRecorder(fakeRecorder, nil)
+	route := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "httpbin"}}
+	other := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "other"}}
+
+	for i := range 2 * DefaultBurst {
+		r.Warning(route, apiv2.ReasonTranslationFailed, "attempt %d", i)
+	}
+	r.Normal(other, apiv2.ReasonProgrammed, "generation 1 was pushed")
+	assert.Len(t, drain(fakeRecorder.Events), DefaultBurst+1)
+}
+
+func TestRecorderWarningForReadsTheResource(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+	route := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "httpbin"}}
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(route).Build()
+	fakeRecorder := record.NewFakeRecorder(100)
+	r := NewRecorder(fakeRecorder, reader)
+
+	nnk := types.NamespacedNameKind{Kind: types.KindHTTPRoute, Namespace: "default", Name: "httpbin"}
+	r.WarningFor(context.Background(), nnk, apiv2.ReasonSyncFailed, "rejected")
+	nnk.Name = "gone"
+	r.WarningFor(context.Background(), nnk, apiv2.ReasonSyncFailed, "rejected")
+	assert.Equal(t, []string{"Warning SyncFailed rejected"}, drain(fakeRecorder.Events))
+
+	var nilRecorder *Recorder
+	nilRecorder.Warning(route, apiv2.ReasonSyncFailed, "rejected")
+}
+
+func TestReasonFor(t *testing.T) {
+	notFound := k8serrors.NewNotFound(schema.GroupResource{Resource: "services"}, "httpbin")
+	assert.Equal(t, apiv2.ReasonUnresolvedReference, ReasonFor(notFound))
+	assert.Equal(t, apiv2.ReasonUnresolvedReference, ReasonFor(types.NewInvalidKindError("Pod")))
+	assert.Equal(t, apiv2.ReasonTranslationFailed, ReasonFor(errors.New("duplicate route rule name")))
+}
@@ -39,6 +39,8 @@ import (
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	apiv2 "github.com/apache/apisix-ingress-controller/api/v2"
+	"github.com/apache/apisix-ingress-controller/internal/controller/events"
 	"github.com/apache/apisix-ingress-controller/internal/controller/indexer"
 	"github.com/apache/apisix-ingress-controller/internal/controller/status"
 	"github.com/apache/apisix-ingress-controller/internal/manager/readiness"
@@ -60,6 +62,7 @@ type GRPCRouteReconciler struct { //nolint:revive
 
 	Updater status.Updater
 	Readier readiness.ReadinessManager
+	Events  *events.Recorder
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -229,6 +232,13 @@ func (r *GRPCRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	ProcessBackendTrafficPolicy(r.Client, r.Log, tctx)
 
+	if !acceptStatus.status {
+		r.Events.Warning(gr, apiv2.ReasonTranslationFailed, "%s", acceptStatus.msg)
+	}
+	if backendRefErr != nil {
+		r.Events.Warning(gr, apiv2.ReasonUnresolvedReference, "%s", backendRefErr)
+	}
+
 	// TODO: diff the old and new status
 	gr.Status.Parents = make([]gatewayv1.RouteParentStatus, 0, len(gateways))
 	for _, gateway := range gateways {
@@ -254,6 +264,7 @@ func (r *GRPCRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			}
 			hCopy := h.DeepCopy()
 			hCopy.Status = gr.Status
+			hCopy.Status.Parents = carryRouteConditionProgrammed(h.Status.Parents, gr.Status.Parents, gr.GetGeneration())
 			return hCopy
 		}),
 	})
@@ -262,6 +273,7 @@ func (r *GRPCRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	if isRouteAccepted(gateways) && err == nil {
 		routeToUpdate := gr
 		if err := r.Provider.Update(ctx, tctx, routeToUpdate); err != nil {
+			r.Events.Warning(gr, apiv2.ReasonTranslationFailed, "%s", err)
 			return ctrl.Result{}, err
 		}
 	}
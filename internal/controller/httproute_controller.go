@@ -42,6 +42,8 @@ import (
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	apiv2 "github.com/apache/apisix-ingress-controller/api/v2"
+	"github.com/apache/apisix-ingress-controller/internal/controller/events"
 	"github.com/apache/apisix-ingress-controller/internal/controller/indexer"
 	"github.com/apache/apisix-ingress-controller/internal/controller/status"
 	"github.com/apache/apisix-ingress-controller/internal/manager/readiness"
@@ -63,6 +65,7 @@ type HTTPRouteReconciler struct { //nolint:revive
 
 	Updater status.Updater
 	Readier readiness.ReadinessManager
+	Events  *events.Recorder
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -222,6 +225,13 @@ func (r *HTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		acceptStatus.msg = err.Error()
 	}
 
+	if !acceptStatus.status {
+		r.Events.Warning(hr, apiv2.ReasonTranslationFailed, "%s", acceptStatus.msg)
+	}
+	if backendRefErr != nil {
+		r.Events.Warning(hr, apiv2.ReasonUnresolvedReference, "%s", backendRefErr)
+	}
+
 	// TODO: diff the old and new status
 	hr.Status.Parents = make([]gatewayv1.RouteParentStatus, 0, len(gateways))
 	for _, gateway := range gateways {
@@ -247,6 +257,7 @@ func (r *HTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			}
 			hCopy := h.DeepCopy()
 			hCopy.Status = hr.Status
+			hCopy.Status.Parents = carryRouteConditionProgrammed(h.Status.Parents, hr.Status.Parents, hr.GetGeneration())
 			return hCopy
 		}),
 	})
@@ -259,6 +270,7 @@ func (r *HTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			routeToUpdate = filteredHTTPRoute
 		}
 		if err := r.Provider.Update(ctx, tctx, routeToUpdate); err != nil {
+			r.Events.Warning(hr, apiv2.ReasonTranslationFailed, "%s", err)
 			return ctrl.Result{}, err
 		}
 	}
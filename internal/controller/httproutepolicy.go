@@ -20,7 +20,9 @@ package controller
 import (
 	"cmp"
 	"context"
+	"maps"
 	"slices"
+	"strings"
 
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -30,6 +32,7 @@ import (
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	apiv2 "github.com/apache/apisix-ingress-controller/api/v2"
 	"github.com/apache/apisix-ingress-controller/internal/controller/indexer"
 	"github.com/apache/apisix-ingress-controller/internal/controller/status"
 	"github.com/apache/apisix-ingress-controller/internal/provider"
@@ -72,6 +75,11 @@ func (r *HTTPRouteReconciler) processHTTPRoutePolicies(tctx *provider.TranslateC
 		}
 	}
 
+	if len(conflicts) > 0 {
+		r.Events.Warning(httpRoute, apiv2.ReasonPolicyConflict,
+			"HTTPRoutePolicies %s conflict, so none of them is applied", policyNames(slices.Collect(maps.Values(conflicts))))
+	}
+
 	for i := range list.Items {
 		var (
 			policy         = list.Items[i]
@@ -154,6 +162,8 @@ func (r *IngressReconciler) processHTTPRoutePolicies(tctx *provider.TranslateCon
 		condition.Status = metav1.ConditionFalse
 		condition.Reason = string(gatewayv1.PolicyReasonConflicted)
 		condition.Message = "HTTPRoutePolicy conflict with others target to the Ingress"
+		r.Events.Warning(ingress, apiv2.ReasonPolicyConflict,
+			"HTTPRoutePolicies %s conflict, so none of them is applied", policyNames(list.Items))
 	} else {
 		tctx.HTTPRoutePolicies = list.Items
 	}
@@ -229,6 +239,16 @@ func setAncestorsForHTTPRoutePolicyStatus(parentRefs []gatewayv1.ParentReference
 	})
 }
 
+// policyNames lists the namespaced names of policies, sorted.
+func policyNames(policies []v1alpha1.HTTPRoutePolicy) string {
+	names := make([]string, 0, len(policies))
+	for _, policy := range policies {
+		names = append(names, utils.NamespacedName(&policy).String())
+	}
+	slices.Sort(names)
+	return strings.Join(names, ", ")
+}
+
 // checkPoliciesConflict determines if there is a conflict among the given HTTPRoutePolicy objects based on their priority values.
 // It returns true if any policy has a different priority than the first policy in the list, otherwise false.
 // An empty or single-element policies slice is considered non-conflicting.
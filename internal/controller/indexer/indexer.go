@@ -25,6 +25,7 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -494,6 +495,15 @@ func setupIngressIndexer(mgr ctrl.Manager) error {
 		return err
 	}
 
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&networkingv1.Ingress{},
+		ApisixUpstreamRef,
+		IngressApisixUpstreamIndexFunc,
+	); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -560,9 +570,37 @@ func IngressServiceIndexFunc(rawObj client.Object) []string {
 			services = append(services, key)
 		}
 	}
+	if backend := ingress.Spec.DefaultBackend; backend != nil && backend.Service != nil {
+		services = append(services, GenIndexKey(ns, backend.Service.Name))
+	}
 	return services
 }
 
+// IngressApisixUpstreamIndexFunc indexes the ApisixUpstreams referenced by the
+// Resource backends of an Ingress.
+func IngressApisixUpstreamIndexFunc(rawObj client.Object) (keys []string) {
+	ingress := rawObj.(*networkingv1.Ingress)
+	add := func(backend *networkingv1.IngressBackend) {
+		if backend == nil || backend.Resource == nil {
+			return
+		}
+		ref := backend.Resource
+		if ptr.Deref(ref.APIGroup, "") == apiv2.GroupVersion.Group && ref.Kind == internaltypes.KindApisixUpstream {
+			keys = append(keys, GenIndexKey(ingress.Namespace, ref.Name))
+		}
+	}
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for i := range rule.HTTP.Paths {
+			add(&rule.HTTP.Paths[i].Backend)
+		}
+	}
+	add(ingress.Spec.DefaultBackend)
+	return
+}
+
 func IngressSecretIndexFunc(rawObj client.Object) []string {
 	ingress := rawObj.(*networkingv1.Ingress)
 	secrets := make([]string, 0)
@@ -575,6 +613,12 @@ func IngressSecretIndexFunc(rawObj client.Object) []string {
 		key := GenIndexKey(ingress.Namespace, tls.SecretName)
 		secrets = append(secrets, key)
 	}
+
+	if ingress.Annotations[annotations.AnnotationsAuthType] == annotations.AuthTypeOpenIDConnect {
+		if name := ingress.Annotations[annotations.AnnotationsOIDCClientSecretRef]; name != "" {
+			secrets = append(secrets, GenIndexKey(ingress.Namespace, name))
+		}
+	}
 	return secrets
 }
 
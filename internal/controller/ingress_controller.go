@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"strings"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -43,7 +44,9 @@ import (
 
 	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
 	apiv2 "github.com/apache/apisix-ingress-controller/api/v2"
+	adctranslator "github.com/apache/apisix-ingress-controller/internal/adc/translator"
 	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations"
+	"github.com/apache/apisix-ingress-controller/internal/controller/events"
 	"github.com/apache/apisix-ingress-controller/internal/controller/indexer"
 	"github.com/apache/apisix-ingress-controller/internal/controller/status"
 	"github.com/apache/apisix-ingress-controller/internal/manager/readiness"
@@ -63,6 +66,7 @@ type IngressReconciler struct { //nolint:revive
 
 	Updater status.Updater
 	Readier readiness.ReadinessManager
+	Events  *events.Recorder
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -113,6 +117,12 @@ func (r *IngressReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&apiv2.ApisixPluginConfig{},
 			handler.EnqueueRequestsFromMapFunc(r.listIngressesForPluginConfig),
 		).
+		Watches(&apiv2.ApisixUpstream{},
+			handler.EnqueueRequestsFromMapFunc(r.listIngressesByApisixUpstream),
+		).
+		Watches(&networkingv1.Ingress{},
+			handler.EnqueueRequestsFromMapFunc(r.listIngressesForDefaultBackend),
+		).
 		WatchesRawSource(
 			source.Channel(
 				r.genericEvent,
@@ -123,7 +133,7 @@ func (r *IngressReconciler) SetupWithManager(mgr ctrl.Manager) error {
 }
 
 // Reconcile handles the reconciliation of Ingress resources
-func (r *IngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *IngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	defer r.Readier.Done(&networkingv1.Ingress{}, req.NamespacedName)
 	ingress := new(networkingv1.Ingress)
 	if err := r.Get(ctx, req.NamespacedName, ingress); err != nil {
@@ -165,6 +175,15 @@ func (r *IngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, nil
 	}
 
+	defer func() {
+		if err != nil {
+			r.Events.Warning(ingress, events.ReasonFor(err), "%s", err)
+		}
+	}()
+
+	// report the invalid annotations the translation ignores
+	r.reportInvalidAnnotations(ingress)
+
 	tctx.RouteParentRefs = append(tctx.RouteParentRefs, gatewayv1.ParentReference{
 		Group: ptr.To(gatewayv1.Group(ingressClass.GroupVersionKind().Group)),
 		Kind:  ptr.To(gatewayv1.Kind(KindIngressClass)),
@@ -177,12 +196,24 @@ func (r *IngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, err
 	}
 
+	// process the default backend, only one Ingress of the IngressClass owns it
+	if err := r.processDefaultBackend(tctx, ingress, ingressClass); err != nil {
+		r.Log.Error(err, "failed to process default backend", "ingress", ingress.Name)
+		return ctrl.Result{}, err
+	}
+
 	// process TLS configuration
 	if err := r.processTLS(tctx, ingress); err != nil {
 		r.Log.Error(err, "failed to process TLS configuration", "ingress", ingress.Name)
 		return ctrl.Result{}, err
 	}
 
+	// process the Secret holding the OpenID Connect client secret
+	if err := r.processOIDCSecret(tctx, ingress); err != nil {
+		r.Log.Error(err, "failed to process OpenID Connect client secret", "ingress", ingress.Name)
+		return ctrl.Result{}, err
+	}
+
 	// process backend services
 	if err := r.processBackends(tctx, ingress); err != nil {
 		r.Log.Error(err, "failed to process backend services", "ingress", ingress.Name)
@@ -240,39 +271,81 @@ func (r *IngressReconciler) listIngressForIngressClass(ctx context.Context, obj
 		return nil
 	}
 
-	// check if the ingress class is the default ingress class
-	if IsDefaultIngressClass(ingressClass) {
-		ingressList := &networkingv1.IngressList{}
-		if err := r.List(ctx, ingressList); err != nil {
-			r.Log.Error(err, "failed to list ingresses for ingress class", "ingressclass", ingressClass.GetName())
-			return nil
-		}
+	ingresses, err := ListIngressesForIngressClass(ctx, r.Client, ingressClass)
+	if err != nil {
+		r.Log.Error(err, "failed to list ingresses for ingress class", "ingressclass", ingressClass.GetName())
+		return nil
+	}
 
-		requests := make([]reconcile.Request, 0, len(ingressList.Items))
-		for i := range ingressList.Items {
-			ingress := &ingressList.Items[i]
-			effectiveClassName := internaltypes.GetEffectiveIngressClassName(ingress)
-			if effectiveClassName == "" || effectiveClassName == ingressClass.GetName() {
-				requests = append(requests, reconcile.Request{
-					NamespacedName: client.ObjectKey{
-						Namespace: ingress.GetNamespace(),
-						Name:      ingress.GetName(),
-					},
-				})
-			}
-		}
-		return requests
-	} else {
-		ingressList := &networkingv1.IngressList{}
-		if err := r.List(ctx, ingressList, client.MatchingFields{
-			indexer.IngressClassRef: ingressClass.GetName(),
-		}); err != nil {
-			r.Log.Error(err, "failed to list ingresses for ingress class", "ingressclass", ingressClass.GetName())
-			return nil
+	requests := make([]reconcile.Request, 0, len(ingresses))
+	for _, ingress := range ingresses {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKey{
+				Namespace: ingress.Namespace,
+				Name:      ingress.Name,
+			},
+		})
+	}
+	return requests
+}
+
+// listIngressesForDefaultBackend lists the other Ingresses of the same
+// IngressClass declaring a default backend, so that the ownership of the
+// catch-all route is re-evaluated when one of them changes or is deleted.
+func (r *IngressReconciler) listIngressesForDefaultBackend(ctx context.Context, obj client.Object) []reconcile.Request {
+	ingress, ok := obj.(*networkingv1.Ingress)
+	if !ok {
+		r.Log.Error(fmt.Errorf("unexpected object type"), "failed to convert object to Ingress")
+		return nil
+	}
+	if ingress.Spec.DefaultBackend == nil {
+		return nil
+	}
+
+	ingressClass, err := FindMatchingIngressClass(ctx, r.Client, r.Log, ingress)
+	if err != nil {
+		return nil
+	}
+	ingresses, err := ListIngressesForIngressClass(ctx, r.Client, ingressClass)
+	if err != nil {
+		r.Log.Error(err, "failed to list ingresses for ingress class", "ingressclass", ingressClass.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, item := range ingresses {
+		if item.Spec.DefaultBackend == nil || (item.Namespace == ingress.Namespace && item.Name == ingress.Name) {
+			continue
 		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKey{
+				Namespace: item.Namespace,
+				Name:      item.Name,
+			},
+		})
+	}
+	return requests
+}
+
+// listIngressesByApisixUpstream list all ingresses that use a specific ApisixUpstream as Resource backend
+func (r *IngressReconciler) listIngressesByApisixUpstream(ctx context.Context, obj client.Object) []reconcile.Request {
+	au, ok := obj.(*apiv2.ApisixUpstream)
+	if !ok {
+		r.Log.Error(fmt.Errorf("unexpected object type"), "failed to convert object to ApisixUpstream")
+		return nil
+	}
 
-		requests := make([]reconcile.Request, 0, len(ingressList.Items))
-		for _, ingress := range ingressList.Items {
+	ingressList := &networkingv1.IngressList{}
+	if err := r.List(ctx, ingressList, client.MatchingFields{
+		indexer.ApisixUpstreamRef: indexer.GenIndexKey(au.Namespace, au.Name),
+	}); err != nil {
+		r.Log.Error(err, "failed to list ingresses by ApisixUpstream", "ApisixUpstream", au.Name)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(ingressList.Items))
+	for _, ingress := range ingressList.Items {
+		if MatchesIngressClass(r.Client, r.Log, &ingress) {
 			requests = append(requests, reconcile.Request{
 				NamespacedName: client.ObjectKey{
 					Namespace: ingress.Namespace,
@@ -280,9 +353,8 @@ func (r *IngressReconciler) listIngressForIngressClass(ctx context.Context, obj
 				},
 			})
 		}
-
-		return requests
 	}
+	return requests
 }
 
 // listIngressesByService list all ingresses that use a specific service
@@ -520,30 +592,146 @@ func (r *IngressReconciler) processTLS(tctx *provider.TranslateContext, ingress
 	return nil
 }
 
+// processOIDCSecret process the Secret referenced by the openid-connect auth annotations
+func (r *IngressReconciler) processOIDCSecret(tctx *provider.TranslateContext, ingress *networkingv1.Ingress) error {
+	if ingress.Annotations[annotations.AnnotationsAuthType] != annotations.AuthTypeOpenIDConnect {
+		return nil
+	}
+	secretName := ingress.Annotations[annotations.AnnotationsOIDCClientSecretRef]
+	if secretName == "" {
+		return nil
+	}
+
+	secretNN := types.NamespacedName{Namespace: ingress.Namespace, Name: secretName}
+	var secret corev1.Secret
+	if err := r.Get(tctx, secretNN, &secret); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			// the translator reports the missing Secret and keeps the route unprogrammed
+			r.Log.Info("secret not found", "namespace", secretNN.Namespace, "name", secretNN.Name)
+			return nil
+		}
+		return err
+	}
+
+	// add the secret to the translate context
+	tctx.Secrets[secretNN] = &secret
+	return nil
+}
+
+// reportInvalidAnnotations reports the invalid annotations of the ingress that its
+// translation leaves out, which would otherwise only be logged.
+func (r *IngressReconciler) reportInvalidAnnotations(ingress *networkingv1.Ingress) {
+	errs := adctranslator.InvalidIngressAnnotations(ingress.Annotations)
+	if len(errs) == 0 {
+		return
+	}
+	msgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	r.Events.Warning(ingress, apiv2.ReasonInvalidAnnotation, "invalid annotations are ignored: %s", strings.Join(msgs, "; "))
+}
+
+// processDefaultBackend decides whether the defaultBackend of the ingress is
+// translated. Only the owner elected by FindIngressDefaultBackendOwner programs
+// the catch-all route of the IngressClass, the others are skipped.
+func (r *IngressReconciler) processDefaultBackend(tctx *provider.TranslateContext, ingress *networkingv1.Ingress, ingressClass *networkingv1.IngressClass) error {
+	if ingress.Spec.DefaultBackend == nil {
+		return nil
+	}
+	owner, err := FindIngressDefaultBackendOwner(tctx, r.Client, ingressClass, ingress)
+	if err != nil {
+		return err
+	}
+	if owner != nil && (owner.Namespace != ingress.Namespace || owner.Name != ingress.Name) {
+		r.Log.Info("default backend is owned by another ingress of the same ingress class, skipping it",
+			"ingress", utils.NamespacedName(ingress),
+			"owner", utils.NamespacedName(owner),
+			"ingressClass", ingressClass.Name,
+		)
+		r.Events.Warning(ingress, apiv2.ReasonDefaultBackendConflict,
+			"default backend is not applied, as Ingress %s owns the default backend of IngressClass %s",
+			utils.NamespacedName(owner), ingressClass.Name)
+		tctx.SkipIngressDefaultBackend = true
+	}
+	return nil
+}
+
 // processBackends process the backend services of the ingress
 func (r *IngressReconciler) processBackends(tctx *provider.TranslateContext, ingress *networkingv1.Ingress) error {
 	var terr error
 
+	ns := ingress.Namespace
+	if svcNs := ingress.Annotations[annotations.AnnotationsSvcNamespace]; svcNs != "" {
+		ns = svcNs
+	}
+	processBackend := func(backend *networkingv1.IngressBackend) {
+		var err error
+		switch {
+		case backend.Service != nil:
+			err = r.processBackendService(tctx, ns, backend.Service)
+		case backend.Resource != nil:
+			err = r.processBackendResource(tctx, ingress.Namespace, backend.Resource)
+		}
+		if err != nil {
+			terr = err
+		}
+	}
+
 	// process all the backend services in the rules
 	for _, rule := range ingress.Spec.Rules {
 		if rule.HTTP == nil {
 			continue
 		}
-		for _, path := range rule.HTTP.Paths {
-			if path.Backend.Service == nil {
+		for i := range rule.HTTP.Paths {
+			processBackend(&rule.HTTP.Paths[i].Backend)
+		}
+	}
+	if ingress.Spec.DefaultBackend != nil && !tctx.SkipIngressDefaultBackend {
+		processBackend(ingress.Spec.DefaultBackend)
+	}
+	return terr
+}
+
+// processBackendResource process a Resource backend, only ApisixUpstream with
+// externalNodes is supported
+func (r *IngressReconciler) processBackendResource(tctx *provider.TranslateContext, namespace string, ref *corev1.TypedLocalObjectReference) error {
+	if ptr.Deref(ref.APIGroup, "") != apiv2.GroupVersion.Group || ref.Kind != internaltypes.KindApisixUpstream {
+		r.Log.Info("unsupported resource backend", "namespace", namespace, "kind", ref.Kind, "name", ref.Name)
+		return nil
+	}
+
+	var (
+		au   apiv2.ApisixUpstream
+		auNN = types.NamespacedName{Namespace: namespace, Name: ref.Name}
+	)
+	if err := r.Get(tctx, auNN, &au); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			r.Log.Info("ApisixUpstream not found", "namespace", namespace, "name", ref.Name)
+			return nil
+		}
+		return err
+	}
+	tctx.Upstreams[auNN] = &au
+
+	for _, node := range au.Spec.ExternalNodes {
+		if node.Type != apiv2.ExternalTypeService {
+			continue
+		}
+		var (
+			service   corev1.Service
+			serviceNN = types.NamespacedName{Namespace: namespace, Name: node.Name}
+		)
+		if err := r.Get(tctx, serviceNN, &service); err != nil {
+			if client.IgnoreNotFound(err) == nil {
+				r.Log.Info("service in ApisixUpstream not found", "ApisixUpstream", auNN, "service", serviceNN)
 				continue
 			}
-			service := path.Backend.Service
-			ns := ingress.Namespace
-			if svcNs := ingress.Annotations[annotations.AnnotationsSvcNamespace]; svcNs != "" {
-				ns = ingress.Annotations[annotations.AnnotationsSvcNamespace]
-			}
-			if err := r.processBackendService(tctx, ns, service); err != nil {
-				terr = err
-			}
+			return err
 		}
+		tctx.Services[serviceNN] = &service
 	}
-	return terr
+	return nil
 }
 
 // processBackendService process a single backend service
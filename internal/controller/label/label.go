@@ -18,6 +18,8 @@
 package label
 
 import (
+	"strings"
+
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/apache/apisix-ingress-controller/internal/controller/config"
@@ -31,6 +33,11 @@ const (
 	LabelNamespace      = "k8s/namespace"
 	LabelControllerName = "k8s/controller-name"
 	LabelManagedBy      = "manager-by"
+
+	// LabelPolicyPrefix starts the key of a label recording that a policy shaped the
+	// object, such as a BackendTrafficPolicy its upstream follows. The rest of the key
+	// is <kind>/<namespace>/<name> of the policy.
+	LabelPolicyPrefix = "k8s/policy/"
 )
 
 func GenLabel(client client.Object, args ...string) Label {
@@ -56,3 +63,29 @@ func GenLabelWithObjectLabels(obj client.Object, args ...string) Label {
 	}
 	return label
 }
+
+// WithPolicy returns labels plus the label recording that the policy of kind, namespace
+// and name shaped the object. Translators share one set of labels between the objects
+// they build from a resource, so labels is copied rather than modified.
+func WithPolicy(labels map[string]string, kind, namespace, name string) map[string]string {
+	out := make(map[string]string, len(labels)+1)
+	for key, value := range labels {
+		out[key] = value
+	}
+	out[LabelPolicyPrefix+kind+"/"+namespace+"/"+name] = "true"
+	return out
+}
+
+// ParsePolicy returns the kind, namespace and name of the policy a label key records,
+// or false if key does not record one.
+func ParsePolicy(key string) (kind, namespace, name string, ok bool) {
+	rest, found := strings.CutPrefix(key, LabelPolicyPrefix)
+	if !found {
+		return "", "", "", false
+	}
+	parts := strings.Split(rest, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+		return "", "", "", false
+	}
+	return parts[0], parts[1], parts[2], true
+}
@@ -74,3 +74,23 @@ func TestGenLabel_IgnoresDanglingKeyArg(t *testing.T) {
 	require.Equal(t, "payments", labels["team"])
 	require.NotContains(t, labels, "dangling")
 }
+
+func TestWithPolicy(t *testing.T) {
+	shared := map[string]string{LabelKind: "HTTPRoute", LabelNamespace: "default", LabelName: "demo"}
+
+	labels := WithPolicy(shared, "BackendTrafficPolicy", "default", "retries")
+
+	require.Len(t, shared, 3, "the shared labels are left alone")
+	require.Equal(t, "HTTPRoute", labels[LabelKind])
+	key := "k8s/policy/BackendTrafficPolicy/default/retries"
+	require.Contains(t, labels, key)
+
+	kind, namespace, name, ok := ParsePolicy(key)
+	require.True(t, ok)
+	require.Equal(t, []string{"BackendTrafficPolicy", "default", "retries"}, []string{kind, namespace, name})
+
+	_, _, _, ok = ParsePolicy(LabelKind)
+	require.False(t, ok)
+	_, _, _, ok = ParsePolicy(LabelPolicyPrefix + "BackendTrafficPolicy/retries")
+	require.False(t, ok)
+}
@@ -338,6 +338,7 @@ func (r *TLSRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 			}
 			tCopy := t.DeepCopy()
 			tCopy.Status = tr.Status
+			tCopy.Status.Parents = carryRouteConditionProgrammed(t.Status.Parents, tr.Status.Parents, tr.GetGeneration())
 			return tCopy
 		}),
 	})
@@ -346,6 +346,7 @@ func (r *UDPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 			}
 			tCopy := t.DeepCopy()
 			tCopy.Status = tr.Status
+			tCopy.Status.Parents = carryRouteConditionProgrammed(t.Status.Parents, tr.Status.Parents, tr.GetGeneration())
 			return tCopy
 		}),
 	})
@@ -314,6 +314,54 @@ func SetRouteParentRef(routeParentStatus *gatewayv1.RouteParentStatus, gatewayNa
 	routeParentStatus.ControllerName = gatewayv1.GatewayController(config.ControllerConfig.ControllerName)
 }
 
+// carryRouteConditionProgrammed returns desired, the parent statuses a reconcile of
+// generation worked out, with the Programmed condition of each parent that accepted the
+// route, see programmedCondition.
+func carryRouteConditionProgrammed(live, desired []gatewayv1.RouteParentStatus, generation int64) []gatewayv1.RouteParentStatus {
+	parents := make([]gatewayv1.RouteParentStatus, 0, len(desired))
+	for _, parent := range desired {
+		if meta.IsStatusConditionTrue(parent.Conditions, string(gatewayv1.RouteConditionAccepted)) {
+			var liveConditions []metav1.Condition
+			for _, l := range live {
+				if reflect.DeepEqual(l.ParentRef, parent.ParentRef) {
+					liveConditions = l.Conditions
+				}
+			}
+			parent.Conditions = MergeCondition(parent.Conditions, programmedCondition(liveConditions, generation))
+		}
+		parents = append(parents, parent)
+	}
+	return parents
+}
+
+// carryApisixCRDConditionProgrammed returns desired, the status a reconcile of generation
+// worked out, with the Programmed condition if it accepted the object, see
+// programmedCondition.
+func carryApisixCRDConditionProgrammed(live, desired apiv2.ApisixStatus, generation int64) apiv2.ApisixStatus {
+	if meta.IsStatusConditionTrue(desired.Conditions, string(apiv2.ConditionTypeAccepted)) {
+		desired.Conditions = MergeCondition(desired.Conditions, programmedCondition(live.Conditions, generation))
+	}
+	return desired
+}
+
+// programmedCondition is the Programmed condition of generation: the one in live, which
+// only the provider sets once a sync pushed generation or failed to, or else a pending one
+// until it does. A reconcile has nothing to say about the data plane itself.
+func programmedCondition(live []metav1.Condition, generation int64) metav1.Condition {
+	if condition := meta.FindStatusCondition(live, string(apiv2.ConditionTypeProgrammed)); condition != nil &&
+		condition.ObservedGeneration == generation {
+		return *condition
+	}
+	return metav1.Condition{
+		Type:               string(apiv2.ConditionTypeProgrammed),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             string(apiv2.ConditionReasonPending),
+		Message:            fmt.Sprintf("waiting for generation %d to be pushed to the data plane", generation),
+	}
+}
+
 // parentRefTargetsListenerExplicitly reports whether a parentRef names a
 // specific listener, via a non-empty sectionName or an explicit port. It is only
 // meaningful for a parentRef that already matched a listener on its Gateway.
@@ -1808,6 +1856,90 @@ func FindMatchingIngressClassByName(ctx context.Context, c client.Client, log lo
 	return nil, errors.New("ingress class is not controlled by us")
 }
 
+// ListIngressesForIngressClass lists the Ingresses that are handled by the
+// given IngressClass, including the Ingresses without a class name when it is
+// the default IngressClass.
+func ListIngressesForIngressClass(ctx context.Context, c client.Client, ingressClass *networkingv1.IngressClass) ([]networkingv1.Ingress, error) {
+	var ingressList networkingv1.IngressList
+	if !IsDefaultIngressClass(ingressClass) {
+		if err := c.List(ctx, &ingressList, client.MatchingFields{
+			indexer.IngressClassRef: ingressClass.GetName(),
+		}); err != nil {
+			return nil, err
+		}
+		return ingressList.Items, nil
+	}
+
+	if err := c.List(ctx, &ingressList); err != nil {
+		return nil, err
+	}
+	ingresses := make([]networkingv1.Ingress, 0, len(ingressList.Items))
+	for _, ingress := range ingressList.Items {
+		effectiveClassName := types.GetEffectiveIngressClassName(&ingress)
+		if effectiveClassName == "" || effectiveClassName == ingressClass.GetName() {
+			ingresses = append(ingresses, ingress)
+		}
+	}
+	return ingresses, nil
+}
+
+// FindIngressDefaultBackendOwner returns the Ingress whose defaultBackend is
+// programmed for the IngressClass, considering candidate alongside the stored
+// Ingresses. Only one catch-all route is programmed per IngressClass: the
+// oldest Ingress wins, ties are broken by namespace/name. An Ingress which is
+// not created yet has no creation timestamp and is treated as the newest.
+func FindIngressDefaultBackendOwner(
+	ctx context.Context,
+	c client.Client,
+	ingressClass *networkingv1.IngressClass,
+	candidate *networkingv1.Ingress,
+) (*networkingv1.Ingress, error) {
+	ingresses, err := ListIngressesForIngressClass(ctx, c, ingressClass)
+	if err != nil {
+		return nil, err
+	}
+
+	var owner *networkingv1.Ingress
+	consider := func(ingress *networkingv1.Ingress) {
+		if ingress.Spec.DefaultBackend == nil || !ingress.DeletionTimestamp.IsZero() {
+			return
+		}
+		if owner == nil || compareIngressDefaultBackendPrecedence(ingress, owner) < 0 {
+			owner = ingress
+		}
+	}
+	if candidate != nil {
+		consider(candidate)
+	}
+	for i := range ingresses {
+		ingress := &ingresses[i]
+		if candidate != nil && ingress.Namespace == candidate.Namespace && ingress.Name == candidate.Name {
+			continue
+		}
+		consider(ingress)
+	}
+	return owner, nil
+}
+
+// compareIngressDefaultBackendPrecedence returns a negative number when a
+// takes precedence over b for the default backend of an IngressClass.
+func compareIngressDefaultBackendPrecedence(a, b *networkingv1.Ingress) int {
+	aCreated, bCreated := a.CreationTimestamp, b.CreationTimestamp
+	switch {
+	case aCreated.IsZero() != bCreated.IsZero():
+		if aCreated.IsZero() {
+			return 1
+		}
+		return -1
+	case !aCreated.Equal(&bCreated):
+		if aCreated.Before(&bCreated) {
+			return -1
+		}
+		return 1
+	}
+	return cmp.Or(strings.Compare(a.Namespace, b.Namespace), strings.Compare(a.Name, b.Name))
+}
+
 // distinctRequests distinct the requests
 func distinctRequests(requests []reconcile.Request) []reconcile.Request {
 	uniqueRequests := make(map[string]reconcile.Request)
@@ -0,0 +1,119 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/apache/apisix-ingress-controller/internal/controller/events"
+	"github.com/apache/apisix-ingress-controller/internal/controller/indexer"
+	"github.com/apache/apisix-ingress-controller/internal/provider"
+)
+
+func TestFindIngressDefaultBackendOwner(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	ingressClass := &networkingv1.IngressClass{ObjectMeta: metav1.ObjectMeta{Name: "apisix"}}
+	now := time.Now()
+	newIngress := func(namespace, name string, created time.Time, withDefaultBackend bool) *networkingv1.Ingress {
+		ingress := &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         namespace,
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Spec: networkingv1.IngressSpec{IngressClassName: ptr.To("apisix")},
+		}
+		if withDefaultBackend {
+			ingress.Spec.DefaultBackend = &networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{Name: "fallback"},
+			}
+		}
+		return ingress
+	}
+
+	oldest := newIngress("ns-b", "oldest", now.Add(-time.Hour), true)
+	tieA := newIngress("ns-a", "tie", now, true)
+	tieB := newIngress("ns-b", "tie", now, true)
+	olderWithoutDefault := newIngress("ns-a", "rules-only", now.Add(-2*time.Hour), false)
+	otherClass := newIngress("ns-a", "other", now.Add(-3*time.Hour), true)
+	otherClass.Spec.IngressClassName = ptr.To("other")
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&networkingv1.Ingress{}, indexer.IngressClassRef, indexer.IngressClassRefIndexFunc).
+		WithObjects(oldest, tieA, tieB, olderWithoutDefault, otherClass).
+		Build()
+
+	t.Run("oldest Ingress of the class wins", func(t *testing.T) {
+		owner, err := FindIngressDefaultBackendOwner(context.Background(), cli, ingressClass, tieB)
+		require.NoError(t, err)
+		assert.Equal(t, "oldest", owner.Name)
+	})
+
+	t.Run("ties are broken by namespace and name", func(t *testing.T) {
+		stale := oldest.DeepCopy()
+		stale.Spec.DefaultBackend = nil
+		owner, err := FindIngressDefaultBackendOwner(context.Background(), cli, ingressClass, stale)
+		require.NoError(t, err)
+		assert.Equal(t, "ns-a", owner.Namespace)
+		assert.Equal(t, "tie", owner.Name)
+	})
+
+	t.Run("an Ingress being created is the newest", func(t *testing.T) {
+		candidate := newIngress("ns-0", "new", time.Time{}, true)
+		owner, err := FindIngressDefaultBackendOwner(context.Background(), cli, ingressClass, candidate)
+		require.NoError(t, err)
+		assert.Equal(t, "oldest", owner.Name)
+	})
+
+	t.Run("no Ingress declares a default backend", func(t *testing.T) {
+		owner, err := FindIngressDefaultBackendOwner(context.Background(), cli,
+			&networkingv1.IngressClass{ObjectMeta: metav1.ObjectMeta{Name: "unused"}}, nil)
+		require.NoError(t, err)
+		assert.Nil(t, owner)
+	})
+	t.Run("the Ingress that loses is told why", func(t *testing.T) {
+		recorder := record.NewFakeRecorder(1)
+		r := &IngressReconciler{
+			Client: cli,
+			Log:    logr.Discard(),
+			Events: events.NewRecorder(recorder, cli),
+		}
+		tctx := provider.NewDefaultTranslateContext(context.Background())
+		require.NoError(t, r.processDefaultBackend(tctx, tieB, ingressClass))
+
+		assert.True(t, tctx.SkipIngressDefaultBackend)
+		require.Len(t, recorder.Events, 1)
+		assert.Contains(t, <-recorder.Events, "DefaultBackendConflict")
+	})
+}
@@ -0,0 +1,106 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	apiv2 "github.com/apache/apisix-ingress-controller/api/v2"
+)
+
+func programmedParent(gateway string, accepted bool, conditions ...metav1.Condition) gatewayv1.RouteParentStatus {
+	parent := gatewayv1.RouteParentStatus{}
+	SetRouteParentRef(&parent, gateway, "default")
+	SetRouteConditionAccepted(&parent, 2, accepted, "")
+	for _, condition := range conditions {
+		parent.Conditions = MergeCondition(parent.Conditions, condition)
+	}
+	return parent
+}
+
+func programmed(generation int64) metav1.Condition {
+	return metav1.Condition{
+		Type:               string(apiv2.ConditionTypeProgrammed),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             string(apiv2.ConditionReasonProgrammed),
+		Message:            "pushed to GatewayProxy/default/apisix as version 3",
+	}
+}
+
+func TestCarryRouteConditionProgrammed(t *testing.T) {
+	live := []gatewayv1.RouteParentStatus{
+		programmedParent("current", true, programmed(2)),
+		programmedParent("stale", true, programmed(1)),
+	}
+	desired := []gatewayv1.RouteParentStatus{
+		programmedParent("current", true),
+		programmedParent("stale", true),
+		programmedParent("new", true),
+		programmedParent("rejected", false),
+	}
+
+	parents := carryRouteConditionProgrammed(live, desired, 2)
+	require.Len(t, parents, 4)
+
+	// The provider already pushed this generation: a reconcile of it keeps saying so.
+	condition := meta.FindStatusCondition(parents[0].Conditions, string(apiv2.ConditionTypeProgrammed))
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, programmed(2).Message, condition.Message)
+
+	// What was pushed is an older generation, or nothing yet.
+	for _, parent := range parents[1:3] {
+		condition := meta.FindStatusCondition(parent.Conditions, string(apiv2.ConditionTypeProgrammed))
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, string(apiv2.ConditionReasonPending), condition.Reason)
+		assert.Equal(t, int64(2), condition.ObservedGeneration)
+	}
+
+	// A parent that did not accept the route has nothing to program.
+	assert.Nil(t, meta.FindStatusCondition(parents[3].Conditions, string(apiv2.ConditionTypeProgrammed)))
+
+	for _, parent := range desired {
+		assert.Nil(t, meta.FindStatusCondition(parent.Conditions, string(apiv2.ConditionTypeProgrammed)),
+			"desired must be left as it is")
+	}
+}
+
+func TestCarryApisixCRDConditionProgrammed(t *testing.T) {
+	var desired apiv2.ApisixStatus
+	SetApisixCRDConditionAccepted(&desired, 2, nil)
+
+	status := carryApisixCRDConditionProgrammed(apiv2.ApisixStatus{
+		Conditions: []metav1.Condition{programmed(2)},
+	}, desired, 2)
+	assert.True(t, meta.IsStatusConditionTrue(status.Conditions, string(apiv2.ConditionTypeProgrammed)))
+
+	status = carryApisixCRDConditionProgrammed(apiv2.ApisixStatus{
+		Conditions: []metav1.Condition{programmed(2)},
+	}, desired, 3)
+	condition := meta.FindStatusCondition(status.Conditions, string(apiv2.ConditionTypeProgrammed))
+	require.NotNil(t, condition)
+	assert.Equal(t, string(apiv2.ConditionReasonPending), condition.Reason)
+}
@@ -21,7 +21,10 @@ import (
 	"context"
 
 	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	v1alpha1 "github.com/apache/apisix-ingress-controller/api/v1alpha1"
 	apiv2 "github.com/apache/apisix-ingress-controller/api/v2"
@@ -111,3 +114,130 @@ func PrepareApisixTlsForValidation(ctx context.Context, c client.Client, log log
 	}
 	return tctx, nil
 }
+
+// PrepareHTTPRouteForValidation builds the translate context of route the way its
+// reconciler does, and returns route with the hostnames its gateways accept. It returns
+// nil when no gateway accepts route, as nothing would be pushed for it. Errors that only
+// change the status of route are logged, since route is pushed regardless.
+func PrepareHTTPRouteForValidation(ctx context.Context, c client.Client, log logr.Logger, route *gatewayv1.HTTPRoute) (*provider.TranslateContext, *gatewayv1.HTTPRoute, error) {
+	gateways, err := ParseRouteParentRefs(ctx, c, log, route, route.Spec.ParentRefs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !isRouteAccepted(gateways) {
+		return nil, nil, nil
+	}
+	tctx := provider.NewDefaultTranslateContext(ctx)
+	tctx.RouteParentRefs = route.Spec.ParentRefs
+	processRouteGateways(c, log, tctx, route, gateways)
+
+	reconciler := &HTTPRouteReconciler{
+		Client: c,
+		Log:    log,
+	}
+	if err := reconciler.processHTTPRoute(tctx, route); err != nil {
+		log.V(1).Info("failed to process HTTPRoute", "error", err.Error())
+	}
+	if err := reconciler.processHTTPRoutePolicies(tctx, route); err != nil {
+		log.V(1).Info("failed to process HTTPRoutePolicy", "error", err.Error())
+	}
+	if err := reconciler.processHTTPRouteBackendRefs(tctx, utils.NamespacedName(route)); err != nil {
+		log.V(1).Info("failed to process HTTPRoute backend refs", "error", err.Error())
+	}
+	ProcessBackendTrafficPolicy(c, log, tctx)
+
+	filtered, err := filterHostnames(gateways, route.DeepCopy())
+	if err != nil {
+		return nil, nil, err
+	}
+	if filtered != nil {
+		route = filtered
+	}
+	return tctx, route, nil
+}
+
+// PrepareGRPCRouteForValidation builds the translate context of route the way its
+// reconciler does. See PrepareHTTPRouteForValidation.
+func PrepareGRPCRouteForValidation(ctx context.Context, c client.Client, log logr.Logger, route *gatewayv1.GRPCRoute) (*provider.TranslateContext, error) {
+	gateways, err := ParseRouteParentRefs(ctx, c, log, route, route.Spec.ParentRefs)
+	if err != nil {
+		return nil, err
+	}
+	if !isRouteAccepted(gateways) {
+		return nil, nil
+	}
+	tctx := provider.NewDefaultTranslateContext(ctx)
+	tctx.RouteParentRefs = route.Spec.ParentRefs
+	processRouteGateways(c, log, tctx, route, gateways)
+
+	reconciler := &GRPCRouteReconciler{
+		Client: c,
+		Log:    log,
+	}
+	if err := reconciler.processGRPCRoute(tctx, route); err != nil {
+		log.V(1).Info("failed to process GRPCRoute", "error", err.Error())
+	}
+	if err := reconciler.processGRPCRouteBackendRefs(tctx, utils.NamespacedName(route)); err != nil {
+		log.V(1).Info("failed to process GRPCRoute backend refs", "error", err.Error())
+	}
+	ProcessBackendTrafficPolicy(c, log, tctx)
+	return tctx, nil
+}
+
+// PrepareIngressForValidation builds the translate context of ingress the way its
+// reconciler does. It returns nil when no IngressClass of this controller matches ingress.
+func PrepareIngressForValidation(ctx context.Context, c client.Client, log logr.Logger, ingress *networkingv1.Ingress) (*provider.TranslateContext, error) {
+	tctx := provider.NewDefaultTranslateContext(ctx)
+
+	ingressClass, err := FindMatchingIngressClass(tctx, c, log, ingress)
+	if err != nil {
+		return nil, err
+	}
+	if ingressClass == nil {
+		return nil, nil
+	}
+	tctx.RouteParentRefs = append(tctx.RouteParentRefs, gatewayv1.ParentReference{
+		Group: ptr.To(gatewayv1.Group(ingressClass.GroupVersionKind().Group)),
+		Kind:  ptr.To(gatewayv1.Kind(KindIngressClass)),
+		Name:  gatewayv1.ObjectName(ingressClass.Name),
+	})
+	if err := ProcessIngressClassParameters(tctx, c, log, ingress, ingressClass); err != nil {
+		return nil, err
+	}
+
+	reconciler := &IngressReconciler{
+		Client: c,
+		Log:    log,
+	}
+	if err := reconciler.processDefaultBackend(tctx, ingress, ingressClass); err != nil {
+		return nil, err
+	}
+	if err := reconciler.processBackends(tctx, ingress); err != nil {
+		return nil, err
+	}
+	if err := reconciler.processPluginConfig(tctx, ingress); err != nil {
+		return nil, err
+	}
+	if err := reconciler.processHTTPRoutePolicies(tctx, ingress); err != nil {
+		return nil, err
+	}
+	ProcessBackendTrafficPolicy(c, log, tctx)
+	return tctx, nil
+}
+
+// processRouteGateways adds the GatewayProxies and listeners of the gateways of a route to
+// tctx, as the route reconcilers do.
+func processRouteGateways(c client.Client, log logr.Logger, tctx *provider.TranslateContext, route client.Object, gateways []RouteParentRefContext) {
+	rk := utils.NamespacedNameKind(route)
+	for _, gateway := range gateways {
+		if err := ProcessGatewayProxy(c, log, tctx, gateway.Gateway, rk); err != nil {
+			log.V(1).Info("failed to process GatewayProxy", "gateway", utils.NamespacedName(gateway.Gateway), "error", err.Error())
+		}
+		if len(gateway.Listeners) > 0 {
+			tctx.Listeners = appendListeners(tctx.Listeners, gateway.Listeners...)
+		} else if gateway.Listener != nil {
+			tctx.Listeners = appendListeners(tctx.Listeners, *gateway.Listener)
+		}
+		tctx.HasExplicitListenerMatch = tctx.HasExplicitListenerMatch || gateway.ExplicitListenerMatch
+	}
+}
@@ -34,6 +34,7 @@ import (
 	apiv2 "github.com/apache/apisix-ingress-controller/api/v2"
 	"github.com/apache/apisix-ingress-controller/internal/controller"
 	"github.com/apache/apisix-ingress-controller/internal/controller/config"
+	"github.com/apache/apisix-ingress-controller/internal/controller/events"
 	"github.com/apache/apisix-ingress-controller/internal/controller/indexer"
 	"github.com/apache/apisix-ingress-controller/internal/controller/status"
 	"github.com/apache/apisix-ingress-controller/internal/manager/readiness"
@@ -71,6 +72,7 @@ import (
 // CustomResourceDefinition
 // +kubebuilder:rbac:groups=apisix.apache.org,resources=pluginconfigs,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apisix.apache.org,resources=gatewayproxies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apisix.apache.org,resources=gatewayproxies/status,verbs=get;update
 // +kubebuilder:rbac:groups=apisix.apache.org,resources=consumers,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apisix.apache.org,resources=consumers/status,verbs=get;update
 // +kubebuilder:rbac:groups=apisix.apache.org,resources=backendtrafficpolicies,verbs=get;list;watch
@@ -107,7 +109,7 @@ type Controller interface {
 	SetupWithManager(mgr manager.Manager) error
 }
 
-func setupControllers(ctx context.Context, mgr manager.Manager, pro provider.Provider, updater status.Updater, readier readiness.ReadinessManager) ([]Controller, error) {
+func setupControllers(ctx context.Context, mgr manager.Manager, pro provider.Provider, updater status.Updater, readier readiness.ReadinessManager, recorder *events.Recorder) ([]Controller, error) {
 	setupLog := ctrl.LoggerFrom(ctx).WithName("setup")
 
 	if err := indexer.SetupAPIv1alpha1Indexer(mgr); err != nil {
@@ -116,14 +118,14 @@ func setupControllers(ctx context.Context, mgr manager.Manager, pro provider.Pro
 	}
 
 	runnables := []Controller{}
-	if controllers, err := setupGatewayAPIControllers(ctx, mgr, pro, updater, readier); err != nil {
+	if controllers, err := setupGatewayAPIControllers(ctx, mgr, pro, updater, readier, recorder); err != nil {
 		setupLog.Error(err, "failed to setup Gateway API controllers")
 		return nil, err
 	} else {
 		runnables = append(runnables, controllers...)
 	}
 
-	if controllers, err := setupAPIv2Controllers(ctx, mgr, pro, updater, readier); err != nil {
+	if controllers, err := setupAPIv2Controllers(ctx, mgr, pro, updater, readier, recorder); err != nil {
 		setupLog.Error(err, "failed to setup API v2 controllers")
 		return nil, err
 	} else {
@@ -140,7 +142,7 @@ func setupControllers(ctx context.Context, mgr manager.Manager, pro provider.Pro
 	return runnables, nil
 }
 
-func setupGatewayAPIControllers(ctx context.Context, mgr manager.Manager, pro provider.Provider, updater status.Updater, readier readiness.ReadinessManager) ([]Controller, error) {
+func setupGatewayAPIControllers(ctx context.Context, mgr manager.Manager, pro provider.Provider, updater status.Updater, readier readiness.ReadinessManager, recorder *events.Recorder) ([]Controller, error) {
 	if err := indexer.SetupGatewayAPIIndexer(mgr); err != nil {
 		return nil, err
 	}
@@ -168,6 +170,7 @@ func setupGatewayAPIControllers(ctx context.Context, mgr manager.Manager, pro pr
 			Provider: pro,
 			Updater:  updater,
 			Readier:  readier,
+			Events:   recorder,
 		},
 		&gatewayv1.GRPCRoute{}: &controller.GRPCRouteReconciler{
 			Client:   mgr.GetClient(),
@@ -176,6 +179,7 @@ func setupGatewayAPIControllers(ctx context.Context, mgr manager.Manager, pro pr
 			Provider: pro,
 			Updater:  updater,
 			Readier:  readier,
+			Events:   recorder,
 		},
 		&gatewayv1.TCPRoute{}: &controller.TCPRouteReconciler{
 			Client:   mgr.GetClient(),
@@ -223,7 +227,7 @@ func setupGatewayAPIControllers(ctx context.Context, mgr manager.Manager, pro pr
 	return runnables, nil
 }
 
-func setupAPIv2Controllers(ctx context.Context, mgr manager.Manager, pro provider.Provider, updater status.Updater, readier readiness.ReadinessManager) ([]Controller, error) {
+func setupAPIv2Controllers(ctx context.Context, mgr manager.Manager, pro provider.Provider, updater status.Updater, readier readiness.ReadinessManager, recorder *events.Recorder) ([]Controller, error) {
 	if err := indexer.SetupAPIv2Indexer(mgr); err != nil {
 		return nil, err
 	}
@@ -245,6 +249,7 @@ func setupAPIv2Controllers(ctx context.Context, mgr manager.Manager, pro provide
 			Provider: pro,
 			Updater:  updater,
 			Readier:  readier,
+			Events:   recorder,
 		},
 		&apiv2.ApisixGlobalRule{}: &controller.ApisixGlobalRuleReconciler{
 			Client:   mgr.GetClient(),
@@ -261,6 +266,7 @@ func setupAPIv2Controllers(ctx context.Context, mgr manager.Manager, pro provide
 			Provider: pro,
 			Updater:  updater,
 			Readier:  readier,
+			Events:   recorder,
 		},
 		&apiv2.ApisixConsumer{}: &controller.ApisixConsumerReconciler{
 			Client:   mgr.GetClient(),
@@ -40,11 +40,13 @@ import (
 	apiv2 "github.com/apache/apisix-ingress-controller/api/v2"
 	"github.com/apache/apisix-ingress-controller/internal/controller"
 	"github.com/apache/apisix-ingress-controller/internal/controller/config"
+	"github.com/apache/apisix-ingress-controller/internal/controller/events"
 	"github.com/apache/apisix-ingress-controller/internal/controller/status"
 	"github.com/apache/apisix-ingress-controller/internal/manager/readiness"
 	"github.com/apache/apisix-ingress-controller/internal/manager/server"
 	"github.com/apache/apisix-ingress-controller/internal/provider"
 	_ "github.com/apache/apisix-ingress-controller/internal/provider/init"
+	webhookv1 "github.com/apache/apisix-ingress-controller/internal/webhook/v1"
 	_ "github.com/apache/apisix-ingress-controller/pkg/metrics"
 	"github.com/apache/apisix-ingress-controller/pkg/utils"
 )
@@ -216,13 +218,32 @@ func Run(ctx context.Context, logger logr.Logger) error {
 		return err
 	}
 
+	// GetEventRecorderFor returns the legacy record.EventRecorder; the suggested
+	// GetEventRecorder uses the new events API with an incompatible interface.
+	recorder := events.NewRecorder(mgr.GetEventRecorderFor("apisix-ingress-controller"), mgr.GetClient()) //nolint:staticcheck
+
 	providerType := string(config.ControllerConfig.ProviderConfig.Type)
 
 	providerOptions := &provider.Options{
 		SyncTimeout:           config.ControllerConfig.ExecADCTimeout.Duration,
 		SyncPeriod:            config.ControllerConfig.ProviderConfig.SyncPeriod.Duration,
 		InitSyncDelay:         config.ControllerConfig.ProviderConfig.InitSyncDelay.Duration,
+		SyncBatchWindow:       config.ControllerConfig.ProviderConfig.BatchWindow.Duration,
+		SyncBatchMaxSize:      config.ControllerConfig.ProviderConfig.BatchMaxSize,
+		SyncConcurrency:       config.ControllerConfig.ProviderConfig.SyncConcurrency,
+		DefaultExecutor:       string(config.ControllerConfig.ProviderConfig.Executor),
+		StatePath:             config.ControllerConfig.ProviderConfig.StatePath,
 		ListenerPortMatchMode: config.ControllerConfig.ListenerPortMatchMode,
+		FileOutput:            config.ControllerConfig.ProviderConfig.File,
+		KubeClient:            mgr.GetClient(),
+		Events:                recorder,
+	}
+	for _, member := range config.ControllerConfig.ProviderConfig.Providers {
+		providerOptions.Providers = append(providerOptions.Providers, string(member))
+	}
+	if drift := config.ControllerConfig.ProviderConfig.DriftDetection; drift.Enable {
+		providerOptions.DriftCheckInterval = drift.Interval.Duration
+		providerOptions.DriftAutoHeal = drift.Mode == config.DriftDetectionModeHeal
 	}
 	provider, err := provider.New(providerType, logger, updater.Writer(), readier, providerOptions)
 	if err != nil {
@@ -230,9 +251,26 @@ func Run(ctx context.Context, logger logr.Logger) error {
 		return err
 	}
 
+	planner, canPlan := provider.(webhookv1.ConfigPlanner)
+	if canPlan {
+		webhookv1.SetConfigPlanner(planner)
+	}
+	if checker, ok := provider.(webhookv1.RouteConflictChecker); ok {
+		webhookv1.SetRouteConflictChecker(checker)
+	}
+
 	if cfg.EnableServer {
+		debugHandlers := server.Registrants{provider}
+		if canPlan {
+			planHandler, err := webhookv1.NewPlanHandler(mgr.GetClient(), mgr.GetScheme(), logger.WithName("plan"))
+			if err != nil {
+				setupLog.Error(err, "unable to create plan handler")
+				return err
+			}
+			debugHandlers = append(debugHandlers, planHandler)
+		}
 		srv := server.NewServer(config.ControllerConfig.ServerAddr)
-		srv.Register("/debug", provider)
+		srv.Register("/debug", debugHandlers)
 		if err := mgr.Add(srv); err != nil {
 			setupLog.Error(err, "unable to add debug server to manager")
 			return err
@@ -265,7 +303,7 @@ func Run(ctx context.Context, logger logr.Logger) error {
 	}
 
 	setupLog.Info("setting up controllers")
-	controllers, err := setupControllers(ctx, mgr, provider, updater.Writer(), readier)
+	controllers, err := setupControllers(ctx, mgr, provider, updater.Writer(), readier, recorder)
 	if err != nil {
 		setupLog.Error(err, "unable to set up controllers")
 		return err
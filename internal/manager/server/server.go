@@ -57,6 +57,15 @@ func (s *Server) Register(pathPrefix string, registrant provider.RegisterHandler
 	})
 }
 
+// Registrants registers each of its handlers under one path prefix.
+type Registrants []provider.RegisterHandler
+
+func (r Registrants) Register(pathPrefix string, mux *http.ServeMux) {
+	for _, registrant := range r {
+		registrant.Register(pathPrefix, mux)
+	}
+}
+
 func NewServer(addr string) *Server {
 	mux := http.NewServeMux()
 	return &Server{
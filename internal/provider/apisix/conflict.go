@@ -0,0 +1,132 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package apisix
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv2 "github.com/apache/apisix-ingress-controller/api/v2"
+	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
+	cutils "github.com/apache/apisix-ingress-controller/internal/controller/utils"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+// updateConflictConditions sets the Conflicted condition of every object whose routes
+// conflict with those of another object, explaining which route wins and why, and
+// clears it on the objects whose routes conflicted at the last sync but no longer do.
+// Only the conflicts of the objects in batches are looked up again, unless full is set;
+// only the objects whose conflicts changed, or that changed themselves, are updated.
+// Ingress has no conditions, its conflicts are only served on the debug server.
+func (d *apisixProvider) updateConflictConditions(full bool, batches []updateBatch) {
+	changed := make(map[types.NamespacedNameKind]bool)
+	if full || d.conflicts == nil {
+		conflicts, err := d.client.RouteConflicts()
+		if err != nil {
+			d.log.Error(err, "failed to find route conflicts")
+			return
+		}
+		d.conflicts = make(map[string][]cache.RouteConflict)
+		for _, conflict := range conflicts {
+			d.conflicts[conflict.Config] = append(d.conflicts[conflict.Config], conflict)
+		}
+		full = true
+	} else {
+		for _, batch := range batches {
+			owners := make([]cache.Owner, 0, len(batch.Objects))
+			for _, nnk := range batch.Objects {
+				owners = append(owners, cache.Owner{Kind: nnk.Kind, Namespace: nnk.Namespace, Name: nnk.Name})
+				changed[nnk] = true
+			}
+			found, err := d.client.OwnerRouteConflicts(batch.Config, owners)
+			if err != nil {
+				d.log.Error(err, "failed to find route conflicts", "config", batch.Config)
+				continue
+			}
+			kept := slices.DeleteFunc(d.conflicts[batch.Config], func(conflict cache.RouteConflict) bool {
+				return slices.ContainsFunc(owners, conflict.Involves)
+			})
+			d.conflicts[batch.Config] = append(kept, found...)
+		}
+	}
+
+	messages := make(map[types.NamespacedNameKind][]string)
+	for _, conflicts := range d.conflicts {
+		for _, conflict := range conflicts {
+			for _, owner := range []cache.Owner{conflict.Winner.Owner, conflict.Loser.Owner} {
+				nnk := types.NamespacedNameKind{Kind: owner.Kind, Namespace: owner.Namespace, Name: owner.Name}
+				messages[nnk] = append(messages[nnk], conflict.String())
+			}
+		}
+	}
+
+	conflicted := make(map[types.NamespacedNameKind]string, len(messages))
+	for nnk, msgs := range messages {
+		slices.Sort(msgs)
+		msg := strings.Join(msgs, "; ")
+		conflicted[nnk] = msg
+		previous, ok := d.conflicted[nnk]
+		if !ok {
+			d.events.WarningFor(context.Background(), nnk, apiv2.ReasonRouteConflict, "%s", msg)
+		}
+		if full || changed[nnk] || !ok || previous != msg {
+			d.updateStatus(nnk, conflictCondition(true, apiv2.ConditionReasonRouteConflict, msg))
+		}
+	}
+	for nnk := range d.conflicted {
+		if _, ok := conflicted[nnk]; !ok {
+			d.updateStatus(nnk, conflictCondition(false, apiv2.ConditionReasonNoConflict, ""))
+		}
+	}
+	d.conflicted = conflicted
+}
+
+func conflictCondition(conflicted bool, reason apiv2.ApisixRouteConditionReason, msg string) metav1.Condition {
+	status := metav1.ConditionFalse
+	if conflicted {
+		status = metav1.ConditionTrue
+	}
+	return metav1.Condition{
+		Type:               string(apiv2.ConditionTypeConflicted),
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             string(reason),
+		Message:            cutils.TruncateConditionMessage(msg),
+	}
+}
+
+// handleConflicts serves the route conflicts of every config, as JSON.
+func (d *apisixProvider) handleConflicts(w http.ResponseWriter, _ *http.Request) {
+	conflicts, err := d.client.RouteConflicts()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if conflicts == nil {
+		conflicts = []cache.RouteConflict{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(conflicts)
+}
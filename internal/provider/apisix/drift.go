@@ -0,0 +1,146 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package apisix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	adcclient "github.com/apache/apisix-ingress-controller/internal/adc/client"
+	"github.com/apache/apisix-ingress-controller/internal/controller/status"
+	cutils "github.com/apache/apisix-ingress-controller/internal/controller/utils"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+// runDriftDetector compares the data plane with the store every DriftCheckInterval,
+// until ctx is done.
+func (d *apisixProvider) runDriftDetector(ctx context.Context) {
+	ticker := time.NewTicker(d.DriftCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		reports := d.client.DetectDrift(ctx, d.DriftAutoHeal)
+		for _, report := range reports {
+			if report.Drifted() {
+				d.log.Info("data plane drifted", "config", report.Config, "server", report.Server,
+					"missing", report.Missing, "extra", report.Extra, "modified", report.Modified, "healed", report.Healed)
+			}
+		}
+		d.updateDriftConditions(reports)
+	}
+}
+
+// updateDriftConditions sets the DataPlaneInSync condition of every GatewayProxy that
+// was checked, from the reports of all of its endpoints.
+func (d *apisixProvider) updateDriftConditions(reports []adcclient.DriftReport) {
+	byGatewayProxy := make(map[types.NamespacedNameKind][]adcclient.DriftReport)
+	for _, report := range reports {
+		byGatewayProxy[report.GatewayProxy] = append(byGatewayProxy[report.GatewayProxy], report)
+	}
+
+	for nnk, reports := range byGatewayProxy {
+		condition := driftCondition(reports)
+		d.updater.Update(status.Update{
+			NamespacedName: nnk.NamespacedName(),
+			Resource:       &v1alpha1.GatewayProxy{},
+			Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
+				cp := obj.(*v1alpha1.GatewayProxy).DeepCopy()
+				condition.ObservedGeneration = cp.GetGeneration()
+				cp.Status.Conditions = cutils.MergeCondition(cp.Status.Conditions, condition)
+				return cp
+			}),
+		})
+	}
+}
+
+func driftCondition(reports []adcclient.DriftReport) metav1.Condition {
+	condition := metav1.Condition{
+		Type:   v1alpha1.GatewayProxyConditionDataPlaneInSync,
+		Status: metav1.ConditionTrue,
+		Reason: v1alpha1.GatewayProxyReasonInSync,
+	}
+
+	var drifted, healed, failed []string
+	for _, report := range reports {
+		summary := fmt.Sprintf("%s: %d missing, %d extra, %d modified",
+			report.Server, countDrift(report.Missing), countDrift(report.Extra), countDrift(report.Modified))
+		switch {
+		case report.Error != "":
+			failed = append(failed, fmt.Sprintf("%s: %s", report.Server, report.Error))
+		case report.Healed:
+			healed = append(healed, summary)
+		case report.Drifted():
+			drifted = append(drifted, summary)
+		}
+	}
+	switch {
+	case len(drifted) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = v1alpha1.GatewayProxyReasonDrifted
+		condition.Message = strings.Join(drifted, "; ")
+	case len(failed) > 0:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = v1alpha1.GatewayProxyReasonDriftCheckFailed
+		condition.Message = strings.Join(failed, "; ")
+	case len(healed) > 0:
+		condition.Message = "pushed again after drift on " + strings.Join(healed, "; ")
+	}
+	condition.Message = cutils.TruncateConditionMessage(condition.Message)
+	return condition
+}
+
+func countDrift(byCollection map[string][]string) int {
+	n := 0
+	for _, ids := range byCollection {
+		n += len(ids)
+	}
+	return n
+}
+
+// handleDrift serves what the drift detector last found, as JSON.
+func (d *apisixProvider) handleDrift(w http.ResponseWriter, _ *http.Request) {
+	reports := d.client.DriftReports()
+	if reports == nil {
+		reports = []adcclient.DriftReport{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(struct {
+		Enabled  bool                    `json:"enabled"`
+		AutoHeal bool                    `json:"autoHeal"`
+		Reports  []adcclient.DriftReport `json:"reports"`
+	}{
+		Enabled:  d.DriftCheckInterval > 0,
+		AutoHeal: d.DriftAutoHeal,
+		Reports:  reports,
+	})
+}
@@ -0,0 +1,99 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package apisix
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	adcclient "github.com/apache/apisix-ingress-controller/internal/adc/client"
+	"github.com/apache/apisix-ingress-controller/internal/controller/status"
+	cutils "github.com/apache/apisix-ingress-controller/internal/controller/utils"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+// endpointRecoveryInterval is how often endpoints that failed a sync are checked for
+// being due a catch-up; each one backs off on its own.
+const endpointRecoveryInterval = 5 * time.Second
+
+// runEndpointRecovery catches up the endpoints that failed a sync, until ctx is done.
+func (d *apisixProvider) runEndpointRecovery(ctx context.Context) {
+	ticker := time.NewTicker(endpointRecoveryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if d.client.CatchUpEndpoints(ctx) {
+			d.updateEndpointStatuses()
+			select {
+			case d.caughtUpCh <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// updateEndpointStatuses reports on every GatewayProxy how syncing to each of its
+// endpoints is going, when that changed since it was last reported.
+func (d *apisixProvider) updateEndpointStatuses() {
+	d.endpointsMu.Lock()
+	defer d.endpointsMu.Unlock()
+
+	current := make(map[types.NamespacedNameKind][]v1alpha1.GatewayProxyEndpointStatus)
+	for nnk, endpoints := range d.client.EndpointStatuses() {
+		current[nnk] = endpointStatuses(endpoints)
+	}
+	for nnk, endpoints := range current {
+		if equality.Semantic.DeepEqual(d.reportedEndpoints[nnk], endpoints) {
+			continue
+		}
+		d.updater.Update(status.Update{
+			NamespacedName: nnk.NamespacedName(),
+			Resource:       &v1alpha1.GatewayProxy{},
+			Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
+				cp := obj.(*v1alpha1.GatewayProxy).DeepCopy()
+				cp.Status.Endpoints = endpoints
+				return cp
+			}),
+		})
+	}
+	d.reportedEndpoints = current
+}
+
+func endpointStatuses(endpoints []adcclient.EndpointStatus) []v1alpha1.GatewayProxyEndpointStatus {
+	statuses := make([]v1alpha1.GatewayProxyEndpointStatus, 0, len(endpoints))
+	for _, ep := range endpoints {
+		statuses = append(statuses, v1alpha1.GatewayProxyEndpointStatus{
+			Address:            ep.Address,
+			State:              ep.State,
+			SyncedVersion:      ep.SyncedVersion,
+			Message:            cutils.TruncateConditionMessage(ep.LastError),
+			LastTransitionTime: &metav1.Time{Time: ep.LastTransitionTime.Truncate(time.Second)},
+		})
+	}
+	return statuses
+}
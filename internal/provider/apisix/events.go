@@ -0,0 +1,160 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package apisix
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	apiv2 "github.com/apache/apisix-ingress-controller/api/v2"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+	"github.com/apache/apisix-ingress-controller/internal/utils"
+)
+
+// pendingProgram is a generation of an object that was translated but has not reached
+// the data plane yet.
+type pendingProgram struct {
+	object  client.Object
+	configs []string
+}
+
+// programTracker holds the generations of objects waiting to reach the data plane, so
+// that reaching it for the first time is reported once per generation.
+type programTracker struct {
+	mu         sync.Mutex
+	pending    map[types.NamespacedNameKind]pendingProgram
+	programmed map[types.NamespacedNameKind]int64
+}
+
+func newProgramTracker() *programTracker {
+	return &programTracker{
+		pending:    make(map[types.NamespacedNameKind]pendingProgram),
+		programmed: make(map[types.NamespacedNameKind]int64),
+	}
+}
+
+// track waits for the generation of obj to reach the data plane of configs, unless it
+// already has.
+func (t *programTracker) track(obj client.Object, configs map[types.NamespacedNameKind]adctypes.Config) {
+	nnk := utils.NamespacedNameKind(obj)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if generation, ok := t.programmed[nnk]; ok && generation == obj.GetGeneration() {
+		return
+	}
+	names := make([]string, 0, len(configs))
+	for _, config := range configs {
+		names = append(names, config.Name)
+	}
+	slices.Sort(names)
+	t.pending[nnk] = pendingProgram{
+		object:  obj.DeepCopyObject().(client.Object),
+		configs: names,
+	}
+}
+
+func (t *programTracker) forget(nnk types.NamespacedNameKind) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.pending, nnk)
+	delete(t.programmed, nnk)
+}
+
+// take takes the objects waiting for configs that all synced, without failing for
+// the object.
+func (t *programTracker) take(synced []string, failed map[types.NamespacedNameKind][]string) []pendingProgram {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var done []pendingProgram
+	for nnk, pending := range t.pending {
+		if pending.syncedTo(synced, failed) {
+			done = append(done, pending)
+			t.programmed[nnk] = pending.object.GetGeneration()
+			delete(t.pending, nnk)
+		}
+	}
+	return done
+}
+
+// waiting returns, without taking them, the objects take would take.
+func (t *programTracker) waiting(synced []string, failed map[types.NamespacedNameKind][]string) []pendingProgram {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var waiting []pendingProgram
+	for _, pending := range t.pending {
+		if pending.syncedTo(synced, failed) {
+			waiting = append(waiting, pending)
+		}
+	}
+	return waiting
+}
+
+// syncedTo reports whether every config of the object synced, without failing for it.
+func (p pendingProgram) syncedTo(synced []string, failed map[types.NamespacedNameKind][]string) bool {
+	if _, ok := failed[utils.NamespacedNameKind(p.object)]; ok {
+		return false
+	}
+	return !slices.ContainsFunc(p.configs, func(name string) bool { return !slices.Contains(synced, name) })
+}
+
+// reportProgrammed reports the objects whose generation reached every endpoint of the
+// data plane of every config it belongs to for the first time, once the configs named
+// synced, and marks them programmed. An object that some endpoints of those configs do
+// not hold yet waits for them to be caught up, and is marked partially programmed.
+func (d *apisixProvider) reportProgrammed(synced []string) {
+	for _, pending := range d.programs.take(d.converged(synced), d.statusUpdateMap) {
+		if nnk := utils.NamespacedNameKind(pending.object); isProgrammedKind(nnk.Kind) {
+			d.updateStatus(nnk, d.programmedCondition(pending.object.GetGeneration(), slices.Clone(pending.configs)))
+		}
+		d.events.Normal(pending.object, apiv2.ReasonProgrammed,
+			"generation %d was pushed to %s", pending.object.GetGeneration(), strings.Join(pending.configs, ", "))
+	}
+	for _, pending := range d.programs.waiting(synced, d.statusUpdateMap) {
+		if nnk := utils.NamespacedNameKind(pending.object); isProgrammedKind(nnk.Kind) {
+			d.updateStatus(nnk, d.programmedCondition(pending.object.GetGeneration(), slices.Clone(pending.configs)))
+		}
+	}
+}
+
+// syncedConfigs returns the configs whose last sync did not fail.
+func (d *apisixProvider) syncedConfigs() []string {
+	var synced []string
+	for _, config := range d.client.ConfigManager.List() {
+		if _, ok := d.configFailures[config.Name]; !ok {
+			synced = append(synced, config.Name)
+		}
+	}
+	return synced
+}
+
+// reportSyncFailures reports the objects the data plane rejected, or rejects for another
+// reason than it did at the last sync.
+func (d *apisixProvider) reportSyncFailures(statusUpdateMap map[types.NamespacedNameKind][]string) {
+	for nnk, msgs := range statusUpdateMap {
+		message := strings.Join(msgs, "; ")
+		if last, ok := d.statusUpdateMap[nnk]; ok && strings.Join(last, "; ") == message {
+			continue
+		}
+		d.events.WarningFor(context.Background(), nnk, apiv2.ReasonSyncFailed, "%s", message)
+	}
+}
@@ -0,0 +1,84 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package apisix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+	"github.com/apache/apisix-ingress-controller/internal/utils"
+)
+
+func TestProgramTrackerReportsEachGenerationOnce(t *testing.T) {
+	tracker := newProgramTracker()
+	route := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "httpbin", Generation: 1}}
+	configs := map[types.NamespacedNameKind]adctypes.Config{
+		{Kind: "GatewayProxy", Namespace: "default", Name: "a"}: {Name: "GatewayProxy/default/a"},
+		{Kind: "GatewayProxy", Namespace: "default", Name: "b"}: {Name: "GatewayProxy/default/b"},
+	}
+
+	tracker.track(route, configs)
+	assert.Empty(t, tracker.take([]string{"GatewayProxy/default/a"}, nil), "one config has not synced yet")
+	failed := map[types.NamespacedNameKind][]string{utils.NamespacedNameKind(route): {"rejected"}}
+	assert.Empty(t, tracker.take([]string{"GatewayProxy/default/a", "GatewayProxy/default/b"}, failed))
+
+	done := tracker.take([]string{"GatewayProxy/default/a", "GatewayProxy/default/b"}, nil)
+	if assert.Len(t, done, 1) {
+		assert.Equal(t, []string{"GatewayProxy/default/a", "GatewayProxy/default/b"}, done[0].configs)
+	}
+
+	tracker.track(route, configs)
+	assert.Empty(t, tracker.take([]string{"GatewayProxy/default/a", "GatewayProxy/default/b"}, nil),
+		"a generation is reported once")
+
+	route.Generation = 2
+	tracker.track(route, configs)
+	assert.Len(t, tracker.take([]string{"GatewayProxy/default/a", "GatewayProxy/default/b"}, nil), 1)
+}
+
+func TestProgramTrackerWaitingLeavesObjectsPending(t *testing.T) {
+	tracker := newProgramTracker()
+	route := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "httpbin", Generation: 1}}
+	tracker.track(route, map[types.NamespacedNameKind]adctypes.Config{
+		{Kind: "GatewayProxy", Namespace: "default", Name: "a"}: {Name: "GatewayProxy/default/a"},
+	})
+
+	synced := []string{"GatewayProxy/default/a"}
+	assert.Empty(t, tracker.waiting(nil, nil), "the config has not synced yet")
+	assert.Len(t, tracker.waiting(synced, nil), 1)
+	assert.Len(t, tracker.waiting(synced, nil), 1, "waiting takes nothing")
+	assert.Len(t, tracker.take(synced, nil), 1)
+	assert.Empty(t, tracker.waiting(synced, nil))
+}
+
+func TestCurrentConditionsLeavesOutEarlierGenerations(t *testing.T) {
+	conditions := currentConditions(3, []metav1.Condition{
+		{Type: "Accepted"},
+		{Type: "Programmed", ObservedGeneration: 2},
+		{Type: "Conflicted", ObservedGeneration: 3},
+	})
+	assert.Equal(t, []metav1.Condition{
+		{Type: "Accepted", ObservedGeneration: 3},
+		{Type: "Conflicted", ObservedGeneration: 3},
+	}, conditions, "a generation the object moved on from says nothing about it")
+}
@@ -0,0 +1,251 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package apisix
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	adcclient "github.com/apache/apisix-ingress-controller/internal/adc/client"
+	"github.com/apache/apisix-ingress-controller/internal/controller/config"
+	"github.com/apache/apisix-ingress-controller/internal/controller/status"
+	cutils "github.com/apache/apisix-ingress-controller/internal/controller/utils"
+	"github.com/apache/apisix-ingress-controller/internal/provider"
+	"github.com/apache/apisix-ingress-controller/internal/utils"
+)
+
+// AnnotationConfigHash is set on a ConfigMap or Secret the file provider writes to the
+// SHA-256 of the configuration it holds, for a pipeline to tell versions apart.
+const AnnotationConfigHash = "apisix.apache.org/config-hash"
+
+const (
+	// labelManagedBy marks the ConfigMaps and Secrets the file provider writes. One that
+	// exists without it belongs to someone else, and is left alone.
+	labelManagedBy      = "app.kubernetes.io/managed-by"
+	labelManagedByValue = "apisix-ingress-controller"
+
+	// maxObjectDataSize is the most a ConfigMap or Secret can hold.
+	maxObjectDataSize = 1 << 20
+)
+
+// newFileExecutor creates the executor that writes configs out as o.FileOutput sets.
+func newFileExecutor(log logr.Logger, o provider.Options) (*adcclient.FileExecutor, error) {
+	output := o.FileOutput
+	var sink adcclient.FileSink
+	switch output.Target {
+	case config.FileOutputTargetDirectory, "":
+		if output.Directory == "" {
+			return nil, errors.New("no directory to write files to")
+		}
+		sink = &adcclient.DirectorySink{Dir: output.Directory}
+	case config.FileOutputTargetConfigMap, config.FileOutputTargetSecret:
+		if o.KubeClient == nil {
+			return nil, fmt.Errorf("no client to write %s objects with", output.Target)
+		}
+		sink = &objectSink{
+			client:    o.KubeClient,
+			namespace: output.Namespace,
+			key:       fileKey(output.Format),
+			secret:    output.Target == config.FileOutputTargetSecret,
+		}
+	default:
+		return nil, fmt.Errorf("unknown file target %q", output.Target)
+	}
+	return adcclient.NewFileExecutor(log, string(output.Format), sink), nil
+}
+
+func fileKey(format config.FileOutputFormat) string {
+	if format == config.FileOutputFormatADC {
+		return "adc.yaml"
+	}
+	return "apisix.yaml"
+}
+
+// objectSink writes each file as one key of a ConfigMap or Secret of its own.
+type objectSink struct {
+	client    client.Client
+	namespace string
+	key       string
+	secret    bool
+}
+
+func (s *objectSink) Write(ctx context.Context, name string, data []byte) (string, bool, error) {
+	var obj client.Object = &corev1.ConfigMap{}
+	kind := "ConfigMap"
+	if s.secret {
+		obj, kind = &corev1.Secret{}, "Secret"
+	}
+	location := kind + " " + s.namespace + "/" + name
+
+	if len(data) > maxObjectDataSize {
+		return location, false, fmt.Errorf("cannot write %s: the configuration is %d bytes, over the %d a %s can hold; write it to a directory instead",
+			location, len(data), maxObjectDataSize, kind)
+	}
+	err := s.client.Get(ctx, client.ObjectKey{Namespace: s.namespace, Name: name}, obj)
+	exists := err == nil
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return location, false, err
+	}
+	if exists && obj.GetLabels()[labelManagedBy] != labelManagedByValue {
+		return location, false, fmt.Errorf("refusing to overwrite %s: it exists without the %s=%s label",
+			location, labelManagedBy, labelManagedByValue)
+	}
+	if exists && s.holds(obj, data) {
+		return location, false, nil
+	}
+
+	obj.SetNamespace(s.namespace)
+	obj.SetName(name)
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[labelManagedBy] = labelManagedByValue
+	obj.SetLabels(labels)
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[AnnotationConfigHash] = hashOf(data)
+	obj.SetAnnotations(annotations)
+	switch o := obj.(type) {
+	case *corev1.ConfigMap:
+		o.Data = map[string]string{s.key: string(data)}
+	case *corev1.Secret:
+		o.Data = map[string][]byte{s.key: data}
+	}
+
+	if exists {
+		err = s.client.Update(ctx, obj)
+	} else {
+		err = s.client.Create(ctx, obj)
+	}
+	if err != nil {
+		return location, false, fmt.Errorf("failed to write %s: %w", location, err)
+	}
+	return location, true, nil
+}
+
+func (s *objectSink) holds(obj client.Object, data []byte) bool {
+	switch o := obj.(type) {
+	case *corev1.ConfigMap:
+		current, ok := o.Data[s.key]
+		return ok && len(o.Data) == 1 && current == string(data)
+	case *corev1.Secret:
+		current, ok := o.Data[s.key]
+		return ok && len(o.Data) == 1 && string(current) == string(data)
+	}
+	return false
+}
+
+func hashOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// gatewayProxyConfig translates gp to the config its resources are synced with. Written
+// to a file, a config needs no control plane, so every GatewayProxy has one.
+func (d *apisixProvider) gatewayProxyConfig(tctx *provider.TranslateContext, gp *v1alpha1.GatewayProxy) (*adctypes.Config, error) {
+	if d.files != nil {
+		return &adctypes.Config{Name: utils.NamespacedNameKind(gp).String()}, nil
+	}
+	return d.translator.TranslateGatewayProxyToConfig(tctx, gp, d.DefaultBackendMode)
+}
+
+// updateFileConditions sets the ConfigWritten condition of every GatewayProxy whose file
+// was written, or failed to be, since it was last reported.
+func (d *apisixProvider) updateFileConditions() {
+	if d.files == nil {
+		return
+	}
+	writes := d.files.Writes()
+	for name, write := range writes {
+		if last, ok := d.reportedFiles[name]; ok && last == write {
+			continue
+		}
+		condition := fileCondition(write)
+		d.updater.Update(status.Update{
+			NamespacedName: write.GatewayProxy.NamespacedName(),
+			Resource:       &v1alpha1.GatewayProxy{},
+			Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
+				cp := obj.(*v1alpha1.GatewayProxy).DeepCopy()
+				condition.ObservedGeneration = cp.GetGeneration()
+				cp.Status.Conditions = cutils.MergeCondition(cp.Status.Conditions, condition)
+				return cp
+			}),
+		})
+	}
+	d.reportedFiles = writes
+}
+
+func fileCondition(write adcclient.FileWrite) metav1.Condition {
+	if write.Error != "" {
+		return metav1.Condition{
+			Type:    v1alpha1.GatewayProxyConditionConfigWritten,
+			Status:  metav1.ConditionFalse,
+			Reason:  v1alpha1.GatewayProxyReasonWriteFailed,
+			Message: cutils.TruncateConditionMessage(write.Error),
+		}
+	}
+	return metav1.Condition{
+		Type:   v1alpha1.GatewayProxyConditionConfigWritten,
+		Status: metav1.ConditionTrue,
+		Reason: v1alpha1.GatewayProxyReasonWritten,
+		Message: fmt.Sprintf("written to %s at %s, sha256 %s",
+			write.Location, write.WrittenAt.UTC().Format(time.RFC3339), write.Hash),
+	}
+}
+
+// handleFiles serves how the last write of the file of every config went, as JSON.
+func (d *apisixProvider) handleFiles(w http.ResponseWriter, _ *http.Request) {
+	writes := []adcclient.FileWrite{}
+	if d.files != nil {
+		for _, write := range d.files.Writes() {
+			writes = append(writes, write)
+		}
+	}
+	slices.SortFunc(writes, func(a, b adcclient.FileWrite) int {
+		return strings.Compare(a.Config, b.Config)
+	})
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(struct {
+		Enabled bool                  `json:"enabled"`
+		Files   []adcclient.FileWrite `json:"files"`
+	}{
+		Enabled: d.files != nil,
+		Files:   writes,
+	})
+}
@@ -0,0 +1,91 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package apisix
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestObjectSinkWritesAConfigMapWhenItChanges(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	sink := &objectSink{client: kubeClient, namespace: "gitops", key: "apisix.yaml"}
+	key := client.ObjectKey{Namespace: "gitops", Name: "default.apisix"}
+
+	location, changed, err := sink.Write(context.Background(), key.Name, []byte("routes: []\n#END\n"))
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "ConfigMap gitops/default.apisix", location)
+
+	var cm corev1.ConfigMap
+	require.NoError(t, kubeClient.Get(context.Background(), key, &cm))
+	assert.Equal(t, "routes: []\n#END\n", cm.Data["apisix.yaml"])
+	firstHash := cm.Annotations[AnnotationConfigHash]
+	assert.Equal(t, hashOf([]byte("routes: []\n#END\n")), firstHash)
+
+	_, changed, err = sink.Write(context.Background(), key.Name, []byte("routes: []\n#END\n"))
+	require.NoError(t, err)
+	assert.False(t, changed, "a ConfigMap that holds the file already is not updated")
+
+	_, changed, err = sink.Write(context.Background(), key.Name, []byte("ssls: []\n#END\n"))
+	require.NoError(t, err)
+	assert.True(t, changed)
+	require.NoError(t, kubeClient.Get(context.Background(), key, &cm))
+	assert.Equal(t, "ssls: []\n#END\n", cm.Data["apisix.yaml"])
+	assert.NotEqual(t, firstHash, cm.Annotations[AnnotationConfigHash])
+}
+
+func TestObjectSinkLeavesObjectsItDoesNotManageAlone(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	theirs := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "gitops", Name: "default.apisix"},
+		Data:       map[string][]byte{"token": []byte("theirs")},
+	}
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(theirs).Build()
+	sink := &objectSink{client: kubeClient, namespace: "gitops", key: "apisix.yaml", secret: true}
+
+	_, changed, err := sink.Write(context.Background(), "default.apisix", []byte("routes: []\n#END\n"))
+	require.ErrorContains(t, err, "refusing to overwrite Secret gitops/default.apisix")
+	assert.False(t, changed)
+	var secret corev1.Secret
+	require.NoError(t, kubeClient.Get(context.Background(), client.ObjectKeyFromObject(theirs), &secret))
+	assert.Equal(t, theirs.Data, secret.Data)
+}
+
+func TestObjectSinkRefusesWhatAnObjectCannotHold(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	sink := &objectSink{client: kubeClient, namespace: "gitops", key: "apisix.yaml"}
+
+	_, changed, err := sink.Write(context.Background(), "default.apisix", make([]byte, maxObjectDataSize+1))
+	require.ErrorContains(t, err, "over the 1048576 a ConfigMap can hold")
+	assert.False(t, changed)
+}
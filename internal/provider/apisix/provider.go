@@ -19,8 +19,12 @@ package apisix
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -31,8 +35,10 @@ import (
 	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
 	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
 	apiv2 "github.com/apache/apisix-ingress-controller/api/v2"
+	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
 	adcclient "github.com/apache/apisix-ingress-controller/internal/adc/client"
 	"github.com/apache/apisix-ingress-controller/internal/adc/translator"
+	"github.com/apache/apisix-ingress-controller/internal/controller/events"
 	"github.com/apache/apisix-ingress-controller/internal/controller/label"
 	"github.com/apache/apisix-ingress-controller/internal/controller/status"
 	"github.com/apache/apisix-ingress-controller/internal/manager/readiness"
@@ -40,6 +46,7 @@ import (
 	"github.com/apache/apisix-ingress-controller/internal/provider/common"
 	"github.com/apache/apisix-ingress-controller/internal/types"
 	"github.com/apache/apisix-ingress-controller/internal/utils"
+	pkgmetrics "github.com/apache/apisix-ingress-controller/pkg/metrics"
 )
 
 const (
@@ -59,10 +66,39 @@ type apisixProvider struct {
 
 	updater         status.Updater
 	statusUpdateMap map[types.NamespacedNameKind][]string
+	// configFailures holds, per config, what its last sync failed for.
+	configFailures map[string]map[types.NamespacedNameKind][]string
+	// conflicts holds, per config, the route conflicts found at the last sync, and
+	// conflicted what each object whose routes conflicted was told about them.
+	conflicts  map[string][]cache.RouteConflict
+	conflicted map[types.NamespacedNameKind]string
 
 	readier readiness.ReadinessManager
 
 	syncCh chan struct{}
+	// caughtUpCh is notified when endpoints were caught up, for what they now hold to be
+	// reported.
+	caughtUpCh chan struct{}
+	queue      *updateQueue
+	// retrier retries each config that failed to sync on a backoff of its own.
+	retrier *common.KeyedRetrier
+
+	// endpointsMu guards reportedEndpoints, the endpoint statuses last set on each
+	// GatewayProxy.
+	endpointsMu       sync.Mutex
+	reportedEndpoints map[types.NamespacedNameKind][]v1alpha1.GatewayProxyEndpointStatus
+
+	// files writes every config out when the provider writes files, and is nil otherwise.
+	files *adcclient.FileExecutor
+	// reportedFiles holds the writes last reported on each GatewayProxy, by config name.
+	reportedFiles map[string]adcclient.FileWrite
+
+	events   *events.Recorder
+	programs *programTracker
+
+	// leading is set once Start runs, which only the leader does: until then the store
+	// holds no routes to check others against.
+	leading atomic.Bool
 
 	client *adcclient.Client
 	log    logr.Logger
@@ -77,24 +113,46 @@ func New(log logr.Logger, updater status.Updater, readier readiness.ReadinessMan
 
 	logger := log.WithName("provider")
 
-	cli, err := adcclient.New(logger, o.DefaultBackendMode, o.SyncTimeout)
+	cli, err := adcclient.New(logger, o.DefaultBackendMode, o.DefaultExecutor, o.SyncTimeout)
 	if err != nil {
 		return nil, err
 	}
+	cli.SyncConcurrency = o.SyncConcurrency
+
+	var files *adcclient.FileExecutor
+	if o.WriteFiles {
+		if files, err = newFileExecutor(logger, o); err != nil {
+			return nil, err
+		}
+		cli.UseFileExecutor(files)
+	}
 
 	return &apisixProvider{
 		client:     cli,
+		files:      files,
 		Options:    o,
 		translator: translator.NewTranslator(log, o.ListenerPortMatchMode),
 		updater:    updater,
 		readier:    readier,
 		syncCh:     make(chan struct{}, 1),
-		log:        logger,
+		caughtUpCh: make(chan struct{}, 1),
+		queue:      newUpdateQueue(o.SyncBatchWindow, o.SyncBatchMaxSize),
+		retrier: common.NewKeyedRetrier(func() common.Backoff {
+			return common.NewExponentialBackoff(RetryBaseDelay, RetryMaxDelay)
+		}),
+		configFailures: make(map[string]map[types.NamespacedNameKind][]string),
+		events:         o.Events,
+		programs:       newProgramTracker(),
+		log:            logger,
 	}, nil
 }
 
 func (d *apisixProvider) Register(pathPrefix string, mux *http.ServeMux) {
 	d.client.ADCDebugProvider.SetupHandler(pathPrefix, mux)
+	mux.HandleFunc("/drift", d.handleDrift)
+	mux.HandleFunc("/quarantine", d.handleQuarantine)
+	mux.HandleFunc("/conflicts", d.handleConflicts)
+	mux.HandleFunc("/files", d.handleFiles)
 }
 
 func (d *apisixProvider) Update(ctx context.Context, tctx *provider.TranslateContext, obj client.Object) error {
@@ -166,7 +224,8 @@ func (d *apisixProvider) Update(ctx context.Context, tctx *provider.TranslateCon
 		return nil
 	}
 
-	defer d.syncNotify()
+	// The configs the object leaves have it deleted, and are pushed too.
+	defer d.queue.Add(rk, configNames(d.client.ConfigManager.Get(rk), configs)...)
 
 	task := adcclient.Task{
 		Key:           rk,
@@ -184,7 +243,11 @@ func (d *apisixProvider) Update(ctx context.Context, tctx *provider.TranslateCon
 	}
 	d.log.V(1).Info("updating config", "task", task)
 
-	return d.client.UpdateConfig(ctx, task)
+	if err := d.client.UpdateConfig(ctx, task); err != nil {
+		return err
+	}
+	d.programs.track(obj, configs)
+	return nil
 }
 
 func (d *apisixProvider) Delete(ctx context.Context, obj client.Object) error {
@@ -217,6 +280,7 @@ func (d *apisixProvider) Delete(ctx context.Context, obj client.Object) error {
 		labels = label.GenLabel(obj)
 	}
 	nnk := utils.NamespacedNameKind(obj)
+	d.programs.forget(nnk)
 
 	// Full synchronization is performed on a gateway by gateway basis
 	// and it is not possible to perform scheduled synchronization
@@ -228,7 +292,7 @@ func (d *apisixProvider) Delete(ctx context.Context, obj client.Object) error {
 			Labels: labels,
 		})
 	}
-	defer d.syncNotify()
+	defer d.queue.Add(nnk, configNames(d.client.ConfigManager.Get(nnk))...)
 	return d.client.DeleteConfig(ctx, adcclient.Task{
 		Key:           nnk,
 		Name:          nnk.String(),
@@ -237,10 +301,23 @@ func (d *apisixProvider) Delete(ctx context.Context, obj client.Object) error {
 	})
 }
 
+// configNames returns the names of the configs in each of configs, sorted and without
+// duplicates.
+func configNames(configs ...map[types.NamespacedNameKind]adctypes.Config) []string {
+	var names []string
+	for _, byKey := range configs {
+		for _, config := range byKey {
+			names = append(names, config.Name)
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
 func (d *apisixProvider) buildConfig(tctx *provider.TranslateContext, nnk types.NamespacedNameKind) (map[types.NamespacedNameKind]adctypes.Config, error) {
 	configs := make(map[types.NamespacedNameKind]adctypes.Config, len(tctx.ResourceParentRefs[nnk]))
 	for _, gp := range tctx.GatewayProxies {
-		config, err := d.translator.TranslateGatewayProxyToConfig(tctx, &gp, d.DefaultResolveEndpoints)
+		config, err := d.gatewayProxyConfig(tctx, &gp)
 		if err != nil {
 			return nil, err
 		}
@@ -255,10 +332,19 @@ func (d *apisixProvider) Start(ctx context.Context) error {
 	// survives the manager container, the configuration it was derived from does not.
 	// Rebuild every baseline from the data plane before syncing from it.
 	d.client.InvalidateADCCache()
+	restored := d.loadState()
+	d.leading.Store(true)
 
 	d.log.Info("starting provider, waiting for readiness")
-	d.readier.WaitReady(ctx, 5*time.Minute)
+	ready := d.readier.WaitReady(ctx, 5*time.Minute)
 	d.log.Info("Ready detected, starting sync loop")
+	if restored {
+		if ready {
+			d.dropRestoredWhenReady(ctx, ready)
+		} else {
+			go d.dropRestoredWhenReady(ctx, ready)
+		}
+	}
 
 	initalSyncDelay := d.InitSyncDelay
 	if initalSyncDelay > 0 {
@@ -272,29 +358,127 @@ func (d *apisixProvider) Start(ctx context.Context) error {
 	ticker := time.NewTicker(syncPeriod)
 	defer ticker.Stop()
 
-	retrier := common.NewRetrier(common.NewExponentialBackoff(RetryBaseDelay, RetryMaxDelay))
+	if d.DriftCheckInterval > 0 {
+		go d.runDriftDetector(ctx)
+	}
+	go d.runEndpointRecovery(ctx)
 
 	for {
+		// Changes and retries push only what is not on the data plane yet; the resync
+		// period pushes everything.
+		var err error
 		select {
 		case <-d.syncCh:
+			err = d.sync(ctx, false)
+		case <-d.queue.C():
+			err = d.flush(ctx)
 		case <-ticker.C:
-		case <-retrier.C():
+			err = d.sync(ctx, true)
+		case <-d.caughtUpCh:
+			d.reportProgrammed(d.syncedConfigs())
+		case <-d.retrier.C():
+			if due := d.retrier.Due(); len(due) > 0 {
+				err = d.sync(ctx, false, due...)
+			}
 		case <-ctx.Done():
-			retrier.Reset()
+			d.retrier.Stop()
 			return nil
 		}
-		if err := d.sync(ctx); err != nil {
+		if err != nil {
 			d.log.Error(err, "failed to sync")
-			retrier.Next()
+		}
+	}
+}
+
+// sync pushes the configs named, or every config, and schedules a retry of each that
+// failed.
+func (d *apisixProvider) sync(ctx context.Context, full bool, names ...string) error {
+	return d.syncBatches(ctx, full, names, nil)
+}
+
+// syncBatches is sync of the configs named, which batches were taken for: an object of
+// one whose config failed for a reason that names none of its objects failed with it.
+func (d *apisixProvider) syncBatches(ctx context.Context, full bool, names []string, batches []updateBatch) error {
+	var listed []string
+	for _, config := range d.client.ConfigManager.List() {
+		listed = append(listed, config.Name)
+	}
+	if len(names) == 0 {
+		names = listed
+	}
+	// A config that is gone has nothing left to retry, or to report.
+	for name := range d.configFailures {
+		if !slices.Contains(listed, name) {
+			delete(d.configFailures, name)
+			d.retrier.Reset(name)
+		}
+	}
+
+	var (
+		statusesMap map[string]types.ADCExecutionErrors
+		err         error
+	)
+	if full {
+		statusesMap, err = d.client.Sync(ctx)
+	} else {
+		statusesMap, err = d.client.SyncChanges(ctx, names...)
+	}
+
+	var (
+		syncErr   *adcclient.SyncError
+		succeeded []string
+	)
+	errors.As(err, &syncErr)
+	for _, name := range names {
+		if syncErr != nil && slices.Contains(syncErr.Configs, name) {
+			d.retrier.Next(name)
 		} else {
-			retrier.Reset()
+			d.retrier.Reset(name)
+			if err == nil || syncErr != nil {
+				succeeded = append(succeeded, name)
+			}
+		}
+	}
+	failedBatches := make(map[string][]types.NamespacedNameKind)
+	for _, batch := range batches {
+		if err != nil && !slices.Contains(succeeded, batch.Config) {
+			failedBatches[batch.Config] = batch.Objects
 		}
 	}
+	d.handleADCExecutionErrors(names, statusesMap, failedBatches)
+	d.reportProgrammed(succeeded)
+	d.updateConflictConditions(full, batches)
+	d.updateEndpointStatuses()
+	d.updateFileConditions()
+	if err == nil {
+		d.saveState()
+	}
+	return err
 }
 
-func (d *apisixProvider) sync(ctx context.Context) error {
-	statusesMap, err := d.client.Sync(ctx)
-	d.handleADCExecutionErrors(statusesMap)
+// flush pushes the configs the queue has a batch of updates ready for.
+func (d *apisixProvider) flush(ctx context.Context) error {
+	batches := d.queue.take()
+	if len(batches) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(batches))
+	for _, batch := range batches {
+		names = append(names, batch.Config)
+	}
+	err := d.syncBatches(ctx, false, names, batches)
+
+	var syncErr *adcclient.SyncError
+	errors.As(err, &syncErr)
+	for _, batch := range batches {
+		status := adctypes.StatusSuccess
+		if err != nil && (syncErr == nil || slices.Contains(syncErr.Configs, batch.Config)) {
+			status = "failure"
+		}
+		pkgmetrics.RecordSyncQueueFlushDuration(batch.Config, status, time.Since(batch.Since).Seconds())
+		d.log.V(1).Info("flushed update batch", "config", batch.Config, "objects", len(batch.Objects),
+			"coalesced", batch.Coalesced, "latency", time.Since(batch.Since).String())
+	}
 	return err
 }
 
@@ -305,8 +489,39 @@ func (d *apisixProvider) syncNotify() {
 	}
 }
 
-func (d *apisixProvider) handleADCExecutionErrors(statusesMap map[string]types.ADCExecutionErrors) {
-	statusUpdateMap := d.resolveADCExecutionErrors(statusesMap)
+// handleADCExecutionErrors updates the status of what a sync of the configs named
+// failed for, and of what their previous sync did. Configs not synced keep what their
+// last sync failed for. A config of failedBatches whose sync failed for none of its
+// objects failed for the objects of its batch.
+func (d *apisixProvider) handleADCExecutionErrors(
+	synced []string,
+	statusesMap map[string]types.ADCExecutionErrors,
+	failedBatches map[string][]types.NamespacedNameKind,
+) {
+	for _, name := range synced {
+		delete(d.configFailures, name)
+	}
+	for name, execErrs := range statusesMap {
+		d.configFailures[name] = d.resolveADCExecutionErrors(map[string]types.ADCExecutionErrors{name: execErrs})
+	}
+	for name, objects := range failedBatches {
+		if len(d.configFailures[name]) > 0 {
+			continue
+		}
+		failures := make(map[types.NamespacedNameKind][]string, len(objects))
+		for _, nnk := range objects {
+			failures[nnk] = []string{fmt.Sprintf("failed to sync %s", name)}
+		}
+		d.configFailures[name] = failures
+	}
+	statusUpdateMap := map[types.NamespacedNameKind][]string{}
+	for _, failures := range d.configFailures {
+		for nnk, msgs := range failures {
+			statusUpdateMap[nnk] = append(statusUpdateMap[nnk], msgs...)
+		}
+	}
+	d.addQuarantined(statusUpdateMap)
+	d.reportSyncFailures(statusUpdateMap)
 	d.handleStatusUpdate(statusUpdateMap)
 	d.log.V(1).Info("handled ADC execution errors", "status_record", statusesMap, "status_update", statusUpdateMap)
 }
@@ -315,9 +530,23 @@ func (d *apisixProvider) NeedLeaderElection() bool {
 	return true
 }
 
+// Plan works out what task would push, relative to what this provider holds.
+func (d *apisixProvider) Plan(task adcclient.Task) ([]*cache.Plan, error) {
+	return d.client.Plan(task)
+}
+
+// CheckRouteConflicts finds the conflicts the routes of task would have with the routes
+// this provider holds for other objects.
+func (d *apisixProvider) CheckRouteConflicts(task adcclient.Task) ([]cache.RouteConflict, error) {
+	if !d.leading.Load() {
+		return nil, adcclient.ErrRoutesNotHeld
+	}
+	return d.client.CheckRouteConflicts(task)
+}
+
 // updateConfigForGatewayProxy update config for all referrers of the GatewayProxy
 func (d *apisixProvider) updateConfigForGatewayProxy(tctx *provider.TranslateContext, gp *v1alpha1.GatewayProxy) error {
-	config, err := d.translator.TranslateGatewayProxyToConfig(tctx, gp, d.DefaultResolveEndpoints)
+	config, err := d.gatewayProxyConfig(tctx, gp)
 	if err != nil {
 		return err
 	}
@@ -330,6 +559,6 @@ func (d *apisixProvider) updateConfigForGatewayProxy(tctx *provider.TranslateCon
 	referrers := tctx.GatewayProxyReferrers[utils.NamespacedName(gp)]
 	d.client.ConfigManager.SetConfigRefs(nnk, referrers)
 	d.client.ConfigManager.UpdateConfig(nnk, *config)
-	d.syncNotify()
+	d.queue.Add(nnk, config.Name)
 	return nil
 }
@@ -0,0 +1,44 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package apisix
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+// addQuarantined marks every quarantined object failed. They are no longer pushed, so
+// no sync reports them again, and without this the next sync would mark them accepted.
+func (d *apisixProvider) addQuarantined(statusUpdateMap map[types.NamespacedNameKind][]string) {
+	for _, entry := range d.client.Quarantined() {
+		if _, ok := statusUpdateMap[entry.Key]; ok {
+			continue
+		}
+		statusUpdateMap[entry.Key] = []string{"quarantined until it changes: " + entry.Reason}
+	}
+}
+
+// handleQuarantine serves the objects held out of every push, as JSON.
+func (d *apisixProvider) handleQuarantine(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(d.client.Quarantined())
+}
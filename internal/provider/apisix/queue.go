@@ -0,0 +1,180 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package apisix
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/apisix-ingress-controller/internal/types"
+	pkgmetrics "github.com/apache/apisix-ingress-controller/pkg/metrics"
+)
+
+// updateQueue coalesces the objects updated in the store into a batch per GatewayProxy
+// config, so that a burst of reconciles -- a Deployment rollout touching every route to
+// it, say -- is pushed in one sync of each config they belong to instead of one per
+// object.
+//
+// The batch of a config is ready once the window has passed since its first update, or
+// as soon as it holds maxBatch distinct objects. Updates to an object already waiting for
+// the config are merged into it. The batch decides when its config is pushed: what is
+// pushed is whatever changed in the store for it, and a failure is attributed by label to
+// each object it belongs to, or else to the objects of the batch.
+type updateQueue struct {
+	window   time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	batches map[string]*configBatch
+
+	ready chan struct{}
+}
+
+// configBatch is what is queued for one config.
+type configBatch struct {
+	objects   map[types.NamespacedNameKind]struct{}
+	coalesced int
+	since     time.Time
+	// due is set once the batch is ready to be taken.
+	due   bool
+	timer *time.Timer
+}
+
+// updateBatch is what take hands out for one config.
+type updateBatch struct {
+	Config  string
+	Objects []types.NamespacedNameKind
+	// Coalesced counts the updates merged into one already waiting.
+	Coalesced int
+	// Since is when the first update of the batch was queued.
+	Since time.Time
+}
+
+func newUpdateQueue(window time.Duration, maxBatch int) *updateQueue {
+	return &updateQueue{
+		window:   window,
+		maxBatch: maxBatch,
+		batches:  make(map[string]*configBatch),
+		ready:    make(chan struct{}, 1),
+	}
+}
+
+// Add queues an update of key to each of configs.
+func (q *updateQueue) Add(key types.NamespacedNameKind, configs ...string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, name := range configs {
+		batch, ok := q.batches[name]
+		if !ok {
+			batch = &configBatch{
+				objects: make(map[types.NamespacedNameKind]struct{}),
+				since:   time.Now(),
+			}
+			q.batches[name] = batch
+		}
+		if _, ok := batch.objects[key]; ok {
+			batch.coalesced++
+			pkgmetrics.IncSyncQueueCoalesced()
+		} else {
+			batch.objects[key] = struct{}{}
+		}
+
+		switch {
+		case batch.due:
+		case q.window <= 0, q.maxBatch > 0 && len(batch.objects) >= q.maxBatch:
+			batch.stopTimer()
+			batch.due = true
+			q.signal()
+		case batch.timer == nil:
+			batch.timer = time.AfterFunc(q.window, func() { q.expire(name, batch) })
+		}
+	}
+	q.updateDepth()
+}
+
+// expire marks batch, queued for config name, ready once its window has passed, unless
+// it was taken already.
+func (q *updateQueue) expire(name string, batch *configBatch) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.batches[name] != batch {
+		return
+	}
+	batch.timer = nil
+	batch.due = true
+	q.signal()
+}
+
+// C is sent to when a batch is ready to be taken.
+func (q *updateQueue) C() <-chan struct{} {
+	return q.ready
+}
+
+// take hands out the batches that are ready, by config, and starts the next batch of
+// each of their configs. It hands out none when another take already handed them out.
+func (q *updateQueue) take() []updateBatch {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var ready []updateBatch
+	for name, batch := range q.batches {
+		if !batch.due {
+			continue
+		}
+		objects := make([]types.NamespacedNameKind, 0, len(batch.objects))
+		for key := range batch.objects {
+			objects = append(objects, key)
+		}
+		ready = append(ready, updateBatch{
+			Config:    name,
+			Objects:   objects,
+			Coalesced: batch.coalesced,
+			Since:     batch.since,
+		})
+		delete(q.batches, name)
+	}
+	slices.SortFunc(ready, func(a, b updateBatch) int { return strings.Compare(a.Config, b.Config) })
+	q.updateDepth()
+	return ready
+}
+
+func (q *updateQueue) signal() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// updateDepth must be called with q.mu held.
+func (q *updateQueue) updateDepth() {
+	depth := 0
+	for _, batch := range q.batches {
+		depth += len(batch.objects)
+	}
+	pkgmetrics.UpdateSyncQueueDepth(float64(depth))
+}
+
+func (b *configBatch) stopTimer() {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+}
@@ -0,0 +1,133 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package apisix
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+func routeKey(name string) types.NamespacedNameKind {
+	return types.NamespacedNameKind{Kind: types.KindHTTPRoute, Namespace: "default", Name: name}
+}
+
+func assertReady(t *testing.T, q *updateQueue, within time.Duration) {
+	t.Helper()
+	select {
+	case <-q.C():
+	case <-time.After(within):
+		t.Fatal("batch not ready")
+	}
+}
+
+func assertNotReady(t *testing.T, q *updateQueue, within time.Duration) {
+	t.Helper()
+	select {
+	case <-q.C():
+		t.Fatal("batch ready too early")
+	case <-time.After(within):
+	}
+}
+
+const (
+	configA = "GatewayProxy/default/a"
+	configB = "GatewayProxy/default/b"
+)
+
+func TestUpdateQueueCoalescesUpdatesWithinTheWindow(t *testing.T) {
+	q := newUpdateQueue(100*time.Millisecond, 0)
+	q.Add(routeKey("a"), configA)
+	q.Add(routeKey("b"), configA)
+	q.Add(routeKey("a"), configA)
+	q.Add(routeKey("a"), configA)
+
+	assertNotReady(t, q, 20*time.Millisecond)
+	assertReady(t, q, time.Second)
+
+	batches := q.take()
+	require.Len(t, batches, 1)
+	assert.Equal(t, configA, batches[0].Config)
+	assert.ElementsMatch(t, []types.NamespacedNameKind{routeKey("a"), routeKey("b")}, batches[0].Objects)
+	assert.Equal(t, 2, batches[0].Coalesced)
+	assert.False(t, batches[0].Since.IsZero())
+
+	assert.Empty(t, q.take(), "a batch is handed out once")
+}
+
+func TestUpdateQueueFlushesAFullBatchEarly(t *testing.T) {
+	q := newUpdateQueue(time.Hour, 2)
+	q.Add(routeKey("a"), configA)
+	q.Add(routeKey("a"), configA)
+	q.Add(routeKey("b"), configB)
+	assertNotReady(t, q, 20*time.Millisecond)
+
+	q.Add(routeKey("b"), configA)
+	assertReady(t, q, time.Second)
+	batches := q.take()
+	require.Len(t, batches, 1, "only the full batch is ready")
+	assert.Equal(t, configA, batches[0].Config)
+	assert.Len(t, batches[0].Objects, 2)
+}
+
+func TestUpdateQueueWithoutAWindowFlushesEveryUpdate(t *testing.T) {
+	q := newUpdateQueue(0, 0)
+	q.Add(routeKey("a"), configA)
+	assertReady(t, q, time.Second)
+	batches := q.take()
+	require.Len(t, batches, 1)
+	assert.Len(t, batches[0].Objects, 1)
+}
+
+func TestUpdateQueueBatchesEachConfigOnItsOwn(t *testing.T) {
+	q := newUpdateQueue(200*time.Millisecond, 0)
+	q.Add(routeKey("a"), configA)
+	time.Sleep(100 * time.Millisecond)
+	q.Add(routeKey("a"), configB)
+	q.Add(routeKey("b"), configB)
+
+	assertReady(t, q, time.Second)
+	batches := q.take()
+	require.Len(t, batches, 1, "the window of b started later")
+	assert.Equal(t, configA, batches[0].Config)
+	assert.Equal(t, []types.NamespacedNameKind{routeKey("a")}, batches[0].Objects)
+
+	assertReady(t, q, time.Second)
+	batches = q.take()
+	require.Len(t, batches, 1)
+	assert.Equal(t, configB, batches[0].Config)
+	assert.ElementsMatch(t, []types.NamespacedNameKind{routeKey("a"), routeKey("b")}, batches[0].Objects)
+}
+
+func TestUpdateQueueStartsTheNextWindowAfterTake(t *testing.T) {
+	q := newUpdateQueue(50*time.Millisecond, 0)
+	q.Add(routeKey("a"), configA)
+	assertReady(t, q, time.Second)
+	q.take()
+
+	q.Add(routeKey("b"), configA)
+	assertNotReady(t, q, 10*time.Millisecond)
+	assertReady(t, q, time.Second)
+	batches := q.take()
+	require.Len(t, batches, 1)
+	assert.Equal(t, []types.NamespacedNameKind{routeKey("b")}, batches[0].Objects)
+}
@@ -0,0 +1,67 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package apisix
+
+import (
+	"context"
+	"time"
+)
+
+// loadState restores what the provider saved before it last stopped, if StatePath is
+// set, so that the first sync has every object as a baseline even if some are not
+// translated yet. It reports whether anything was restored.
+func (d *apisixProvider) loadState() bool {
+	if d.StatePath == "" {
+		return false
+	}
+	loaded, err := d.client.LoadState(d.StatePath)
+	if err != nil {
+		// A state that cannot be read is no worse than none.
+		d.log.Error(err, "failed to load saved state, starting without it", "path", d.StatePath)
+		return false
+	}
+	return loaded
+}
+
+// saveState saves the store and the configs to StatePath, if it is set.
+func (d *apisixProvider) saveState() {
+	if d.StatePath == "" {
+		return
+	}
+	if err := d.client.SaveState(d.StatePath); err != nil {
+		d.log.Error(err, "failed to save state", "path", d.StatePath)
+	}
+}
+
+// dropRestoredWhenReady drops what loadState restored and nothing translated again, once
+// every object has been translated: what is left was deleted while the controller was
+// down. Until then, it stays, so that an early sync does not remove what was merely not
+// translated yet.
+func (d *apisixProvider) dropRestoredWhenReady(ctx context.Context, ready bool) {
+	for !ready {
+		if ctx.Err() != nil {
+			return
+		}
+		d.log.Info("not ready yet, keeping the restored state")
+		ready = d.readier.WaitReady(ctx, time.Minute)
+	}
+	if dropped := d.client.DropRestored(); dropped > 0 {
+		d.log.Info("dropped restored objects deleted while the controller was down", "owners", dropped)
+		d.syncNotify()
+	}
+}
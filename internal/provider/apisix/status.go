@@ -19,7 +19,9 @@ package apisix
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -38,35 +40,109 @@ import (
 //
 // For resources in the current failure map (statusUpdateMap), it marks them as failed.
 // For resources that exist only in the previous failure history (i.e. not in this sync's failures),
-// it marks them as accepted (success).
+// it marks them as accepted (success), and as programmed by the last push of their configs.
 func (d *apisixProvider) handleStatusUpdate(statusUpdateMap map[types.NamespacedNameKind][]string) {
 	// Mark all resources in the current failure set as failed.
 	for nnk, msgs := range statusUpdateMap {
-		d.updateStatus(nnk, cutils.NewConditionTypeAccepted(
-			apiv2.ConditionReasonSyncFailed,
-			false,
-			0,
-			strings.Join(msgs, "; "),
-		))
+		msg := strings.Join(msgs, "; ")
+		conditions := []metav1.Condition{cutils.NewConditionTypeAccepted(apiv2.ConditionReasonSyncFailed, false, 0, msg)}
+		if isProgrammedKind(nnk.Kind) {
+			conditions = append(conditions, newConditionTypeProgrammed(apiv2.ConditionReasonSyncFailed, false, 0, msg))
+		}
+		d.updateStatus(nnk, conditions...)
 	}
 
 	// Mark resources that exist only in the previous failure history as successful.
 	for nnk := range d.statusUpdateMap {
 		if _, ok := statusUpdateMap[nnk]; !ok {
-			d.updateStatus(nnk, cutils.NewConditionTypeAccepted(
-				apiv2.ConditionReasonAccepted,
-				true,
-				0,
-				"",
-			))
+			conditions := []metav1.Condition{cutils.NewConditionTypeAccepted(apiv2.ConditionReasonAccepted, true, 0, "")}
+			if isProgrammedKind(nnk.Kind) {
+				var configs []string
+				for _, config := range d.client.ConfigManager.Get(nnk) {
+					configs = append(configs, config.Name)
+				}
+				conditions = append(conditions, d.programmedCondition(0, configs))
+			}
+			d.updateStatus(nnk, conditions...)
 		}
 	}
 	// Update the failure history with the current failure set.
 	d.statusUpdateMap = statusUpdateMap
 }
 
+// isProgrammedKind reports whether objects of kind carry a Programmed condition: the
+// routes, whose reconcilers keep it, see controller.carryRouteConditionProgrammed.
+func isProgrammedKind(kind string) bool {
+	switch kind {
+	case types.KindApisixRoute, types.KindHTTPRoute, types.KindGRPCRoute,
+		types.KindTCPRoute, types.KindUDPRoute, types.KindTLSRoute:
+		return true
+	default:
+		return false
+	}
+}
+
+func newConditionTypeProgrammed(reason apiv2.ApisixRouteConditionReason, status bool, generation int64, msg string) metav1.Condition {
+	condition := cutils.NewConditionTypeAccepted(reason, status, generation, msg)
+	condition.Type = string(apiv2.ConditionTypeProgrammed)
+	return condition
+}
+
+// programmedCondition is the Programmed condition of generation of an object that the
+// data plane of configs accepted, naming the push of each that it reached it with. It is
+// true only once every endpoint of every config holds that push.
+func (d *apisixProvider) programmedCondition(generation int64, configs []string) metav1.Condition {
+	slices.Sort(configs)
+	pushes := make([]string, 0, len(configs))
+	var lagging []string
+	for _, name := range configs {
+		for _, addr := range d.client.LaggingEndpoints(name) {
+			lagging = append(lagging, name+" at "+addr)
+		}
+		push, ok := d.client.LastPush(name)
+		if !ok {
+			pushes = append(pushes, name)
+			continue
+		}
+		pushes = append(pushes, fmt.Sprintf("%s as push %d at %s", name, push.Sequence, push.At.UTC().Format(time.RFC3339)))
+	}
+	msg := "pushed to " + strings.Join(pushes, ", ")
+	if len(lagging) > 0 {
+		return newConditionTypeProgrammed(apiv2.ConditionReasonPartiallyProgrammed, false, generation,
+			msg+", but not yet to "+strings.Join(lagging, ", "))
+	}
+	return newConditionTypeProgrammed(apiv2.ConditionReasonProgrammed, true, generation, msg)
+}
+
+// converged returns the configs named every endpoint of which holds the last push.
+func (d *apisixProvider) converged(names []string) []string {
+	var converged []string
+	for _, name := range names {
+		if len(d.client.LaggingEndpoints(name)) == 0 {
+			converged = append(converged, name)
+		}
+	}
+	return converged
+}
+
+// currentConditions sets the ObservedGeneration of conditions that have none to
+// generation, and leaves out those observed at an earlier one: what a sync says about a
+// generation the object moved on from is not what it says about the object.
+func currentConditions(generation int64, conditions []metav1.Condition) []metav1.Condition {
+	current := make([]metav1.Condition, 0, len(conditions))
+	for _, condition := range conditions {
+		if condition.ObservedGeneration == 0 {
+			condition.ObservedGeneration = generation
+		}
+		if condition.ObservedGeneration >= generation {
+			current = append(current, condition)
+		}
+	}
+	return current
+}
+
 //nolint:gocyclo
-func (d *apisixProvider) updateStatus(nnk types.NamespacedNameKind, condition metav1.Condition) {
+func (d *apisixProvider) updateStatus(nnk types.NamespacedNameKind, conditions ...metav1.Condition) {
 	switch nnk.Kind {
 	case types.KindApisixRoute:
 		d.updater.Update(status.Update{
@@ -74,7 +150,9 @@ func (d *apisixProvider) updateStatus(nnk types.NamespacedNameKind, condition me
 			Resource:       &apiv2.ApisixRoute{},
 			Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
 				cp := obj.(*apiv2.ApisixRoute).DeepCopy()
-				cutils.SetApisixCRDConditionWithGeneration(&cp.Status, cp.GetGeneration(), condition)
+				for _, condition := range currentConditions(cp.GetGeneration(), conditions) {
+					cutils.SetApisixCRDCondition(&cp.Status, condition)
+				}
 				return cp
 			}),
 		})
@@ -84,7 +162,9 @@ func (d *apisixProvider) updateStatus(nnk types.NamespacedNameKind, condition me
 			Resource:       &apiv2.ApisixGlobalRule{},
 			Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
 				cp := obj.(*apiv2.ApisixGlobalRule).DeepCopy()
-				cutils.SetApisixCRDConditionWithGeneration(&cp.Status, cp.GetGeneration(), condition)
+				for _, condition := range currentConditions(cp.GetGeneration(), conditions) {
+					cutils.SetApisixCRDCondition(&cp.Status, condition)
+				}
 				return cp
 			}),
 		})
@@ -94,7 +174,9 @@ func (d *apisixProvider) updateStatus(nnk types.NamespacedNameKind, condition me
 			Resource:       &apiv2.ApisixTls{},
 			Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
 				cp := obj.(*apiv2.ApisixTls).DeepCopy()
-				cutils.SetApisixCRDConditionWithGeneration(&cp.Status, cp.GetGeneration(), condition)
+				for _, condition := range currentConditions(cp.GetGeneration(), conditions) {
+					cutils.SetApisixCRDCondition(&cp.Status, condition)
+				}
 				return cp
 			}),
 		})
@@ -104,7 +186,9 @@ func (d *apisixProvider) updateStatus(nnk types.NamespacedNameKind, condition me
 			Resource:       &apiv2.ApisixConsumer{},
 			Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
 				cp := obj.(*apiv2.ApisixConsumer).DeepCopy()
-				cutils.SetApisixCRDConditionWithGeneration(&cp.Status, cp.GetGeneration(), condition)
+				for _, condition := range currentConditions(cp.GetGeneration(), conditions) {
+					cutils.SetApisixCRDCondition(&cp.Status, condition)
+				}
 				return cp
 			}),
 		})
@@ -122,7 +206,7 @@ func (d *apisixProvider) updateStatus(nnk types.NamespacedNameKind, condition me
 			Resource:       &gatewayv1.HTTPRoute{},
 			Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
 				cp := obj.(*gatewayv1.HTTPRoute).DeepCopy()
-				condition.ObservedGeneration = cp.GetGeneration()
+				conditions := currentConditions(cp.GetGeneration(), conditions)
 				gatewayNs := cp.GetNamespace()
 				for i, ref := range cp.Status.Parents {
 					ns := gatewayNs
@@ -136,7 +220,9 @@ func (d *apisixProvider) updateStatus(nnk types.NamespacedNameKind, condition me
 							Kind:      types.KindGateway,
 						}
 						if _, ok := gatewayRefs[nnk]; ok {
-							ref.Conditions = cutils.MergeCondition(ref.Conditions, condition)
+							for _, condition := range conditions {
+								ref.Conditions = cutils.MergeCondition(ref.Conditions, condition)
+							}
 							cp.Status.Parents[i] = ref
 						}
 					}
@@ -158,7 +244,7 @@ func (d *apisixProvider) updateStatus(nnk types.NamespacedNameKind, condition me
 			Resource:       &gatewayv1.UDPRoute{},
 			Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
 				cp := obj.(*gatewayv1.UDPRoute).DeepCopy()
-				condition.ObservedGeneration = cp.GetGeneration()
+				conditions := currentConditions(cp.GetGeneration(), conditions)
 				gatewayNs := cp.GetNamespace()
 				for i, ref := range cp.Status.Parents {
 					ns := gatewayNs
@@ -172,7 +258,9 @@ func (d *apisixProvider) updateStatus(nnk types.NamespacedNameKind, condition me
 							Kind:      types.KindGateway,
 						}
 						if _, ok := gatewayRefs[nnk]; ok {
-							ref.Conditions = cutils.MergeCondition(ref.Conditions, condition)
+							for _, condition := range conditions {
+								ref.Conditions = cutils.MergeCondition(ref.Conditions, condition)
+							}
 							cp.Status.Parents[i] = ref
 						}
 					}
@@ -194,7 +282,7 @@ func (d *apisixProvider) updateStatus(nnk types.NamespacedNameKind, condition me
 			Resource:       &gatewayv1.TCPRoute{},
 			Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
 				cp := obj.(*gatewayv1.TCPRoute).DeepCopy()
-				condition.ObservedGeneration = cp.GetGeneration()
+				conditions := currentConditions(cp.GetGeneration(), conditions)
 				gatewayNs := cp.GetNamespace()
 				for i, ref := range cp.Status.Parents {
 					ns := gatewayNs
@@ -208,7 +296,9 @@ func (d *apisixProvider) updateStatus(nnk types.NamespacedNameKind, condition me
 							Kind:      types.KindGateway,
 						}
 						if _, ok := gatewayRefs[nnk]; ok {
-							ref.Conditions = cutils.MergeCondition(ref.Conditions, condition)
+							for _, condition := range conditions {
+								ref.Conditions = cutils.MergeCondition(ref.Conditions, condition)
+							}
 							cp.Status.Parents[i] = ref
 						}
 					}
@@ -230,7 +320,45 @@ func (d *apisixProvider) updateStatus(nnk types.NamespacedNameKind, condition me
 			Resource:       &gatewayv1.GRPCRoute{},
 			Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
 				cp := obj.(*gatewayv1.GRPCRoute).DeepCopy()
-				condition.ObservedGeneration = cp.GetGeneration()
+				conditions := currentConditions(cp.GetGeneration(), conditions)
+				gatewayNs := cp.GetNamespace()
+				for i, ref := range cp.Status.Parents {
+					ns := gatewayNs
+					if ref.ParentRef.Namespace != nil {
+						ns = string(*ref.ParentRef.Namespace)
+					}
+					if ref.ParentRef.Kind == nil || *ref.ParentRef.Kind == types.KindGateway {
+						nnk := types.NamespacedNameKind{
+							Name:      string(ref.ParentRef.Name),
+							Namespace: ns,
+							Kind:      types.KindGateway,
+						}
+						if _, ok := gatewayRefs[nnk]; ok {
+							for _, condition := range conditions {
+								ref.Conditions = cutils.MergeCondition(ref.Conditions, condition)
+							}
+							cp.Status.Parents[i] = ref
+						}
+					}
+				}
+				return cp
+			}),
+		})
+	case types.KindTLSRoute:
+		parentRefs := d.client.ConfigManager.GetConfigRefsByResourceKey(nnk)
+		d.log.V(1).Info("updating TLSRoute status", "parentRefs", parentRefs)
+		gatewayRefs := map[types.NamespacedNameKind]struct{}{}
+		for _, parentRef := range parentRefs {
+			if parentRef.Kind == types.KindGateway {
+				gatewayRefs[parentRef] = struct{}{}
+			}
+		}
+		d.updater.Update(status.Update{
+			NamespacedName: nnk.NamespacedName(),
+			Resource:       &gatewayv1.TLSRoute{},
+			Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
+				cp := obj.(*gatewayv1.TLSRoute).DeepCopy()
+				conditions := currentConditions(cp.GetGeneration(), conditions)
 				gatewayNs := cp.GetNamespace()
 				for i, ref := range cp.Status.Parents {
 					ns := gatewayNs
@@ -244,7 +372,9 @@ func (d *apisixProvider) updateStatus(nnk types.NamespacedNameKind, condition me
 							Kind:      types.KindGateway,
 						}
 						if _, ok := gatewayRefs[nnk]; ok {
-							ref.Conditions = cutils.MergeCondition(ref.Conditions, condition)
+							for _, condition := range conditions {
+								ref.Conditions = cutils.MergeCondition(ref.Conditions, condition)
+							}
 							cp.Status.Parents[i] = ref
 						}
 					}
@@ -0,0 +1,319 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
+	"github.com/apache/apisix-ingress-controller/internal/controller/label"
+)
+
+// The JSON API serves what the HTML pages show, for scripts. A config name holds slashes,
+// so it is path-escaped in URLs: GatewayProxy%2Fdefault%2Fapisix.
+const (
+	apiConfigsPath  = "/api/v1/configs"
+	apiResourcesPat = "GET " + apiConfigsPath + "/{config}/{type}"
+	apiObjectsPat   = "GET /api/v1/objects/{kind}/{namespace}/{name}"
+)
+
+// APIOwner is the Kubernetes object an ADC resource was translated from.
+type APIOwner struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// APIConfig is a config, without its credentials, and how many resources of each type
+// it holds.
+type APIConfig struct {
+	Name         string         `json:"name"`
+	GatewayProxy APIOwner       `json:"gatewayProxy"`
+	BackendType  string         `json:"backendType,omitempty"`
+	Executor     string         `json:"executor,omitempty"`
+	ServerAddrs  []string       `json:"serverAddrs"`
+	Resources    map[string]int `json:"resources"`
+}
+
+// APIResource is one ADC resource of a config, with secrets redacted.
+type APIResource struct {
+	Type     string    `json:"type"`
+	ID       string    `json:"id"`
+	Name     string    `json:"name,omitempty"`
+	Owner    *APIOwner `json:"owner,omitempty"`
+	Resource any       `json:"resource"`
+}
+
+// APIOrigin is where an ADC resource came from: the Kubernetes object it was translated
+// from and the policies, such as a BackendTrafficPolicy, that shaped it.
+type APIOrigin struct {
+	Config   string     `json:"config"`
+	Type     string     `json:"type"`
+	ID       string     `json:"id"`
+	Name     string     `json:"name,omitempty"`
+	Owner    *APIOwner  `json:"owner,omitempty"`
+	Policies []APIOwner `json:"policies,omitempty"`
+}
+
+type apiList[T any] struct {
+	Items []T `json:"items"`
+}
+
+func (asrv *ADCDebugProvider) setupAPIHandler(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+apiConfigsPath, asrv.handleAPIConfigs)
+	mux.HandleFunc(apiResourcesPat, asrv.handleAPIResources)
+	mux.HandleFunc(apiResourcesPat+"/{id}", asrv.handleAPIResource)
+	mux.HandleFunc(apiResourcesPat+"/{id}/origin", asrv.handleAPIOrigin)
+	mux.HandleFunc(apiObjectsPat, asrv.handleAPIObject)
+}
+
+func (asrv *ADCDebugProvider) handleAPIConfigs(w http.ResponseWriter, _ *http.Request) {
+	configs := asrv.configManager.List()
+	items := make([]APIConfig, 0, len(configs))
+	for key, config := range configs {
+		counts := make(map[string]int)
+		for _, resourceType := range apiResourceTypes {
+			resources, err := asrv.listAPIResources(config.Name, resourceType)
+			if err != nil {
+				writeAPIError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			counts[resourceType] = len(resources)
+		}
+		items = append(items, APIConfig{
+			Name:         config.Name,
+			GatewayProxy: APIOwner{Kind: key.Kind, Namespace: key.Namespace, Name: key.Name},
+			BackendType:  config.BackendType,
+			Executor:     config.Executor,
+			ServerAddrs:  config.ServerAddrs,
+			Resources:    counts,
+		})
+	}
+	slices.SortFunc(items, func(a, b APIConfig) int { return strings.Compare(a.Name, b.Name) })
+	writeAPIJSON(w, apiList[APIConfig]{Items: items})
+}
+
+// handleAPIResources lists the resources of a type in a config. The kind, namespace and
+// name query parameters keep only those translated from matching Kubernetes objects.
+func (asrv *ADCDebugProvider) handleAPIResources(w http.ResponseWriter, r *http.Request) {
+	resources, ok := asrv.apiResources(w, r)
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+	resources = slices.DeleteFunc(resources, func(res APIResource) bool {
+		return !ownerMatches(res.Owner, query.Get("kind"), query.Get("namespace"), query.Get("name"))
+	})
+	writeAPIJSON(w, apiList[APIResource]{Items: resources})
+}
+
+func (asrv *ADCDebugProvider) handleAPIResource(w http.ResponseWriter, r *http.Request) {
+	resources, ok := asrv.apiResources(w, r)
+	if !ok {
+		return
+	}
+	id := r.PathValue("id")
+	i := slices.IndexFunc(resources, func(res APIResource) bool { return res.ID == id })
+	if i < 0 {
+		writeAPIError(w, http.StatusNotFound, fmt.Sprintf("%s %q not found", r.PathValue("type"), id))
+		return
+	}
+	writeAPIJSON(w, resources[i])
+}
+
+// handleAPIOrigin answers which Kubernetes objects produced a resource.
+func (asrv *ADCDebugProvider) handleAPIOrigin(w http.ResponseWriter, r *http.Request) {
+	configName, resourceType, id := r.PathValue("config"), r.PathValue("type"), r.PathValue("id")
+	if !asrv.knownConfig(w, configName) {
+		return
+	}
+	if resourceType == adctypes.TypePluginMetadata || !slices.Contains(apiResourceTypes, resourceType) {
+		writeAPIError(w, http.StatusNotFound, fmt.Sprintf("resource type %q has no origin", resourceType))
+		return
+	}
+	origin, err := asrv.store.Origin(configName, resourceType, id)
+	switch {
+	case errors.Is(err, cache.ErrNotFound):
+		writeAPIError(w, http.StatusNotFound, fmt.Sprintf("%s %q not found", resourceType, id))
+	case err != nil:
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+	default:
+		writeAPIJSON(w, newAPIOrigin(origin))
+	}
+}
+
+// handleAPIObject answers which resources, in every config, a Kubernetes object was
+// translated into or shaped as a policy.
+func (asrv *ADCDebugProvider) handleAPIObject(w http.ResponseWriter, r *http.Request) {
+	origins, err := asrv.store.Derived(cache.Owner{
+		Kind:      r.PathValue("kind"),
+		Namespace: r.PathValue("namespace"),
+		Name:      r.PathValue("name"),
+	})
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	items := make([]APIOrigin, 0, len(origins))
+	for _, origin := range origins {
+		items = append(items, newAPIOrigin(origin))
+	}
+	writeAPIJSON(w, apiList[APIOrigin]{Items: items})
+}
+
+// knownConfig reports whether a config is named configName, or answers w that none is.
+func (asrv *ADCDebugProvider) knownConfig(w http.ResponseWriter, configName string) bool {
+	for _, config := range asrv.configManager.List() {
+		if config.Name == configName {
+			return true
+		}
+	}
+	writeAPIError(w, http.StatusNotFound, fmt.Sprintf("config %q not found", configName))
+	return false
+}
+
+// apiResources lists the resources the config and type of r name, or answers r with why
+// it cannot.
+func (asrv *ADCDebugProvider) apiResources(w http.ResponseWriter, r *http.Request) ([]APIResource, bool) {
+	configName, resourceType := r.PathValue("config"), r.PathValue("type")
+	if !asrv.knownConfig(w, configName) {
+		return nil, false
+	}
+	if !slices.Contains(apiResourceTypes, resourceType) {
+		writeAPIError(w, http.StatusNotFound, fmt.Sprintf("unknown resource type %q, want one of %s",
+			resourceType, strings.Join(apiResourceTypes, ", ")))
+		return nil, false
+	}
+	resources, err := asrv.listAPIResources(configName, resourceType)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+	return resources, true
+}
+
+var apiResourceTypes = []string{
+	adctypes.TypeService, adctypes.TypeRoute, adctypes.TypeStreamRoute, adctypes.TypeConsumer,
+	adctypes.TypeSSL, adctypes.TypeGlobalRule, adctypes.TypePluginMetadata,
+}
+
+func (asrv *ADCDebugProvider) listAPIResources(configName, resourceType string) ([]APIResource, error) {
+	resources, err := asrv.store.GetResources(configName)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []APIResource{}
+	switch resourceType {
+	case adctypes.TypeService:
+		for _, svc := range resources.Services {
+			items = append(items, newAPIResource(resourceType, svc.ID, svc.Name, svc.Labels, svc.Redacted()))
+		}
+	case adctypes.TypeRoute:
+		for _, svc := range resources.Services {
+			for _, route := range svc.Routes {
+				items = append(items, newAPIResource(resourceType, route.ID, route.Name, route.Labels, route.Redacted()))
+			}
+		}
+	case adctypes.TypeStreamRoute:
+		for _, svc := range resources.Services {
+			for _, route := range svc.StreamRoutes {
+				items = append(items, newAPIResource(resourceType, route.ID, route.Name, route.Labels, route.Redacted()))
+			}
+		}
+	case adctypes.TypeConsumer:
+		for _, consumer := range resources.Consumers {
+			items = append(items, newAPIResource(resourceType, consumer.Username, consumer.Username,
+				consumer.Labels, consumer.Redacted()))
+		}
+	case adctypes.TypeSSL:
+		for _, ssl := range resources.SSLs {
+			items = append(items, newAPIResource(resourceType, ssl.ID, ssl.Name, ssl.Labels, ssl.Redacted()))
+		}
+	case adctypes.TypeGlobalRule:
+		// Each global rule item is what one object contributed; the data plane gets them
+		// merged.
+		globalRules, err := asrv.store.ListGlobalRules(configName)
+		if err != nil {
+			// A config the store holds nothing for has no global rules either.
+			return items, nil
+		}
+		for _, item := range globalRules {
+			items = append(items, newAPIResource(resourceType, item.ID, item.Name, item.Labels, item.Redacted()))
+		}
+	case adctypes.TypePluginMetadata:
+		if resources.PluginMetadata != nil {
+			items = append(items, newAPIResource(resourceType, "pluginmetadata", "Plugin Metadata", nil,
+				resources.PluginMetadata.Redacted()))
+		}
+	}
+	slices.SortFunc(items, func(a, b APIResource) int { return strings.Compare(a.ID, b.ID) })
+	return items, nil
+}
+
+func newAPIResource(resourceType, id, name string, labels map[string]string, resource any) APIResource {
+	res := APIResource{Type: resourceType, ID: id, Name: name, Resource: resource}
+	if kind, owner := labels[label.LabelKind], labels[label.LabelName]; kind != "" && owner != "" {
+		res.Owner = &APIOwner{Kind: kind, Namespace: labels[label.LabelNamespace], Name: owner}
+	}
+	return res
+}
+
+func newAPIOrigin(origin *cache.Origin) APIOrigin {
+	out := APIOrigin{Config: origin.Config, Type: origin.Type, ID: origin.ID, Name: origin.Name}
+	if origin.Owner.Kind != "" && origin.Owner.Name != "" {
+		out.Owner = &APIOwner{Kind: origin.Owner.Kind, Namespace: origin.Owner.Namespace, Name: origin.Owner.Name}
+	}
+	for _, policy := range origin.Policies {
+		out.Policies = append(out.Policies, APIOwner{Kind: policy.Kind, Namespace: policy.Namespace, Name: policy.Name})
+	}
+	return out
+}
+
+// ownerMatches reports whether owner is the object kind, namespace and name select; an
+// empty one selects any.
+func ownerMatches(owner *APIOwner, kind, namespace, name string) bool {
+	if kind == "" && namespace == "" && name == "" {
+		return true
+	}
+	if owner == nil {
+		return false
+	}
+	return (kind == "" || owner.Kind == kind) &&
+		(namespace == "" || owner.Namespace == namespace) &&
+		(name == "" || owner.Name == name)
+}
+
+func writeAPIJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(v)
+}
+
+func writeAPIError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
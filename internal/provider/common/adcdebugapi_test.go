@@ -0,0 +1,219 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
+	"github.com/apache/apisix-ingress-controller/internal/controller/label"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+const debugConfig = "GatewayProxy/default/gp"
+
+func ownerLabels(kind, name string) map[string]string {
+	return map[string]string{label.LabelKind: kind, label.LabelNamespace: "default", label.LabelName: name}
+}
+
+func newDebugAPI(t *testing.T) *http.ServeMux {
+	t.Helper()
+	store := cache.NewStore(logr.Discard())
+	for _, name := range []string{"a", "b"} {
+		labels := ownerLabels("HTTPRoute", name)
+		require.NoError(t, store.Insert(debugConfig, []string{adctypes.TypeService}, &adctypes.Resources{
+			Services: []*adctypes.Service{{
+				Metadata: adctypes.Metadata{ID: name, Name: name, Labels: labels},
+				Routes: []*adctypes.Route{{Metadata: adctypes.Metadata{
+					ID:     name + "-route",
+					Labels: label.WithPolicy(labels, "HTTPRoutePolicy", "default", name+"-policy"),
+				}}},
+			}},
+		}, labels))
+	}
+	secretLabels := ownerLabels("ApisixRoute", "secrets")
+	require.NoError(t, store.Insert(debugConfig, []string{adctypes.TypeService}, &adctypes.Resources{
+		Services: []*adctypes.Service{{
+			Metadata: adctypes.Metadata{ID: "secrets", Labels: secretLabels},
+			Plugins:  adctypes.Plugins{"openid-connect": map[string]any{"client_id": "CLIENT", "client_secret": "OIDC SECRET"}},
+			Upstream: &adctypes.Upstream{TLS: &adctypes.ClientTLS{Cert: "UPSTREAM CERT", Key: "UPSTREAM KEY"}},
+			Routes: []*adctypes.Route{{
+				Metadata: adctypes.Metadata{ID: "secrets-route", Labels: secretLabels},
+				Plugins: adctypes.Plugins{
+					"proxy-rewrite": map[string]any{"headers": map[string]any{"set": map[string]any{"Authorization": "Bearer ROUTE TOKEN"}}},
+					"traffic-split": &adctypes.TrafficSplitConfig{Rules: []adctypes.TrafficSplitConfigRule{{
+						WeightedUpstreams: []adctypes.TrafficSplitConfigRuleWeightedUpstream{{
+							Upstream: &adctypes.Upstream{TLS: &adctypes.ClientTLS{Key: "SPLIT KEY"}},
+							Weight:   1,
+						}},
+					}}},
+				},
+			}},
+			StreamRoutes: []*adctypes.StreamRoute{{
+				Metadata: adctypes.Metadata{ID: "secrets-stream", Labels: secretLabels},
+				Plugins:  adctypes.Plugins{"mqtt-proxy": map[string]any{"tls": &adctypes.TLSClass{ClientCERT: "STREAM CERT", ClientKey: "STREAM KEY"}}},
+			}},
+		}},
+	}, secretLabels))
+	require.NoError(t, store.Insert(debugConfig, []string{adctypes.TypeGlobalRule, adctypes.TypePluginMetadata}, &adctypes.Resources{
+		GlobalRules:    adctypes.GlobalRule{"basic-auth": map[string]any{"username": "admin", "password": "GLOBAL PASSWORD"}},
+		PluginMetadata: adctypes.PluginMetadata{"http-logger": map[string]any{"log_format": map[string]any{"host": "$host"}, "auth_token": "METADATA TOKEN"}},
+	}, ownerLabels("ApisixGlobalRule", "global")))
+	tlsLabels := ownerLabels("ApisixTls", "tls")
+	require.NoError(t, store.Insert(debugConfig, []string{adctypes.TypeSSL}, &adctypes.Resources{
+		SSLs: []*adctypes.SSL{{
+			Metadata:     adctypes.Metadata{ID: "tls", Labels: tlsLabels},
+			Certificates: []adctypes.Certificate{{Certificate: "CERT", Key: "PRIVATE KEY"}},
+		}},
+	}, tlsLabels))
+	consumerLabels := ownerLabels("Consumer", "jack")
+	require.NoError(t, store.Insert(debugConfig, []string{adctypes.TypeConsumer}, &adctypes.Resources{
+		Consumers: []*adctypes.Consumer{{
+			Metadata: adctypes.Metadata{Labels: consumerLabels},
+			Username: "jack",
+			Credentials: []adctypes.Credential{{
+				Type:   "key-auth",
+				Config: adctypes.Plugins{"key": "secret"},
+			}},
+		}},
+	}, consumerLabels))
+
+	configManager := NewConfigManager[types.NamespacedNameKind, adctypes.Config]()
+	configManager.UpdateConfig(types.NamespacedNameKind{Kind: "GatewayProxy", Namespace: "default", Name: "gp"},
+		adctypes.Config{Name: debugConfig, ServerAddrs: []string{"http://apisix:9180"}, Token: "admin-key"})
+
+	mux := http.NewServeMux()
+	NewADCDebugProvider(store, configManager).SetupHandler("/debug", mux)
+	return mux
+}
+
+func getAPI(t *testing.T, mux *http.ServeMux, target string, want int, v any) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	require.Equal(t, want, rec.Code, rec.Body.String())
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	if v != nil {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), v))
+	}
+	return rec.Body.String()
+}
+
+func TestDebugAPIListsConfigs(t *testing.T) {
+	var list apiList[APIConfig]
+	body := getAPI(t, newDebugAPI(t), "/api/v1/configs", http.StatusOK, &list)
+
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, debugConfig, list.Items[0].Name)
+	assert.Equal(t, APIOwner{Kind: "GatewayProxy", Namespace: "default", Name: "gp"}, list.Items[0].GatewayProxy)
+	assert.Equal(t, 3, list.Items[0].Resources[adctypes.TypeRoute])
+	assert.NotContains(t, body, "admin-key")
+}
+
+func TestDebugAPIFiltersResourcesByOwner(t *testing.T) {
+	mux := newDebugAPI(t)
+
+	var list apiList[APIResource]
+	getAPI(t, mux, "/api/v1/configs/GatewayProxy%2Fdefault%2Fgp/route?kind=HTTPRoute&name=b", http.StatusOK, &list)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "b-route", list.Items[0].ID)
+	assert.Equal(t, &APIOwner{Kind: "HTTPRoute", Namespace: "default", Name: "b"}, list.Items[0].Owner)
+
+	getAPI(t, mux, "/api/v1/configs/GatewayProxy%2Fdefault%2Fgp/route?namespace=other", http.StatusOK, &list)
+	assert.Empty(t, list.Items)
+}
+
+func TestDebugAPIRedactsSecrets(t *testing.T) {
+	mux := newDebugAPI(t)
+
+	body := getAPI(t, mux, "/api/v1/configs/GatewayProxy%2Fdefault%2Fgp/ssl/tls", http.StatusOK, nil)
+	assert.Contains(t, body, "CERT")
+	assert.NotContains(t, body, "PRIVATE KEY")
+
+	body = getAPI(t, mux, "/api/v1/configs/GatewayProxy%2Fdefault%2Fgp/consumer/jack", http.StatusOK, nil)
+	assert.Contains(t, body, "key-auth")
+	assert.NotContains(t, body, "secret")
+}
+
+func TestDebugAPIRedactsSecretsOfEveryResourceType(t *testing.T) {
+	mux := newDebugAPI(t)
+	secrets := []string{
+		"OIDC SECRET", "UPSTREAM KEY", "ROUTE TOKEN", "SPLIT KEY", "STREAM KEY", "GLOBAL PASSWORD", "METADATA TOKEN",
+	}
+
+	for _, tc := range []struct {
+		resourceType string
+		kept         string
+	}{
+		{resourceType: adctypes.TypeService, kept: "UPSTREAM CERT"},
+		{resourceType: adctypes.TypeRoute, kept: "traffic-split"},
+		{resourceType: adctypes.TypeStreamRoute, kept: "STREAM CERT"},
+		{resourceType: adctypes.TypeGlobalRule, kept: "admin"},
+		{resourceType: adctypes.TypePluginMetadata, kept: "$host"},
+	} {
+		t.Run(tc.resourceType, func(t *testing.T) {
+			body := getAPI(t, mux, "/api/v1/configs/GatewayProxy%2Fdefault%2Fgp/"+tc.resourceType, http.StatusOK, nil)
+			assert.Contains(t, body, tc.kept)
+			assert.Contains(t, body, adctypes.Redacted)
+			for _, secret := range secrets {
+				assert.NotContains(t, body, secret)
+			}
+		})
+	}
+}
+
+func TestDebugAPIAnswersUnknownNamesWithNotFound(t *testing.T) {
+	mux := newDebugAPI(t)
+
+	getAPI(t, mux, "/api/v1/configs/GatewayProxy%2Fdefault%2Fother/route", http.StatusNotFound, nil)
+	getAPI(t, mux, "/api/v1/configs/GatewayProxy%2Fdefault%2Fgp/upstream", http.StatusNotFound, nil)
+	getAPI(t, mux, "/api/v1/configs/GatewayProxy%2Fdefault%2Fgp/route/missing", http.StatusNotFound, nil)
+}
+
+func TestDebugAPILooksUpOrigins(t *testing.T) {
+	mux := newDebugAPI(t)
+
+	var origin APIOrigin
+	getAPI(t, mux, "/api/v1/configs/GatewayProxy%2Fdefault%2Fgp/route/b-route/origin", http.StatusOK, &origin)
+	assert.Equal(t, &APIOwner{Kind: "HTTPRoute", Namespace: "default", Name: "b"}, origin.Owner)
+	assert.Equal(t, []APIOwner{{Kind: "HTTPRoutePolicy", Namespace: "default", Name: "b-policy"}}, origin.Policies)
+
+	var list apiList[APIOrigin]
+	getAPI(t, mux, "/api/v1/objects/HTTPRoutePolicy/default/b-policy", http.StatusOK, &list)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, APIOrigin{
+		Config:   debugConfig,
+		Type:     adctypes.TypeRoute,
+		ID:       "b-route",
+		Owner:    &APIOwner{Kind: "HTTPRoute", Namespace: "default", Name: "b"},
+		Policies: []APIOwner{{Kind: "HTTPRoutePolicy", Namespace: "default", Name: "b-policy"}},
+	}, list.Items[0])
+
+	getAPI(t, mux, "/api/v1/objects/HTTPRoute/default/a", http.StatusOK, &list)
+	require.Len(t, list.Items, 2, "the service and its route")
+
+	getAPI(t, mux, "/api/v1/configs/GatewayProxy%2Fdefault%2Fgp/route/missing/origin", http.StatusNotFound, nil)
+}
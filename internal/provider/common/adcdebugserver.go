@@ -51,6 +51,7 @@ func (asrv *ADCDebugProvider) SetupHandler(pathPrefix string, mux *http.ServeMux
 	asrv.pathPrefix = pathPrefix
 	mux.HandleFunc("/config", asrv.handleConfig)
 	mux.HandleFunc("/", asrv.handleIndex)
+	asrv.setupAPIHandler(mux)
 }
 
 func NewADCDebugProvider(store *cache.Store, configManager *ConfigManager[types.NamespacedNameKind, adctypes.Config]) *ADCDebugProvider {
@@ -50,6 +50,9 @@ type ConfigManager[K comparable, T any] struct {
 	configRefs map[K][]K
 
 	resourceConfigKeys map[K][]K
+
+	// restored holds the keys Restore added that nothing updated or deleted since.
+	restored map[K]struct{}
 }
 
 func NewConfigManager[K comparable, T any]() *ConfigManager[K, T] {
@@ -57,6 +60,7 @@ func NewConfigManager[K comparable, T any]() *ConfigManager[K, T] {
 		resourceConfigKeys: make(map[K][]K),
 		configs:            make(map[K]T),
 		configRefs:         make(map[K][]K),
+		restored:           make(map[K]struct{}),
 	}
 }
 
@@ -85,6 +89,7 @@ func (s *ConfigManager[K, T]) GetConfigRefsByResourceKey(key K) []K {
 func (s *ConfigManager[K, T]) SetConfigRefs(key K, refs []K) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	delete(s.restored, key)
 	s.configRefs[key] = refs
 }
 
@@ -116,6 +121,7 @@ func (s *ConfigManager[K, T]) List() map[K]T {
 func (s *ConfigManager[K, T]) UpdateConfig(key K, cfg T) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	delete(s.restored, key)
 	s.configs[key] = cfg
 }
 
@@ -129,10 +135,12 @@ func (s *ConfigManager[K, T]) Update(
 	parentRefSet := make(map[K]struct{})
 	oldParentRefs := s.resourceConfigKeys[key]
 	newRefs := make([]K, 0, len(mapRefs))
+	delete(s.restored, key)
 
 	for k, v := range mapRefs {
 		newRefs = append(newRefs, k)
 		s.configs[k] = v
+		delete(s.restored, k)
 		parentRefSet[k] = struct{}{}
 	}
 	s.resourceConfigKeys[key] = newRefs
@@ -151,12 +159,14 @@ func (s *ConfigManager[K, T]) Update(
 func (s *ConfigManager[K, T]) Set(key K, cfg T) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	delete(s.restored, key)
 	s.configs[key] = cfg
 }
 
 func (s *ConfigManager[K, T]) Delete(key K) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	delete(s.restored, key)
 	delete(s.resourceConfigKeys, key)
 	delete(s.configs, key)
 	delete(s.configRefs, key)
@@ -165,6 +175,82 @@ func (s *ConfigManager[K, T]) Delete(key K) {
 func (s *ConfigManager[K, T]) DeleteConfig(key K) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	delete(s.restored, key)
 	delete(s.configs, key)
 	delete(s.configRefs, key)
 }
+
+// ConfigManagerState is everything a ConfigManager holds, in a form that can be saved
+// and restored.
+type ConfigManagerState[K comparable, T any] struct {
+	Configs            []ConfigEntry[K, T] `json:"configs"`
+	ConfigRefs         []RefsEntry[K]      `json:"configRefs"`
+	ResourceConfigKeys []RefsEntry[K]      `json:"resourceConfigKeys"`
+}
+
+type ConfigEntry[K comparable, T any] struct {
+	Key    K `json:"key"`
+	Config T `json:"config"`
+}
+
+type RefsEntry[K comparable] struct {
+	Key  K   `json:"key"`
+	Refs []K `json:"refs"`
+}
+
+// State returns everything the manager holds.
+func (s *ConfigManager[K, T]) State() *ConfigManagerState[K, T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := &ConfigManagerState[K, T]{}
+	for k, v := range s.configs {
+		state.Configs = append(state.Configs, ConfigEntry[K, T]{Key: k, Config: v})
+	}
+	for k, refs := range s.configRefs {
+		state.ConfigRefs = append(state.ConfigRefs, RefsEntry[K]{Key: k, Refs: refs})
+	}
+	for k, keys := range s.resourceConfigKeys {
+		state.ResourceConfigKeys = append(state.ResourceConfigKeys, RefsEntry[K]{Key: k, Refs: keys})
+	}
+	return state
+}
+
+// Restore adds what state holds for the keys the manager holds nothing for. Until a key
+// restored is updated or deleted again, DropRestored removes it.
+func (s *ConfigManager[K, T]) Restore(state *ConfigManagerState[K, T]) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, entry := range state.Configs {
+		if _, ok := s.configs[entry.Key]; !ok {
+			s.configs[entry.Key] = entry.Config
+			s.restored[entry.Key] = struct{}{}
+		}
+	}
+	for _, entry := range state.ConfigRefs {
+		if _, ok := s.configRefs[entry.Key]; !ok {
+			s.configRefs[entry.Key] = entry.Refs
+		}
+	}
+	for _, entry := range state.ResourceConfigKeys {
+		if _, ok := s.resourceConfigKeys[entry.Key]; !ok {
+			s.resourceConfigKeys[entry.Key] = entry.Refs
+			s.restored[entry.Key] = struct{}{}
+		}
+	}
+}
+
+// DropRestored removes every key Restore added that was not updated or deleted since,
+// and returns them.
+func (s *ConfigManager[K, T]) DropRestored() []K {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dropped := make([]K, 0, len(s.restored))
+	for key := range s.restored {
+		delete(s.configs, key)
+		delete(s.configRefs, key)
+		delete(s.resourceConfigKeys, key)
+		dropped = append(dropped, key)
+	}
+	s.restored = make(map[K]struct{})
+	return dropped
+}
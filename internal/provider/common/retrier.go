@@ -18,6 +18,8 @@
 package common
 
 import (
+	"maps"
+	"slices"
 	"sync"
 	"time"
 )
@@ -94,3 +96,98 @@ func (r *Retrier) Next() {
 func (r *Retrier) C() <-chan struct{} {
 	return r.ch
 }
+
+// KeyedRetrier retries each key on a backoff of its own, so that a key that keeps failing
+// does not hold back retrying the others. C fires whenever a key is due; Due says which.
+type KeyedRetrier struct {
+	mu         sync.Mutex
+	ch         chan struct{}
+	newBackoff func() Backoff
+	retries    map[string]*keyedRetry
+	due        map[string]struct{}
+}
+
+type keyedRetry struct {
+	backoff Backoff
+	timer   *time.Timer
+}
+
+func NewKeyedRetrier(newBackoff func() Backoff) *KeyedRetrier {
+	return &KeyedRetrier{
+		ch:         make(chan struct{}, 1),
+		newBackoff: newBackoff,
+		retries:    make(map[string]*keyedRetry),
+		due:        make(map[string]struct{}),
+	}
+}
+
+// Next schedules key to be retried, later each time it is called again before Reset.
+func (r *KeyedRetrier) Next(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	retry, ok := r.retries[key]
+	if !ok {
+		retry = &keyedRetry{backoff: r.newBackoff()}
+		r.retries[key] = retry
+	}
+	if retry.timer != nil {
+		retry.timer.Stop()
+	}
+	delete(r.due, key)
+	retry.timer = time.AfterFunc(retry.backoff.Next(), func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if r.retries[key] != retry {
+			return
+		}
+		r.due[key] = struct{}{}
+		select {
+		case r.ch <- struct{}{}:
+		default:
+		}
+	})
+}
+
+// Reset cancels any retry of key and starts its backoff over.
+func (r *KeyedRetrier) Reset(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if retry, ok := r.retries[key]; ok {
+		if retry.timer != nil {
+			retry.timer.Stop()
+		}
+		delete(r.retries, key)
+	}
+	delete(r.due, key)
+}
+
+// Stop cancels every retry.
+func (r *KeyedRetrier) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, retry := range r.retries {
+		if retry.timer != nil {
+			retry.timer.Stop()
+		}
+		delete(r.retries, key)
+	}
+	clear(r.due)
+}
+
+// Due returns the keys whose retry is due, and forgets them until they are scheduled
+// again.
+func (r *KeyedRetrier) Due() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	keys := slices.Sorted(maps.Keys(r.due))
+	clear(r.due)
+	return keys
+}
+
+func (r *KeyedRetrier) C() <-chan struct{} {
+	return r.ch
+}
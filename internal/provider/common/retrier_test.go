@@ -0,0 +1,58 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyedRetrierBacksOffEachKeyOnItsOwn(t *testing.T) {
+	r := NewKeyedRetrier(func() Backoff {
+		return NewExponentialBackoff(10*time.Millisecond, time.Hour)
+	})
+	defer r.Stop()
+
+	// "dead" has failed many times over, and is now retried far apart.
+	for range 10 {
+		r.Next("dead")
+	}
+	r.Next("flaky")
+
+	select {
+	case <-r.C():
+	case <-time.After(5 * time.Second):
+		t.Fatal("no retry is due")
+	}
+	assert.Equal(t, []string{"flaky"}, r.Due())
+
+	r.Reset("dead")
+	r.Next("dead")
+	require.Eventually(t, func() bool {
+		select {
+		case <-r.C():
+			return true
+		default:
+			return false
+		}
+	}, 5*time.Second, 5*time.Millisecond)
+	assert.Equal(t, []string{"dead"}, r.Due(), "a reset key starts its backoff over")
+}
@@ -0,0 +1,359 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package composite implements a provider that mirrors the configuration to several
+// providers at once, such as a data plane and the files a GitOps pipeline picks up.
+package composite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"maps"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
+	adcclient "github.com/apache/apisix-ingress-controller/internal/adc/client"
+	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations"
+	"github.com/apache/apisix-ingress-controller/internal/controller/status"
+	"github.com/apache/apisix-ingress-controller/internal/manager/readiness"
+	"github.com/apache/apisix-ingress-controller/internal/provider"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+	"github.com/apache/apisix-ingress-controller/internal/utils"
+	pkgmetrics "github.com/apache/apisix-ingress-controller/pkg/metrics"
+)
+
+const ProviderTypeComposite = "composite"
+
+// retryInterval is how often a provider retries the updates and deletes it failed while
+// the others took them. Each one is retried on a backoff of its own, doubling up to
+// maxRetryDelay, and given up on after maxRetries: it holds the translate context of the
+// reconcile that failed, which grows staler with every retry.
+const (
+	retryInterval = 10 * time.Second
+	maxRetryDelay = 5 * time.Minute
+	maxRetries    = 8
+)
+
+// member is one provider of a compositeProvider, under the type it was registered as.
+type member struct {
+	name string
+	provider.Provider
+
+	// mu guards pending.
+	mu sync.Mutex
+	// pending holds, per object, the last update or delete this provider failed while
+	// another provider took it. It is retried for this provider alone.
+	pending map[types.NamespacedNameKind]*pendingOp
+}
+
+// pendingOp is an update or delete one provider is left to retry.
+type pendingOp struct {
+	operation string
+	obj       client.Object
+	run       func(context.Context, *member) error
+
+	// attempts counts the retries that failed, and next is when the op is retried.
+	attempts int
+	next     time.Time
+}
+
+// backoff sets when op is retried after a failed attempt, and tells whether it still is.
+func (op *pendingOp) backoff(now time.Time) bool {
+	op.attempts++
+	if op.attempts >= maxRetries {
+		return false
+	}
+	op.next = now.Add(min(retryInterval<<op.attempts, maxRetryDelay))
+	return true
+}
+
+// transient tells whether err may go away when the same op is retried: an object the
+// translator or the data plane refuses is refused again, until it changes and is
+// reconciled anew.
+func transient(err error) bool {
+	var (
+		reasonErr      types.ReasonError
+		strictErr      *annotations.StrictError
+		validationErr  types.ADCValidationError
+		validationErrs types.ADCValidationErrors
+	)
+	switch {
+	case errors.As(err, &reasonErr), errors.As(err, &strictErr),
+		errors.As(err, &validationErr), errors.As(err, &validationErrs):
+		return false
+	case k8serrors.IsInvalid(err), k8serrors.IsBadRequest(err):
+		return false
+	default:
+		return true
+	}
+}
+
+// compositeProvider forwards every update and delete to each of its providers. A
+// provider that fails does not hold up the others: each keeps its own store, queue and
+// retries, and the error of each is reported on its own.
+type compositeProvider struct {
+	members []*member
+	status  *statusAggregator
+	log     logr.Logger
+}
+
+// planner and conflictChecker are what the admission webhook asks the primary provider.
+type planner interface {
+	Plan(task adcclient.Task) ([]*cache.Plan, error)
+}
+
+type conflictChecker interface {
+	CheckRouteConflicts(task adcclient.Task) ([]cache.RouteConflict, error)
+}
+
+// New creates a provider mirroring to every provider type in Options.Providers. Each one
+// saves its state, if any, to a file of its own next to Options.StatePath.
+func New(log logr.Logger, updater status.Updater, readier readiness.ReadinessManager, opts ...provider.Option) (provider.Provider, error) {
+	o := provider.Options{}
+	o.ApplyOptions(opts)
+	if len(o.Providers) == 0 {
+		return nil, errors.New("no providers to mirror to")
+	}
+
+	logger := log.WithName("composite")
+	c := &compositeProvider{
+		status: newStatusAggregator(updater, o.Providers),
+		log:    logger,
+	}
+	for i, name := range o.Providers {
+		if name == ProviderTypeComposite {
+			return nil, errors.New("a composite provider cannot mirror to another")
+		}
+		memberOpts := opts
+		if o.StatePath != "" {
+			memberOpts = append(memberOpts, &provider.Options{StatePath: o.StatePath + "." + name})
+		}
+		p, err := provider.New(name, logger.WithValues("provider", name), c.status.updaterFor(i), readier, memberOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create provider %q: %w", name, err)
+		}
+		c.members = append(c.members, &member{
+			name:     name,
+			Provider: p,
+			pending:  make(map[types.NamespacedNameKind]*pendingOp),
+		})
+	}
+	return c, nil
+}
+
+// Register serves the debug handlers of each provider under its own name, below
+// pathPrefix, and an index of the providers at pathPrefix itself. Like every other
+// provider, it is handed mux with pathPrefix already stripped from the request path.
+func (c *compositeProvider) Register(pathPrefix string, mux *http.ServeMux) {
+	for _, m := range c.members {
+		memberPrefix := pathPrefix + "/" + m.name
+		subMux := http.NewServeMux()
+		m.Register(memberPrefix, subMux)
+		mux.Handle("/"+m.name+"/", http.StripPrefix("/"+m.name, subMux))
+		mux.HandleFunc("/"+m.name, func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, memberPrefix+"/", http.StatusPermanentRedirect)
+		})
+	}
+	mux.HandleFunc("/{$}", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = fmt.Fprint(w, "<html><head><title>Providers</title></head><body><h1>Providers</h1><ul>")
+		for _, m := range c.members {
+			_, _ = fmt.Fprintf(w, `<li><a href="%s/">%s</a></li>`,
+				html.EscapeString(pathPrefix+"/"+m.name), html.EscapeString(m.name))
+		}
+		_, _ = fmt.Fprint(w, "</ul></body></html>")
+	})
+}
+
+func (c *compositeProvider) Update(ctx context.Context, tctx *provider.TranslateContext, obj client.Object) error {
+	return c.forEach(ctx, "update", obj, func(ctx context.Context, m *member) error {
+		return m.Update(ctx, tctx, obj)
+	})
+}
+
+func (c *compositeProvider) Delete(ctx context.Context, obj client.Object) error {
+	c.status.forget(obj)
+	return c.forEach(ctx, "delete", obj, func(ctx context.Context, m *member) error {
+		return m.Delete(ctx, obj)
+	})
+}
+
+// forEach runs op on every provider, whatever the others returned. A provider that fails
+// while another succeeds retries op on its own, see retryPending, so that the object is
+// not requeued and applied again to the providers that took it. Only when every provider
+// failed is the error, the errors of all of them joined, returned for the object to be
+// requeued.
+func (c *compositeProvider) forEach(
+	ctx context.Context,
+	operation string,
+	obj client.Object,
+	op func(context.Context, *member) error,
+) error {
+	key := utils.NamespacedNameKind(obj)
+	var (
+		errs   []error
+		failed []*member
+	)
+	for _, m := range c.members {
+		if err := c.run(ctx, m, operation, obj, op); err != nil {
+			errs = append(errs, fmt.Errorf("provider %s: %w", m.name, err))
+			failed = append(failed, m)
+			continue
+		}
+		m.settle(key, nil)
+	}
+	if len(failed) == len(c.members) {
+		for _, m := range failed {
+			m.settle(key, nil)
+		}
+		return errors.Join(errs...)
+	}
+	for i, m := range failed {
+		if !transient(errs[i]) {
+			// Retrying would fail alike: only a change to the object, which is
+			// reconciled anew, can fix it.
+			m.settle(key, nil)
+			continue
+		}
+		m.settle(key, &pendingOp{operation: operation, obj: obj, run: op})
+	}
+	return nil
+}
+
+// run runs op on m, recording how it went.
+func (c *compositeProvider) run(
+	ctx context.Context,
+	m *member,
+	operation string,
+	obj client.Object,
+	op func(context.Context, *member) error,
+) error {
+	start := time.Now()
+	err := op(ctx, m)
+	result := adctypes.StatusSuccess
+	if err != nil {
+		result = "failure"
+		c.log.Error(err, "provider failed", "provider", m.name, "operation", operation,
+			"object", utils.NamespacedNameKind(obj))
+	}
+	pkgmetrics.RecordProviderOperation(m.name, operation, result, time.Since(start).Seconds())
+	return err
+}
+
+// settle records what m is left to retry for key: op, or, when op is nil, nothing. A
+// later update or delete of key supersedes whatever was pending for it.
+func (m *member) settle(key types.NamespacedNameKind, op *pendingOp) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if op == nil {
+		delete(m.pending, key)
+		return
+	}
+	m.pending[key] = op
+}
+
+// retryPending retries what m failed while the other providers took it, and is due. An
+// op that fails for good, or too many times, is given up on.
+func (c *compositeProvider) retryPending(ctx context.Context, m *member) {
+	m.mu.Lock()
+	pending := maps.Clone(m.pending)
+	m.mu.Unlock()
+	for key, op := range pending {
+		now := time.Now()
+		if now.Before(op.next) {
+			continue
+		}
+		err := c.run(ctx, m, op.operation, op.obj, op.run)
+		m.mu.Lock()
+		// Unless a later update or delete has replaced it since.
+		if m.pending[key] == op {
+			switch {
+			case err == nil:
+				delete(m.pending, key)
+			case !transient(err) || !op.backoff(now):
+				c.log.Error(err, "giving up retrying", "provider", m.name, "operation", op.operation,
+					"object", key, "attempts", op.attempts)
+				delete(m.pending, key)
+			}
+		}
+		m.mu.Unlock()
+	}
+}
+
+// Start runs every provider until ctx is done. One that stops early is logged, and the
+// others keep running; it returns an error only when every provider stopped with one.
+func (c *compositeProvider) Start(ctx context.Context) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, m := range c.members {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			go wait.UntilWithContext(ctx, func(ctx context.Context) { c.retryPending(ctx, m) }, retryInterval)
+			if err := m.Start(ctx); err != nil {
+				c.log.Error(err, "provider stopped", "provider", m.name)
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("provider %s: %w", m.name, err))
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if len(errs) == len(c.members) {
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+func (c *compositeProvider) NeedLeaderElection() bool {
+	for _, m := range c.members {
+		if m.NeedLeaderElection() {
+			return true
+		}
+	}
+	return false
+}
+
+// Plan works out what task would push, relative to what the primary provider holds.
+func (c *compositeProvider) Plan(task adcclient.Task) ([]*cache.Plan, error) {
+	if p, ok := c.members[0].Provider.(planner); ok {
+		return p.Plan(task)
+	}
+	return nil, nil
+}
+
+// CheckRouteConflicts finds the conflicts the routes of task would have with the routes
+// the primary provider holds for other objects.
+func (c *compositeProvider) CheckRouteConflicts(task adcclient.Task) ([]cache.RouteConflict, error) {
+	if p, ok := c.members[0].Provider.(conflictChecker); ok {
+		return p.CheckRouteConflicts(task)
+	}
+	return nil, nil
+}
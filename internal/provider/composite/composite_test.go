@@ -0,0 +1,308 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package composite
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	"github.com/apache/apisix-ingress-controller/internal/controller/status"
+	"github.com/apache/apisix-ingress-controller/internal/manager/readiness"
+	"github.com/apache/apisix-ingress-controller/internal/provider"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+type fakeProvider struct {
+	updater  status.Updater
+	err      error
+	startErr error
+
+	mu      sync.Mutex
+	updates []string
+}
+
+func (p *fakeProvider) Register(pathPrefix string, mux *http.ServeMux) {
+	mux.HandleFunc("/config", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(pathPrefix))
+	})
+}
+
+func (p *fakeProvider) Update(_ context.Context, _ *provider.TranslateContext, obj client.Object) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.updates = append(p.updates, obj.GetName())
+	return p.err
+}
+
+func (p *fakeProvider) fail(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.err = err
+}
+
+func (p *fakeProvider) Delete(context.Context, client.Object) error {
+	return p.err
+}
+
+func (p *fakeProvider) Start(ctx context.Context) error {
+	if p.startErr != nil {
+		return p.startErr
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func (p *fakeProvider) NeedLeaderElection() bool {
+	return true
+}
+
+// newTestComposite creates a compositeProvider over fake providers registered as names.
+func newTestComposite(t *testing.T, updater status.Updater, fakes map[string]*fakeProvider, names ...string) *compositeProvider {
+	for _, name := range names {
+		fake := fakes[name]
+		provider.Register(name, func(_ logr.Logger, u status.Updater, _ readiness.ReadinessManager, _ ...provider.Option) (provider.Provider, error) {
+			fake.updater = u
+			return fake, nil
+		})
+	}
+	p, err := New(logr.Discard(), updater, nil, &provider.Options{Providers: names})
+	require.NoError(t, err)
+	return p.(*compositeProvider)
+}
+
+type recordingUpdater struct {
+	updates []status.Update
+}
+
+func (u *recordingUpdater) Update(update status.Update) {
+	u.updates = append(u.updates, update)
+}
+
+func TestUpdateReachesEveryProvider(t *testing.T) {
+	fakes := map[string]*fakeProvider{
+		"fake-old": {err: errors.New("connection refused")},
+		"fake-new": {},
+	}
+	p := newTestComposite(t, &recordingUpdater{}, fakes, "fake-old", "fake-new")
+
+	gp := &v1alpha1.GatewayProxy{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "apisix"}}
+	err := p.Update(context.Background(), provider.NewDefaultTranslateContext(context.Background()), gp)
+	assert.NoError(t, err, "the object is not requeued for the providers that took it")
+	assert.Equal(t, []string{"apisix"}, fakes["fake-old"].updates)
+	assert.Equal(t, []string{"apisix"}, fakes["fake-new"].updates, "a failing provider does not hold up the others")
+
+	// The provider that failed retries on its own, until it succeeds.
+	p.retryPending(context.Background(), p.members[0])
+	assert.Equal(t, []string{"apisix", "apisix"}, fakes["fake-old"].updates)
+	p.retryPending(context.Background(), p.members[0])
+	assert.Equal(t, []string{"apisix", "apisix"}, fakes["fake-old"].updates, "a failed retry backs off")
+	fakes["fake-old"].fail(nil)
+	makeDue(p.members[0])
+	p.retryPending(context.Background(), p.members[0])
+	p.retryPending(context.Background(), p.members[0])
+	assert.Equal(t, []string{"apisix", "apisix", "apisix"}, fakes["fake-old"].updates)
+	assert.Equal(t, []string{"apisix"}, fakes["fake-new"].updates)
+}
+
+// makeDue has every op m is left to retry retried now.
+func makeDue(m *member) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, op := range m.pending {
+		op.next = time.Time{}
+	}
+}
+
+func TestUpdateGivesUpOnWhatFailsForGood(t *testing.T) {
+	fakes := map[string]*fakeProvider{
+		"fake-refusing": {err: types.ReasonError{Reason: "InvalidKind", Message: "invalid kind"}},
+		"fake-taking":   {},
+	}
+	p := newTestComposite(t, &recordingUpdater{}, fakes, "fake-refusing", "fake-taking")
+
+	gp := &v1alpha1.GatewayProxy{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "apisix"}}
+	require.NoError(t, p.Update(context.Background(), provider.NewDefaultTranslateContext(context.Background()), gp))
+	assert.Empty(t, p.members[0].pending, "what is refused is refused again")
+
+	// What may go away on its own is retried, but not forever.
+	fakes["fake-refusing"].fail(errors.New("connection refused"))
+	require.NoError(t, p.Update(context.Background(), provider.NewDefaultTranslateContext(context.Background()), gp))
+	for range maxRetries {
+		require.Len(t, p.members[0].pending, 1)
+		p.retryPending(context.Background(), p.members[0])
+		makeDue(p.members[0])
+	}
+	assert.Empty(t, p.members[0].pending)
+	assert.Len(t, fakes["fake-refusing"].updates, 2+maxRetries)
+}
+
+func TestUpdateFailsWhenEveryProviderFails(t *testing.T) {
+	fakes := map[string]*fakeProvider{
+		"fake-down-a": {err: errors.New("connection refused")},
+		"fake-down-b": {err: errors.New("timeout")},
+	}
+	p := newTestComposite(t, &recordingUpdater{}, fakes, "fake-down-a", "fake-down-b")
+
+	gp := &v1alpha1.GatewayProxy{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "apisix"}}
+	err := p.Update(context.Background(), provider.NewDefaultTranslateContext(context.Background()), gp)
+	assert.ErrorContains(t, err, "provider fake-down-a: connection refused")
+	assert.ErrorContains(t, err, "provider fake-down-b: timeout")
+	for _, m := range p.members {
+		assert.Empty(t, m.pending, "a requeued object is retried through the reconcile alone")
+	}
+}
+
+func TestRegisterServesEveryProviderBelowThePrefix(t *testing.T) {
+	fakes := map[string]*fakeProvider{
+		"fake-a": {},
+		"fake-b": {},
+	}
+	p := newTestComposite(t, &recordingUpdater{}, fakes, "fake-a", "fake-b")
+	// Mounted the way the debug server mounts every provider.
+	subMux, mux := http.NewServeMux(), http.NewServeMux()
+	p.Register("/debug", subMux)
+	mux.Handle("/debug/", http.StripPrefix("/debug", subMux))
+
+	get := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+	rec := get("/debug/fake-b/config")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "/debug/fake-b", rec.Body.String())
+
+	rec = get("/debug/")
+	assert.Contains(t, rec.Body.String(), `href="/debug/fake-a/"`)
+	assert.Contains(t, rec.Body.String(), `href="/debug/fake-b/"`)
+
+	rec = get("/debug/fake-a")
+	assert.Equal(t, http.StatusPermanentRedirect, rec.Code)
+	assert.Equal(t, "/debug/fake-a/", rec.Header().Get("Location"))
+}
+
+func TestStartFailsOnlyWhenEveryProviderFails(t *testing.T) {
+	fakes := map[string]*fakeProvider{
+		"fake-broken": {startErr: errors.New("broken")},
+		"fake-fine":   {},
+	}
+	p := newTestComposite(t, &recordingUpdater{}, fakes, "fake-broken", "fake-fine")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.NoError(t, p.Start(ctx))
+
+	fakes = map[string]*fakeProvider{
+		"fake-broken-a": {startErr: errors.New("broken")},
+		"fake-broken-b": {startErr: errors.New("broken too")},
+	}
+	p = newTestComposite(t, &recordingUpdater{}, fakes, "fake-broken-a", "fake-broken-b")
+	err := p.Start(context.Background())
+	assert.ErrorContains(t, err, "provider fake-broken-a: broken")
+	assert.ErrorContains(t, err, "provider fake-broken-b: broken too")
+}
+
+func setCondition(condition metav1.Condition) status.Mutator {
+	return status.MutatorFunc(func(obj client.Object) client.Object {
+		gp := obj.(*v1alpha1.GatewayProxy).DeepCopy()
+		gp.Status.Conditions = append(gp.Status.Conditions, condition)
+		return gp
+	})
+}
+
+func TestStatusReportsTheWorstOfEveryProvider(t *testing.T) {
+	updater := &recordingUpdater{}
+	fakes := map[string]*fakeProvider{
+		"fake-primary":   {},
+		"fake-secondary": {},
+	}
+	p := newTestComposite(t, updater, fakes, "fake-primary", "fake-secondary")
+	key := k8stypes.NamespacedName{Namespace: "default", Name: "apisix"}
+
+	fakes["fake-primary"].updater.Update(status.Update{
+		NamespacedName: key,
+		Resource:       &v1alpha1.GatewayProxy{},
+		Mutator: setCondition(metav1.Condition{
+			Type: "DataPlaneInSync", Status: metav1.ConditionTrue, Reason: "InSync", Message: "in sync",
+		}),
+	})
+	fakes["fake-secondary"].updater.Update(status.Update{
+		NamespacedName: key,
+		Resource:       &v1alpha1.GatewayProxy{},
+		Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
+			gp := setCondition(metav1.Condition{
+				Type: "DataPlaneInSync", Status: metav1.ConditionFalse, Reason: "Drifted", Message: "2 routes missing",
+			}).Mutate(obj).(*v1alpha1.GatewayProxy)
+			gp.Status.Conditions = append(gp.Status.Conditions, metav1.Condition{
+				Type: "ConfigWritten", Status: metav1.ConditionTrue, Reason: "Written",
+			})
+			return gp
+		}),
+	})
+	require.Len(t, updater.updates, 2)
+
+	// The earlier update, written first, reports what every provider said since.
+	live := &v1alpha1.GatewayProxy{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "apisix"}}
+	result := updater.updates[0].Mutator.Mutate(live).(*v1alpha1.GatewayProxy)
+	require.Len(t, result.Status.Conditions, 2)
+	assert.Equal(t, "Drifted", result.Status.Conditions[0].Reason)
+
+	result = updater.updates[1].Mutator.Mutate(live).(*v1alpha1.GatewayProxy)
+	require.Len(t, result.Status.Conditions, 2)
+	assert.Equal(t, metav1.ConditionFalse, result.Status.Conditions[0].Status)
+	assert.Equal(t, "Drifted", result.Status.Conditions[0].Reason)
+	assert.Equal(t, "fake-secondary: 2 routes missing", result.Status.Conditions[0].Message)
+	assert.Equal(t, "ConfigWritten", result.Status.Conditions[1].Type)
+	assert.Empty(t, live.Status.Conditions, "the live object is left as it is")
+
+	// Once the last update is written, nothing is kept of the object; a write retried
+	// on a conflict still has what it took.
+	assert.Empty(t, p.status.pending)
+	result = updater.updates[1].Mutator.Mutate(live).(*v1alpha1.GatewayProxy)
+	assert.Equal(t, "Drifted", result.Status.Conditions[0].Reason)
+}
+
+func TestStatusIsForgottenOnDelete(t *testing.T) {
+	fakes := map[string]*fakeProvider{
+		"fake-kept":    {},
+		"fake-deleted": {},
+	}
+	p := newTestComposite(t, &recordingUpdater{}, fakes, "fake-kept", "fake-deleted")
+	gp := &v1alpha1.GatewayProxy{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "apisix"}}
+
+	fakes["fake-kept"].updater.Update(status.Update{
+		NamespacedName: client.ObjectKeyFromObject(gp),
+		Resource:       &v1alpha1.GatewayProxy{},
+		Mutator:        setCondition(metav1.Condition{Type: "DataPlaneInSync", Status: metav1.ConditionTrue}),
+	})
+	require.Len(t, p.status.pending, 1)
+	require.NoError(t, p.Delete(context.Background(), gp))
+	assert.Empty(t, p.status.pending, "an update never written is dropped with the object")
+}
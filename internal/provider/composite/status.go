@@ -0,0 +1,270 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package composite
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/apisix-ingress-controller/internal/controller/status"
+)
+
+// conditionConflicted is the one condition reported that is bad when True.
+const conditionConflicted = "Conflicted"
+
+// statusKey identifies the object a status update is for.
+type statusKey struct {
+	resource string
+	k8stypes.NamespacedName
+}
+
+// statusAggregator combines the status updates of the providers of a compositeProvider,
+// so that each object reports the worst of what its providers said about it rather than
+// whatever the last one to sync said.
+type statusAggregator struct {
+	updater status.Updater
+	names   []string
+
+	mu sync.Mutex
+	// pending holds, per object, what each provider said about it since its status was
+	// last written. See take.
+	pending map[statusKey]*pendingStatus
+}
+
+// pendingStatus is what each provider said about an object, in the order of the
+// providers, and how many updates of it were made.
+type pendingStatus struct {
+	mutators []status.Mutator
+	version  uint64
+}
+
+func newStatusAggregator(updater status.Updater, names []string) *statusAggregator {
+	return &statusAggregator{
+		updater: updater,
+		names:   names,
+		pending: make(map[statusKey]*pendingStatus),
+	}
+}
+
+func statusKeyOf(resource client.Object, nn k8stypes.NamespacedName) statusKey {
+	return statusKey{resource: fmt.Sprintf("%T", resource), NamespacedName: nn}
+}
+
+// updaterFor is the status updater of the i-th provider.
+func (a *statusAggregator) updaterFor(i int) status.Updater {
+	return memberUpdater{aggregator: a, index: i}
+}
+
+type memberUpdater struct {
+	aggregator *statusAggregator
+	index      int
+}
+
+func (u memberUpdater) Update(update status.Update) {
+	u.aggregator.update(u.index, update)
+}
+
+// update keeps what the i-th provider last said about an object, and has the status of
+// the object recomputed from what every provider said.
+func (a *statusAggregator) update(i int, update status.Update) {
+	key := statusKeyOf(update.Resource, update.NamespacedName)
+	a.mu.Lock()
+	pending, ok := a.pending[key]
+	if !ok {
+		pending = &pendingStatus{mutators: make([]status.Mutator, len(a.names))}
+		a.pending[key] = pending
+	}
+	pending.mutators[i] = update.Mutator
+	pending.version++
+	version := pending.version
+	a.mu.Unlock()
+
+	var (
+		once     sync.Once
+		mutators []status.Mutator
+	)
+	a.updater.Update(status.Update{
+		NamespacedName: update.NamespacedName,
+		Resource:       update.Resource,
+		Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
+			// A write retried on a conflict mutates again what the first try took.
+			once.Do(func() { mutators = a.take(key, version) })
+			return a.mutate(mutators, obj)
+		}),
+	})
+}
+
+// take returns what every provider said about the object of key. Updates are written
+// in the order they are made, so once the last one made is, nothing is left to merge
+// and the object is forgotten; an earlier one finds it gone, and writes nothing.
+func (a *statusAggregator) take(key statusKey, version uint64) []status.Mutator {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	pending, ok := a.pending[key]
+	if !ok {
+		return nil
+	}
+	if pending.version == version {
+		delete(a.pending, key)
+	}
+	return slices.Clone(pending.mutators)
+}
+
+// forget drops what the providers said about obj, which is gone.
+func (a *statusAggregator) forget(obj client.Object) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.pending, statusKeyOf(obj, client.ObjectKeyFromObject(obj)))
+}
+
+// mutate applies mutators, what every provider said about the object, to obj. The first
+// provider to have said anything sets every field; the conditions are then merged with
+// those the others set, the worst of each winning.
+func (a *statusAggregator) mutate(mutators []status.Mutator, obj client.Object) client.Object {
+	var (
+		base    client.Object
+		baseIdx int
+		merged  map[string]any
+	)
+	for i, mutator := range mutators {
+		if mutator == nil {
+			continue
+		}
+		result := mutator.Mutate(obj.DeepCopyObject().(client.Object))
+		if result == nil {
+			continue
+		}
+		if base == nil {
+			base, baseIdx = result, i
+			if len(mutators) == 1 {
+				return base
+			}
+			u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(result)
+			if err != nil {
+				return base
+			}
+			merged = u
+			continue
+		}
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(result)
+		if err != nil {
+			continue
+		}
+		mergeStatus(merged, u, a.names[baseIdx], a.names[i])
+	}
+	if base == nil || merged == nil {
+		return base
+	}
+
+	out := reflect.New(reflect.TypeOf(base).Elem()).Interface().(client.Object)
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(merged, out); err != nil {
+		return base
+	}
+	return out
+}
+
+// mergeStatus merges the conditions of other into those of base: those of the status
+// itself, and those of each of its parents or ancestors when both list the same ones.
+func mergeStatus(base, other map[string]any, baseName, otherName string) {
+	baseStatus, _ := base["status"].(map[string]any)
+	otherStatus, _ := other["status"].(map[string]any)
+	if baseStatus == nil || otherStatus == nil {
+		return
+	}
+	mergeConditionsField(baseStatus, otherStatus, baseName, otherName)
+	for _, field := range []string{"parents", "ancestors"} {
+		baseList, _ := baseStatus[field].([]any)
+		otherList, _ := otherStatus[field].([]any)
+		if len(baseList) != len(otherList) {
+			continue
+		}
+		for j := range baseList {
+			b, _ := baseList[j].(map[string]any)
+			o, _ := otherList[j].(map[string]any)
+			if b != nil && o != nil {
+				mergeConditionsField(b, o, baseName, otherName)
+			}
+		}
+	}
+}
+
+func mergeConditionsField(base, other map[string]any, baseName, otherName string) {
+	baseConditions, _ := base["conditions"].([]any)
+	otherConditions, _ := other["conditions"].([]any)
+	if len(otherConditions) == 0 {
+		return
+	}
+	for _, oc := range otherConditions {
+		o, _ := oc.(map[string]any)
+		if o == nil {
+			continue
+		}
+		found := false
+		for j, bc := range baseConditions {
+			b, _ := bc.(map[string]any)
+			if b == nil || b["type"] != o["type"] {
+				continue
+			}
+			found = true
+			if conditionRank(o) > conditionRank(b) {
+				b = prefixMessage(o, otherName)
+			} else if conditionRank(o) < conditionRank(b) {
+				b = prefixMessage(b, baseName)
+			}
+			baseConditions[j] = b
+			break
+		}
+		if !found {
+			baseConditions = append(baseConditions, o)
+		}
+	}
+	base["conditions"] = baseConditions
+}
+
+// conditionRank orders a condition from good to bad: True is good, but for Conflicted.
+func conditionRank(condition map[string]any) int {
+	good, bad := string(metav1.ConditionTrue), string(metav1.ConditionFalse)
+	if condition["type"] == conditionConflicted {
+		good, bad = bad, good
+	}
+	switch condition["status"] {
+	case good:
+		return 0
+	case bad:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// prefixMessage names the provider a condition the providers disagree on came from.
+func prefixMessage(condition map[string]any, name string) map[string]any {
+	message, _ := condition["message"].(string)
+	if !strings.HasPrefix(message, name+": ") {
+		condition["message"] = name + ": " + message
+	}
+	return condition
+}
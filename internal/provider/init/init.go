@@ -24,6 +24,7 @@ import (
 	"github.com/apache/apisix-ingress-controller/internal/manager/readiness"
 	"github.com/apache/apisix-ingress-controller/internal/provider"
 	"github.com/apache/apisix-ingress-controller/internal/provider/apisix"
+	"github.com/apache/apisix-ingress-controller/internal/provider/composite"
 )
 
 func init() {
@@ -35,7 +36,16 @@ func init() {
 			opts ...provider.Option,
 		) (provider.Provider, error) {
 			opts = append(opts, provider.WithDefaultBackendMode("apisix-standalone"))
-			opts = append(opts, provider.WithDefaultResolveEndpoints())
 			return apisix.New(log, statusUpdater, readinessManager, opts...)
 		})
+	provider.Register("file",
+		func(log logr.Logger,
+			statusUpdater status.Updater,
+			readinessManager readiness.ReadinessManager,
+			opts ...provider.Option,
+		) (provider.Provider, error) {
+			opts = append(opts, provider.WithWriteFiles())
+			return apisix.New(log, statusUpdater, readinessManager, opts...)
+		})
+	provider.Register(composite.ProviderTypeComposite, composite.New)
 }
@@ -20,7 +20,10 @@ package provider
 import (
 	"time"
 
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
 	"github.com/apache/apisix-ingress-controller/internal/controller/config"
+	"github.com/apache/apisix-ingress-controller/internal/controller/events"
 )
 
 type Option interface {
@@ -28,12 +31,36 @@ type Option interface {
 }
 
 type Options struct {
-	SyncTimeout             time.Duration
-	SyncPeriod              time.Duration
-	InitSyncDelay           time.Duration
-	DefaultBackendMode      string
-	DefaultResolveEndpoints bool
-	ListenerPortMatchMode   config.ListenerPortMatchMode
+	SyncTimeout      time.Duration
+	SyncPeriod       time.Duration
+	InitSyncDelay    time.Duration
+	SyncBatchWindow  time.Duration
+	SyncBatchMaxSize int
+	// SyncConcurrency is how many configs are synced at once.
+	SyncConcurrency int
+	// DriftCheckInterval enables the drift detector when positive.
+	DriftCheckInterval time.Duration
+	DriftAutoHeal      bool
+	// StatePath is the file the store is saved to after each sync, and restored from on
+	// start. Empty disables it.
+	StatePath string
+	// DefaultBackendMode is the mode of a GatewayProxy whose control plane sets none. It
+	// also decides, for such a GatewayProxy, whether the endpoints of its control plane
+	// Service are resolved, as they are for apisix-standalone.
+	DefaultBackendMode    string
+	DefaultExecutor       string
+	ListenerPortMatchMode config.ListenerPortMatchMode
+	// WriteFiles makes the provider write the configuration of each GatewayProxy out as
+	// set by FileOutput, instead of pushing it to a control plane.
+	WriteFiles bool
+	FileOutput config.FileOutputConfig
+	// Providers are the provider types a composite provider mirrors to.
+	Providers []string
+	// KubeClient writes the ConfigMaps or Secrets of the configmap and secret FileOutput
+	// targets.
+	KubeClient client.Client
+	// Events reports what the data plane made of each resource as Kubernetes Events.
+	Events *events.Recorder
 }
 
 func (o *Options) ApplyToList(lo *Options) {
@@ -46,15 +73,48 @@ func (o *Options) ApplyToList(lo *Options) {
 	if o.InitSyncDelay > 0 {
 		lo.InitSyncDelay = o.InitSyncDelay
 	}
+	if o.SyncBatchWindow > 0 {
+		lo.SyncBatchWindow = o.SyncBatchWindow
+	}
+	if o.SyncBatchMaxSize > 0 {
+		lo.SyncBatchMaxSize = o.SyncBatchMaxSize
+	}
+	if o.SyncConcurrency > 0 {
+		lo.SyncConcurrency = o.SyncConcurrency
+	}
+	if o.DriftCheckInterval > 0 {
+		lo.DriftCheckInterval = o.DriftCheckInterval
+	}
+	if o.DriftAutoHeal {
+		lo.DriftAutoHeal = o.DriftAutoHeal
+	}
+	if o.StatePath != "" {
+		lo.StatePath = o.StatePath
+	}
 	if o.DefaultBackendMode != "" {
 		lo.DefaultBackendMode = o.DefaultBackendMode
 	}
-	if o.DefaultResolveEndpoints {
-		lo.DefaultResolveEndpoints = o.DefaultResolveEndpoints
+	if o.DefaultExecutor != "" {
+		lo.DefaultExecutor = o.DefaultExecutor
 	}
 	if o.ListenerPortMatchMode != "" {
 		lo.ListenerPortMatchMode = o.ListenerPortMatchMode
 	}
+	if o.WriteFiles {
+		lo.WriteFiles = o.WriteFiles
+	}
+	if o.FileOutput != (config.FileOutputConfig{}) {
+		lo.FileOutput = o.FileOutput
+	}
+	if len(o.Providers) > 0 {
+		lo.Providers = o.Providers
+	}
+	if o.KubeClient != nil {
+		lo.KubeClient = o.KubeClient
+	}
+	if o.Events != nil {
+		lo.Events = o.Events
+	}
 }
 
 func (o *Options) ApplyOptions(opts []Option) *Options {
@@ -74,12 +134,12 @@ func WithDefaultBackendMode(mode string) Option {
 	return defaultBackendModeOption(mode)
 }
 
-type defaultResolveEndpointsOption bool
+type writeFilesOption bool
 
-func (r defaultResolveEndpointsOption) ApplyToList(o *Options) {
-	o.DefaultResolveEndpoints = bool(r)
+func (w writeFilesOption) ApplyToList(o *Options) {
+	o.WriteFiles = bool(w)
 }
 
-func WithDefaultResolveEndpoints() Option {
-	return defaultResolveEndpointsOption(true)
+func WithWriteFiles() Option {
+	return writeFilesOption(true)
 }
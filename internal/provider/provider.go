@@ -52,6 +52,10 @@ type TranslateContext struct {
 	// injection (see Translator.shouldInjectServerPortVars) and is computed from
 	// the matched RouteParentRefContext, preserving each parentRef's Gateway.
 	HasExplicitListenerMatch bool
+	// SkipIngressDefaultBackend is true when another Ingress of the same
+	// IngressClass owns the default backend, so the defaultBackend of the
+	// Ingress being translated must not produce a catch-all route.
+	SkipIngressDefaultBackend bool
 
 	EndpointSlices         map[k8stypes.NamespacedName][]discoveryv1.EndpointSlice
 	Secrets                map[k8stypes.NamespacedName]*corev1.Secret
@@ -67,6 +67,8 @@ const (
 	AppProtocolHTTPS = "https"
 	AppProtocolWS    = "kubernetes.io/ws"
 	AppProtocolWSS   = "kubernetes.io/wss"
+	AppProtocolH2C   = "kubernetes.io/h2c"
+	AppProtocolGRPC  = "grpc"
 )
 
 func KindOf(obj any) string {
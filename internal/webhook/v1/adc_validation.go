@@ -23,6 +23,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
 	v1alpha1 "github.com/apache/apisix-ingress-controller/api/v1alpha1"
@@ -38,53 +39,78 @@ import (
 )
 
 type adcAdmissionValidator struct {
-	kubeClient             client.Client
-	client                 *adcclient.Client
-	translator             *adctranslator.Translator
-	log                    logr.Logger
-	defaultResolveEndpoint bool
+	kubeClient client.Client
+	client     *adcclient.Client
+	translator *adctranslator.Translator
+	log        logr.Logger
+	// defaultMode is the mode of a GatewayProxy whose control plane sets none.
+	defaultMode string
 }
 
 func newADCAdmissionValidator(kubeClient client.Client, log logr.Logger) (*adcAdmissionValidator, error) {
-	defaultMode := string(config.ControllerConfig.ProviderConfig.Type)
-	cli, err := adcclient.New(log, defaultMode, config.ControllerConfig.ExecADCTimeout.Duration)
+	defaultMode := defaultBackendMode(config.ControllerConfig.ProviderConfig)
+	cli, err := adcclient.New(log, defaultMode, string(config.ControllerConfig.ProviderConfig.Executor), config.ControllerConfig.ExecADCTimeout.Duration)
 	if err != nil {
 		return nil, err
 	}
 
 	return &adcAdmissionValidator{
-		kubeClient:             kubeClient,
-		client:                 cli,
-		translator:             adctranslator.NewTranslator(log, config.ControllerConfig.ListenerPortMatchMode),
-		log:                    log.WithName("adc-validation"),
-		defaultResolveEndpoint: config.ControllerConfig.ProviderConfig.Type == config.ProviderTypeStandalone,
+		kubeClient:  kubeClient,
+		client:      cli,
+		translator:  adctranslator.NewTranslator(log, config.ControllerConfig.ListenerPortMatchMode),
+		log:         log.WithName("adc-validation"),
+		defaultMode: defaultMode,
 	}, nil
 }
 
-func (v *adcAdmissionValidator) Validate(ctx context.Context, obj client.Object) error {
+// defaultBackendMode is the mode a GatewayProxy whose control plane sets none runs in
+// under pc. The file provider pushes nothing, so what such a GatewayProxy validates
+// against is the ADC backend a control plane runs by default; a composite provider runs
+// in the mode of the one provider it mirrors to that pushes to a control plane.
+func defaultBackendMode(pc config.ProviderConfig) string {
+	switch pc.Type {
+	case config.ProviderTypeAPISIX, config.ProviderTypeStandalone:
+		return string(pc.Type)
+	case config.ProviderTypeComposite:
+		for _, providerType := range pc.Providers {
+			if providerType == config.ProviderTypeAPISIX || providerType == config.ProviderTypeStandalone {
+				return string(providerType)
+			}
+		}
+	}
+	return string(config.ProviderTypeAPISIX)
+}
+
+// Admit validates obj with ADC, and checks the routes it translates to for conflicts
+// with those of other objects. When the webhook is configured to, it also returns, as
+// warnings, a summary of what admitting obj would push to the data plane.
+func (v *adcAdmissionValidator) Admit(ctx context.Context, obj client.Object) (admission.Warnings, error) {
 	if v == nil {
-		return nil
+		return nil, nil
 	}
 
 	task, err := v.buildTask(ctx, obj)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if task == nil {
-		return nil
+		return nil, nil
 	}
 
 	if err := v.client.Validate(ctx, *task); err != nil {
 		var validationErrs internaltypes.ADCValidationErrors
 		if errors.As(err, &validationErrs) {
-			return err
+			return nil, err
 		}
 
 		v.log.Error(err, "ADC validation unavailable, allowing admission", "resource", utils.NamespacedNameKind(obj))
-		return nil
 	}
 
-	return nil
+	conflictWarnings, err := v.routeConflicts(*task)
+	if err != nil {
+		return nil, err
+	}
+	return append(conflictWarnings, v.planWarnings(*task, obj)...), nil
 }
 
 func (v *adcAdmissionValidator) buildTask(ctx context.Context, obj client.Object) (*adcclient.Task, error) {
@@ -190,7 +216,7 @@ func (v *adcAdmissionValidator) buildTask(ctx context.Context, obj client.Object
 func (v *adcAdmissionValidator) buildConfigs(tctx *provider.TranslateContext) (map[internaltypes.NamespacedNameKind]adctypes.Config, error) {
 	configs := make(map[internaltypes.NamespacedNameKind]adctypes.Config, len(tctx.GatewayProxies))
 	for key, gp := range tctx.GatewayProxies {
-		cfg, err := v.translator.TranslateGatewayProxyToConfig(tctx, &gp, v.defaultResolveEndpoint)
+		cfg, err := v.translator.TranslateGatewayProxyToConfig(tctx, &gp, v.defaultMode)
 		if err != nil {
 			return nil, err
 		}
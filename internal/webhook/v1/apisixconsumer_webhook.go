@@ -80,7 +80,8 @@ func (v *ApisixConsumerCustomValidator) ValidateCreate(ctx context.Context, obj
 	if len(warnings) > 0 {
 		return warnings, nil
 	}
-	return warnings, v.adcValidator.Validate(ctx, consumer)
+	planWarnings, err := v.adcValidator.Admit(ctx, consumer)
+	return append(warnings, planWarnings...), err
 }
 
 func (v *ApisixConsumerCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
@@ -101,7 +102,8 @@ func (v *ApisixConsumerCustomValidator) ValidateUpdate(ctx context.Context, oldO
 	if len(warnings) > 0 {
 		return warnings, nil
 	}
-	return warnings, v.adcValidator.Validate(ctx, consumer)
+	planWarnings, err := v.adcValidator.Admit(ctx, consumer)
+	return append(warnings, planWarnings...), err
 }
 
 func (*ApisixConsumerCustomValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
@@ -75,7 +75,8 @@ func (v *ApisixRouteCustomValidator) ValidateCreate(ctx context.Context, obj run
 		apisixRouteLog.Error(v.initErr, "ADC validator init failed, skipping ADC validation")
 		return warnings, nil
 	}
-	return warnings, v.adcValidator.Validate(ctx, route)
+	planWarnings, err := v.adcValidator.Admit(ctx, route)
+	return append(warnings, planWarnings...), err
 }
 
 func (v *ApisixRouteCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
@@ -93,7 +94,8 @@ func (v *ApisixRouteCustomValidator) ValidateUpdate(ctx context.Context, oldObj,
 		apisixRouteLog.Error(v.initErr, "ADC validator init failed, skipping ADC validation")
 		return warnings, nil
 	}
-	return warnings, v.adcValidator.Validate(ctx, route)
+	planWarnings, err := v.adcValidator.Admit(ctx, route)
+	return append(warnings, planWarnings...), err
 }
 
 func (*ApisixRouteCustomValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
@@ -85,7 +85,8 @@ func (v *ApisixTlsCustomValidator) ValidateCreate(ctx context.Context, obj runti
 		return warnings, nil
 	}
 
-	return warnings, v.adcValidator.Validate(ctx, tls)
+	planWarnings, err := v.adcValidator.Admit(ctx, tls)
+	return append(warnings, planWarnings...), err
 }
 
 func (v *ApisixTlsCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
@@ -109,7 +110,8 @@ func (v *ApisixTlsCustomValidator) ValidateUpdate(ctx context.Context, oldObj, n
 		return warnings, nil
 	}
 
-	return warnings, v.adcValidator.Validate(ctx, tls)
+	planWarnings, err := v.adcValidator.Admit(ctx, tls)
+	return append(warnings, planWarnings...), err
 }
 
 func (*ApisixTlsCustomValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
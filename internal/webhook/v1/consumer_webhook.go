@@ -82,7 +82,8 @@ func (v *ConsumerCustomValidator) ValidateCreate(ctx context.Context, obj runtim
 	if err := v.validateDuplicateKeyAuthCredentials(ctx, consumer); err != nil {
 		return warnings, err
 	}
-	return warnings, v.adcValidator.Validate(ctx, consumer)
+	planWarnings, err := v.adcValidator.Admit(ctx, consumer)
+	return append(warnings, planWarnings...), err
 }
 
 func (v *ConsumerCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
@@ -103,7 +104,8 @@ func (v *ConsumerCustomValidator) ValidateUpdate(ctx context.Context, oldObj, ne
 	if err := v.validateDuplicateKeyAuthCredentials(ctx, consumer); err != nil {
 		return warnings, err
 	}
-	return warnings, v.adcValidator.Validate(ctx, consumer)
+	planWarnings, err := v.adcValidator.Admit(ctx, consumer)
+	return append(warnings, planWarnings...), err
 }
 
 func (*ConsumerCustomValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	v1alpha1 "github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	"github.com/apache/apisix-ingress-controller/internal/controller/config"
 	"github.com/apache/apisix-ingress-controller/internal/webhook/v1/reference"
 )
 
@@ -63,6 +64,9 @@ func (v *GatewayProxyCustomValidator) ValidateCreate(ctx context.Context, obj ru
 	}
 	gatewayProxyLog.Info("Validation for GatewayProxy upon creation", "name", gp.GetName(), "namespace", gp.GetNamespace())
 
+	if err := validateControlPlaneMode(gp); err != nil {
+		return nil, err
+	}
 	warnings := v.collectWarnings(ctx, gp)
 	if err := v.validateGatewayProxyConflict(ctx, gp); err != nil {
 		return nil, err
@@ -78,6 +82,9 @@ func (v *GatewayProxyCustomValidator) ValidateUpdate(ctx context.Context, oldObj
 	}
 	gatewayProxyLog.Info("Validation for GatewayProxy upon update", "name", gp.GetName(), "namespace", gp.GetNamespace())
 
+	if err := validateControlPlaneMode(gp); err != nil {
+		return nil, err
+	}
 	warnings := v.collectWarnings(ctx, gp)
 	if err := v.validateGatewayProxyConflict(ctx, gp); err != nil {
 		return nil, err
@@ -90,6 +97,22 @@ func (v *GatewayProxyCustomValidator) ValidateDelete(context.Context, runtime.Ob
 	return nil, nil
 }
 
+// validateControlPlaneMode rejects a control plane mode the controller cannot sync to. The
+// mode decides, for this GatewayProxy alone, whether its configuration is pushed to one
+// etcd-backed Admin API or to every data plane instance behind its service.
+func validateControlPlaneMode(gp *v1alpha1.GatewayProxy) error {
+	if gp.Spec.Provider == nil || gp.Spec.Provider.ControlPlane == nil {
+		return nil
+	}
+	switch config.ProviderType(gp.Spec.Provider.ControlPlane.Mode) {
+	case "", config.ProviderTypeAPISIX, config.ProviderTypeStandalone:
+		return nil
+	default:
+		return fmt.Errorf("invalid control plane mode %q: must be %q or %q",
+			gp.Spec.Provider.ControlPlane.Mode, config.ProviderTypeAPISIX, config.ProviderTypeStandalone)
+	}
+}
+
 func (v *GatewayProxyCustomValidator) collectWarnings(ctx context.Context, gp *v1alpha1.GatewayProxy) admission.Warnings {
 	var warnings admission.Warnings
 
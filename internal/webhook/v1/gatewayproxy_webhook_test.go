@@ -365,3 +365,22 @@ func TestGatewayProxyValidator_AllowsEndpointOverlapWithDifferentAdminKey(t *tes
 	require.NoError(t, err)
 	require.Empty(t, warnings)
 }
+
+func TestGatewayProxyValidator_ControlPlaneMode(t *testing.T) {
+	validator := buildGatewayProxyValidator(t)
+
+	for _, mode := range []string{"", "apisix", "apisix-standalone"} {
+		gp := newGatewayProxy()
+		gp.Spec.Provider.ControlPlane.Mode = mode
+		_, err := validator.ValidateCreate(context.Background(), gp)
+		require.NoError(t, err, "mode %q", mode)
+	}
+
+	gp := newGatewayProxy()
+	gp.Spec.Provider.ControlPlane.Mode = "standalone"
+	_, err := validator.ValidateCreate(context.Background(), gp)
+	require.ErrorContains(t, err, `invalid control plane mode "standalone"`)
+
+	_, err = validator.ValidateUpdate(context.Background(), newGatewayProxy(), gp)
+	require.ErrorContains(t, err, `invalid control plane mode "standalone"`)
+}
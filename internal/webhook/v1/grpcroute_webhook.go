@@ -43,16 +43,22 @@ func SetupGRPCRouteWebhookWithManager(mgr ctrl.Manager) error {
 // +kubebuilder:webhook:path=/validate-gateway-networking-k8s-io-v1-grpcroute,mutating=false,failurePolicy=fail,sideEffects=None,groups=gateway.networking.k8s.io,resources=grpcroutes,verbs=create;update,versions=v1,name=vgrpcroute-v1.kb.io,admissionReviewVersions=v1,failurePolicy=Ignore
 
 type GRPCRouteCustomValidator struct {
-	Client  client.Client
-	checker reference.Checker
+	Client       client.Client
+	checker      reference.Checker
+	adcValidator *adcAdmissionValidator
 }
 
 var _ admission.Validator[runtime.Object] = &GRPCRouteCustomValidator{}
 
 func NewGRPCRouteCustomValidator(c client.Client) *GRPCRouteCustomValidator {
+	adcValidator, err := newADCAdmissionValidator(c, grpcRouteLog)
+	if err != nil {
+		grpcRouteLog.Error(err, "ADC validator init failed, skipping route conflict checks")
+	}
 	return &GRPCRouteCustomValidator{
-		Client:  c,
-		checker: reference.NewChecker(c, grpcRouteLog),
+		Client:       c,
+		checker:      reference.NewChecker(c, grpcRouteLog),
+		adcValidator: adcValidator,
 	}
 }
 
@@ -71,7 +77,9 @@ func (v *GRPCRouteCustomValidator) ValidateCreate(ctx context.Context, obj runti
 		return nil, nil
 	}
 
-	return v.collectWarnings(ctx, route), nil
+	warnings := v.collectWarnings(ctx, route)
+	conflictWarnings, err := v.adcValidator.AdmitRoutes(ctx, route)
+	return append(warnings, conflictWarnings...), err
 }
 
 func (v *GRPCRouteCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
@@ -89,7 +97,9 @@ func (v *GRPCRouteCustomValidator) ValidateUpdate(ctx context.Context, oldObj, n
 		return nil, nil
 	}
 
-	return v.collectWarnings(ctx, route), nil
+	warnings := v.collectWarnings(ctx, route)
+	conflictWarnings, err := v.adcValidator.AdmitRoutes(ctx, route)
+	return append(warnings, conflictWarnings...), err
 }
 
 func (*GRPCRouteCustomValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
@@ -43,16 +43,22 @@ func SetupHTTPRouteWebhookWithManager(mgr ctrl.Manager) error {
 // +kubebuilder:webhook:path=/validate-gateway-networking-k8s-io-v1-httproute,mutating=false,failurePolicy=fail,sideEffects=None,groups=gateway.networking.k8s.io,resources=httproutes,verbs=create;update,versions=v1,name=vhttproute-v1.kb.io,admissionReviewVersions=v1,failurePolicy=Ignore
 
 type HTTPRouteCustomValidator struct {
-	Client  client.Client
-	checker reference.Checker
+	Client       client.Client
+	checker      reference.Checker
+	adcValidator *adcAdmissionValidator
 }
 
 var _ admission.Validator[runtime.Object] = &HTTPRouteCustomValidator{}
 
 func NewHTTPRouteCustomValidator(c client.Client) *HTTPRouteCustomValidator {
+	adcValidator, err := newADCAdmissionValidator(c, httpRouteLog)
+	if err != nil {
+		httpRouteLog.Error(err, "ADC validator init failed, skipping route conflict checks")
+	}
 	return &HTTPRouteCustomValidator{
-		Client:  c,
-		checker: reference.NewChecker(c, httpRouteLog),
+		Client:       c,
+		checker:      reference.NewChecker(c, httpRouteLog),
+		adcValidator: adcValidator,
 	}
 }
 
@@ -71,7 +77,9 @@ func (v *HTTPRouteCustomValidator) ValidateCreate(ctx context.Context, obj runti
 		return nil, nil
 	}
 
-	return v.collectWarnings(ctx, route), nil
+	warnings := v.collectWarnings(ctx, route)
+	conflictWarnings, err := v.adcValidator.AdmitRoutes(ctx, route)
+	return append(warnings, conflictWarnings...), err
 }
 
 func (v *HTTPRouteCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
@@ -89,7 +97,9 @@ func (v *HTTPRouteCustomValidator) ValidateUpdate(ctx context.Context, oldObj, n
 		return nil, nil
 	}
 
-	return v.collectWarnings(ctx, route), nil
+	warnings := v.collectWarnings(ctx, route)
+	conflictWarnings, err := v.adcValidator.AdmitRoutes(ctx, route)
+	return append(warnings, conflictWarnings...), err
 }
 
 func (*HTTPRouteCustomValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
@@ -18,17 +18,24 @@ package v1
 import (
 	"context"
 	"fmt"
+	"strconv"
 
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	apiv2 "github.com/apache/apisix-ingress-controller/api/v2"
+	"github.com/apache/apisix-ingress-controller/internal/adc/translator"
 	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations"
+	"github.com/apache/apisix-ingress-controller/internal/adc/translator/annotations/upstream"
 	"github.com/apache/apisix-ingress-controller/internal/controller"
+	internaltypes "github.com/apache/apisix-ingress-controller/internal/types"
 	"github.com/apache/apisix-ingress-controller/internal/webhook/v1/reference"
 	sslvalidator "github.com/apache/apisix-ingress-controller/internal/webhook/v1/ssl"
 )
@@ -52,16 +59,22 @@ func SetupIngressWebhookWithManager(mgr ctrl.Manager) error {
 // NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
 // as this struct is used only for temporary operations and does not need to be deeply copied.
 type IngressCustomValidator struct {
-	Client  client.Client
-	checker reference.Checker
+	Client       client.Client
+	checker      reference.Checker
+	adcValidator *adcAdmissionValidator
 }
 
 var _ admission.Validator[runtime.Object] = &IngressCustomValidator{}
 
 func NewIngressCustomValidator(c client.Client) *IngressCustomValidator {
+	adcValidator, err := newADCAdmissionValidator(c, ingresslog)
+	if err != nil {
+		ingresslog.Error(err, "ADC validator init failed, skipping route conflict checks")
+	}
 	return &IngressCustomValidator{
-		Client:  c,
-		checker: reference.NewChecker(c, ingresslog),
+		Client:       c,
+		checker:      reference.NewChecker(c, ingresslog),
+		adcValidator: adcValidator,
 	}
 }
 
@@ -87,7 +100,9 @@ func (v *IngressCustomValidator) ValidateCreate(ctx context.Context, obj runtime
 	}
 
 	warnings := v.collectReferenceWarnings(ctx, ingress)
-	return warnings, nil
+	warnings = append(warnings, v.collectDefaultBackendWarnings(ctx, ingress)...)
+	conflictWarnings, err := v.adcValidator.AdmitRoutes(ctx, ingress)
+	return append(warnings, conflictWarnings...), err
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Ingress.
@@ -112,13 +127,17 @@ func (v *IngressCustomValidator) ValidateUpdate(ctx context.Context, oldObj, new
 	}
 
 	warnings := v.collectReferenceWarnings(ctx, ingress)
-	return warnings, nil
+	warnings = append(warnings, v.collectDefaultBackendWarnings(ctx, ingress)...)
+	conflictWarnings, err := v.adcValidator.AdmitRoutes(ctx, ingress)
+	return append(warnings, conflictWarnings...), err
 }
 
 // validateAnnotations rejects annotation combinations that would otherwise be
 // silently dropped, leaving the route without a requested security plugin.
 // enable-csrf with no csrf-key is refused here so kubectl apply fails loudly
 // instead of programming a route the operator believes is protected.
+// Sizes and durations are validated with the same parsers the translator uses,
+// so a malformed value is reported at admission rather than ignored.
 func validateAnnotations(ingress *networkingv1.Ingress) error {
 	e := annotations.NewExtractor(ingress.Annotations)
 	if e.GetBoolAnnotation(annotations.AnnotationsEnableCsrf) &&
@@ -126,9 +145,68 @@ func validateAnnotations(ingress *networkingv1.Ingress) error {
 		return fmt.Errorf("annotation %q is enabled but %q is missing or empty",
 			annotations.AnnotationsEnableCsrf, annotations.AnnotationsCsrfKey)
 	}
+
+	if err := validateAuthAnnotations(e); err != nil {
+		return err
+	}
+
+	if value := e.GetStringAnnotation(annotations.AnnotationsClientMaxBodySize); value != "" {
+		if _, err := annotations.ParseSize(value); err != nil {
+			return fmt.Errorf("annotation %q: %w", annotations.AnnotationsClientMaxBodySize, err)
+		}
+	}
+	if value := e.GetStringAnnotation(annotations.AnnotationsBackendProtocol); value != "" {
+		if _, err := upstream.ParseBackendProtocol(value); err != nil {
+			return fmt.Errorf("annotation %q: %w", annotations.AnnotationsBackendProtocol, err)
+		}
+	}
+	if value := e.GetStringAnnotation(annotations.AnnotationsRequestBuffering); value != "" {
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("annotation %q: invalid boolean %q", annotations.AnnotationsRequestBuffering, value)
+		}
+	}
+	for _, name := range []string{
+		annotations.AnnotationsTimeoutConnect,
+		annotations.AnnotationsTimeoutRead,
+		annotations.AnnotationsTimeoutSend,
+	} {
+		if value := e.GetStringAnnotation(name); value != "" {
+			if _, err := annotations.ParseDurationSeconds(value); err != nil {
+				return fmt.Errorf("annotation %q: %w", name, err)
+			}
+		}
+	}
 	return nil
 }
 
+// validateAuthAnnotations rejects unknown auth types and openidConnect without
+// the settings the openid-connect plugin requires, which would otherwise leave
+// the route unprogrammed.
+func validateAuthAnnotations(e annotations.Extractor) error {
+	switch authType := e.GetStringAnnotation(annotations.AnnotationsAuthType); authType {
+	case "", annotations.AuthTypeKeyAuth, annotations.AuthTypeBasicAuth,
+		annotations.AuthTypeJwtAuth, annotations.AuthTypeHMACAuth:
+		return nil
+	case annotations.AuthTypeOpenIDConnect:
+		for _, name := range []string{
+			annotations.AnnotationsOIDCDiscovery,
+			annotations.AnnotationsOIDCClientID,
+			annotations.AnnotationsOIDCClientSecretRef,
+		} {
+			if e.GetStringAnnotation(name) == "" {
+				return fmt.Errorf("annotation %q is required when %q is %q",
+					name, annotations.AnnotationsAuthType, authType)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("annotation %q has unsupported value %q, expected one of %s, %s, %s, %s or %s",
+			annotations.AnnotationsAuthType, authType,
+			annotations.AuthTypeKeyAuth, annotations.AuthTypeBasicAuth, annotations.AuthTypeJwtAuth,
+			annotations.AuthTypeHMACAuth, annotations.AuthTypeOpenIDConnect)
+	}
+}
+
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Ingress.
 func (v *IngressCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	return nil, nil
@@ -179,6 +257,18 @@ func (v *IngressCustomValidator) collectReferenceWarnings(ctx context.Context, i
 			if path.Backend.Service != nil {
 				addServiceWarning(path.Backend.Service.Name)
 			}
+			if ref := path.Backend.Resource; ref != nil && !translator.IsSupportedIngressResourceBackend(ref) {
+				warnings = append(warnings, unsupportedResourceBackendWarning(ref))
+			}
+		}
+	}
+
+	if backend := ingress.Spec.DefaultBackend; backend != nil {
+		if backend.Service != nil {
+			addServiceWarning(backend.Service.Name)
+		}
+		if ref := backend.Resource; ref != nil && !translator.IsSupportedIngressResourceBackend(ref) {
+			warnings = append(warnings, unsupportedResourceBackendWarning(ref))
 		}
 	}
 
@@ -186,5 +276,39 @@ func (v *IngressCustomValidator) collectReferenceWarnings(ctx context.Context, i
 		addSecretWarning(tls.SecretName)
 	}
 
+	if ingress.Annotations[annotations.AnnotationsAuthType] == annotations.AuthTypeOpenIDConnect {
+		addSecretWarning(ingress.Annotations[annotations.AnnotationsOIDCClientSecretRef])
+	}
+
 	return warnings
 }
+
+func unsupportedResourceBackendWarning(ref *corev1.TypedLocalObjectReference) string {
+	return fmt.Sprintf("Resource backend %s/%s %s is not supported and will be ignored, only %s/%s is supported",
+		ptr.Deref(ref.APIGroup, ""), ref.Kind, ref.Name, apiv2.GroupVersion.Group, internaltypes.KindApisixUpstream)
+}
+
+// collectDefaultBackendWarnings warns when the defaultBackend of the Ingress
+// will not be programmed because another Ingress of the same IngressClass
+// already owns the catch-all route.
+func (v *IngressCustomValidator) collectDefaultBackendWarnings(ctx context.Context, ingress *networkingv1.Ingress) admission.Warnings {
+	if ingress.Spec.DefaultBackend == nil {
+		return nil
+	}
+	ingressClass, err := controller.FindMatchingIngressClass(ctx, v.Client, ingresslog, ingress)
+	if err != nil {
+		return nil
+	}
+	owner, err := controller.FindIngressDefaultBackendOwner(ctx, v.Client, ingressClass, ingress)
+	if err != nil {
+		ingresslog.Error(err, "failed to find the owner of the default backend", "ingressClass", ingressClass.Name)
+		return nil
+	}
+	if owner == nil || (owner.Namespace == ingress.Namespace && owner.Name == ingress.Name) {
+		return nil
+	}
+	return admission.Warnings{fmt.Sprintf(
+		"defaultBackend conflicts with Ingress %s/%s which already provides the default backend of IngressClass %s; it will be ignored",
+		owner.Namespace, owner.Name, ingressClass.Name,
+	)}
+}
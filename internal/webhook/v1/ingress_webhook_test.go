@@ -58,6 +58,7 @@ func buildIngressValidator(t *testing.T, objects ...runtime.Object) *IngressCust
 	builder := fake.NewClientBuilder().
 		WithScheme(scheme).
 		WithIndex(&networkingv1.IngressClass{}, indexer.IngressClass, indexer.IngressClassIndexFunc).
+		WithIndex(&networkingv1.Ingress{}, indexer.IngressClassRef, indexer.IngressClassRefIndexFunc).
 		WithRuntimeObjects(allObjects...)
 
 	return NewIngressCustomValidator(builder.Build())
@@ -158,3 +159,107 @@ func TestIngressCustomValidator_AllowsEnableCsrfWithKey(t *testing.T) {
 	_, err = validator.ValidateCreate(context.Background(), csrfIngress(nil))
 	require.NoError(t, err)
 }
+
+func TestIngressCustomValidator_ValidatesClientControlAnnotations(t *testing.T) {
+	validator := buildIngressValidator(t)
+
+	for _, anno := range []map[string]string{
+		{"k8s.apisix.apache.org/client-max-body-size": "10mb"},
+		{"k8s.apisix.apache.org/client-max-body-size": "-1"},
+		{"k8s.apisix.apache.org/request-buffering": "sometimes"},
+		{"k8s.apisix.apache.org/timeout-read": "500ms"},
+		{"k8s.apisix.apache.org/timeout-connect": "soon"},
+		{"k8s.apisix.apache.org/backend-protocol": "H2C"},
+	} {
+		_, err := validator.ValidateCreate(context.Background(), csrfIngress(anno))
+		require.Error(t, err, "annotations %v should be rejected", anno)
+	}
+
+	_, err := validator.ValidateCreate(context.Background(), csrfIngress(map[string]string{
+		"k8s.apisix.apache.org/client-max-body-size": "10m",
+		"k8s.apisix.apache.org/request-buffering":    "false",
+		"k8s.apisix.apache.org/timeout-connect":      "5s",
+		"k8s.apisix.apache.org/timeout-read":         "2m",
+		"k8s.apisix.apache.org/timeout-send":         "30",
+	}))
+	require.NoError(t, err)
+}
+
+func TestIngressCustomValidator_ValidatesAuthAnnotations(t *testing.T) {
+	validator := buildIngressValidator(t)
+
+	_, err := validator.ValidateCreate(context.Background(), csrfIngress(map[string]string{
+		"k8s.apisix.apache.org/auth-type": "ldapAuth",
+	}))
+	require.Error(t, err)
+
+	// openidConnect without a client secret reference
+	_, err = validator.ValidateCreate(context.Background(), csrfIngress(map[string]string{
+		"k8s.apisix.apache.org/auth-type":      "openidConnect",
+		"k8s.apisix.apache.org/oidc-discovery": "https://idp.example.com/.well-known/openid-configuration",
+		"k8s.apisix.apache.org/oidc-client-id": "dashboard",
+	}))
+	require.Error(t, err)
+
+	for _, authType := range []string{"keyAuth", "basicAuth", "jwtAuth", "hmacAuth"} {
+		_, err = validator.ValidateCreate(context.Background(), csrfIngress(map[string]string{
+			"k8s.apisix.apache.org/auth-type": authType,
+		}))
+		require.NoError(t, err, "auth-type %s should be accepted", authType)
+	}
+
+	warnings, err := validator.ValidateCreate(context.Background(), csrfIngress(map[string]string{
+		"k8s.apisix.apache.org/auth-type":              "openidConnect",
+		"k8s.apisix.apache.org/oidc-discovery":         "https://idp.example.com/.well-known/openid-configuration",
+		"k8s.apisix.apache.org/oidc-client-id":         "dashboard",
+		"k8s.apisix.apache.org/oidc-client-secret-ref": "oidc-secret",
+	}))
+	require.NoError(t, err)
+	require.Contains(t, warnings, "Referenced Secret 'default/oidc-secret' not found")
+}
+
+func TestIngressCustomValidator_WarnsForDefaultBackendConflict(t *testing.T) {
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "fallback", Namespace: "default"}}
+	defaultBackend := &networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{Name: "fallback"},
+	}
+	owner := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "owner",
+			Namespace:         "default",
+			CreationTimestamp: metav1.Now(),
+		},
+		Spec: networkingv1.IngressSpec{DefaultBackend: defaultBackend},
+	}
+	validator := buildIngressValidator(t, service, owner)
+
+	obj := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "late", Namespace: "default"},
+		Spec:       networkingv1.IngressSpec{DefaultBackend: defaultBackend},
+	}
+	warnings, err := validator.ValidateCreate(context.Background(), obj)
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "conflicts with Ingress default/owner")
+
+	// the owner itself gets no warning on update
+	warnings, err = validator.ValidateUpdate(context.Background(), owner, owner)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+}
+
+func TestIngressCustomValidator_WarnsForUnsupportedResourceBackend(t *testing.T) {
+	validator := buildIngressValidator(t)
+	obj := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ingress", Namespace: "default"},
+		Spec: networkingv1.IngressSpec{
+			DefaultBackend: &networkingv1.IngressBackend{
+				Resource: &corev1.TypedLocalObjectReference{Kind: "ConfigMap", Name: "static"},
+			},
+		},
+	}
+	warnings, err := validator.ValidateCreate(context.Background(), obj)
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "Resource backend /ConfigMap static is not supported")
+}
@@ -0,0 +1,150 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
+	adcclient "github.com/apache/apisix-ingress-controller/internal/adc/client"
+	"github.com/apache/apisix-ingress-controller/internal/controller/config"
+)
+
+// maxPlanRequestBytes bounds the manifest the plan endpoint reads.
+const maxPlanRequestBytes = 1 << 20
+
+// ConfigPlanner works out what a task would push to the data plane, relative to what the
+// controller holds for each of its configs.
+type ConfigPlanner interface {
+	Plan(task adcclient.Task) ([]*cache.Plan, error)
+}
+
+var configPlanner ConfigPlanner
+
+// SetConfigPlanner sets what plans are worked out against. Without one, admission warnings
+// carry no plan and the plan endpoint is unavailable.
+func SetConfigPlanner(planner ConfigPlanner) {
+	configPlanner = planner
+}
+
+// Plan translates obj the way admission validates it, and works out what applying it
+// would push to the data plane of each config it goes to.
+func (v *adcAdmissionValidator) Plan(ctx context.Context, obj client.Object) ([]*cache.Plan, error) {
+	if configPlanner == nil {
+		return nil, errors.New("no provider to plan against")
+	}
+	task, err := v.buildTask(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, nil
+	}
+	return configPlanner.Plan(*task)
+}
+
+// planWarnings summarizes what applying task would push, one warning per config, when
+// the webhook is configured to.
+func (v *adcAdmissionValidator) planWarnings(task adcclient.Task, obj client.Object) admission.Warnings {
+	if configPlanner == nil || config.ControllerConfig.Webhook == nil || !config.ControllerConfig.Webhook.PlanWarnings {
+		return nil
+	}
+	plans, err := configPlanner.Plan(task)
+	if err != nil {
+		v.log.Error(err, "failed to plan, skipping plan warnings", "resource", task.Key)
+		return nil
+	}
+	var warnings admission.Warnings
+	for _, plan := range plans {
+		warnings = append(warnings, fmt.Sprintf("Applying %s/%s changes %s: %s",
+			obj.GetNamespace(), obj.GetName(), plan.Config, plan.Summary()))
+	}
+	return warnings
+}
+
+// PlanHandler serves the plan of a manifest on the debug server.
+type PlanHandler struct {
+	validator *adcAdmissionValidator
+	decoder   runtime.Decoder
+}
+
+// NewPlanHandler returns a PlanHandler that translates manifests of the kinds admission
+// validates with ADC: ApisixRoute, ApisixConsumer, ApisixTls and Consumer.
+func NewPlanHandler(kubeClient client.Client, scheme *runtime.Scheme, log logr.Logger) (*PlanHandler, error) {
+	validator, err := newADCAdmissionValidator(kubeClient, log)
+	if err != nil {
+		return nil, err
+	}
+	return &PlanHandler{
+		validator: validator,
+		decoder:   serializer.NewCodecFactory(scheme).UniversalDeserializer(),
+	}, nil
+}
+
+func (h *PlanHandler) Register(_ string, mux *http.ServeMux) {
+	mux.HandleFunc("/plan", h.handlePlan)
+}
+
+// handlePlan answers a POST of one YAML or JSON manifest with the plan of applying it,
+// as JSON.
+func (h *PlanHandler) handlePlan(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "POST a manifest to plan it", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPlanRequestBytes))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	decoded, _, err := h.decoder.Decode(body, nil, nil)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode manifest: %s", err), http.StatusBadRequest)
+		return
+	}
+	obj, ok := decoded.(client.Object)
+	if !ok {
+		http.Error(w, fmt.Sprintf("cannot plan a %T", decoded), http.StatusBadRequest)
+		return
+	}
+
+	plans, err := h.validator.Plan(r.Context(), obj)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if plans == nil {
+		plans = []*cache.Plan{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(plans)
+}
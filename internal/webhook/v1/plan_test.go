@@ -0,0 +1,148 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	apisixv2 "github.com/apache/apisix-ingress-controller/api/v2"
+	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
+	adcclient "github.com/apache/apisix-ingress-controller/internal/adc/client"
+	"github.com/apache/apisix-ingress-controller/internal/controller/config"
+)
+
+// fakePlanner plans every route of a task as added to each of its configs.
+type fakePlanner struct {
+	tasks []adcclient.Task
+}
+
+func (p *fakePlanner) Plan(task adcclient.Task) ([]*cache.Plan, error) {
+	p.tasks = append(p.tasks, task)
+	var plans []*cache.Plan
+	for _, cfg := range task.Configs {
+		plan := &cache.Plan{Config: cfg.Name}
+		for _, service := range task.Resources.Services {
+			for _, route := range service.Routes {
+				plan.Routes.Added = append(plan.Routes.Added, route.ID)
+			}
+		}
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+func withPlanWarnings(t *testing.T, planner ConfigPlanner) {
+	t.Helper()
+	prevWebhook, prevPlanner := config.ControllerConfig.Webhook, configPlanner
+	config.ControllerConfig.Webhook = &config.WebhookConfig{PlanWarnings: true}
+	SetConfigPlanner(planner)
+	t.Cleanup(func() {
+		config.ControllerConfig.Webhook = prevWebhook
+		SetConfigPlanner(prevPlanner)
+	})
+}
+
+func TestApisixRouteValidator_WarnsWithThePlan(t *testing.T) {
+	serverURL := withMockADCServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requireValidateRequest(t, r)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"success":true}`))
+	})
+	planner := &fakePlanner{}
+	withPlanWarnings(t, planner)
+
+	route := &apisixv2.ApisixRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "default"},
+		Spec: apisixv2.ApisixRouteSpec{
+			IngressClassName: "apisix",
+			HTTP: []apisixv2.ApisixRouteHTTP{{
+				Name: "rule",
+				Match: apisixv2.ApisixRouteHTTPMatch{
+					Paths: []string{"/demo"},
+				},
+				Backends: []apisixv2.ApisixRouteHTTPBackend{{
+					ServiceName:        "backend",
+					ServicePort:        intstr.FromInt(80),
+					ResolveGranularity: apisixv2.ResolveGranularityService,
+				}},
+			}},
+		},
+	}
+	objects := append(managedIngressClassWithGatewayProxy(serverURL),
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				ClusterIP: "10.0.0.1",
+				Ports:     []corev1.ServicePort{{Port: 80}},
+			},
+		},
+	)
+
+	warnings, err := buildApisixRouteValidator(t, objects...).ValidateCreate(context.Background(), route)
+	require.NoError(t, err)
+	require.Len(t, planner.tasks, 1, "the plan comes from the task that was validated")
+	require.Len(t, warnings, 1)
+	require.Contains(t, warnings[0], "Applying default/demo changes")
+	require.Contains(t, warnings[0], "default/gateway-proxy: routes +1 ~0 -0")
+}
+
+func TestApisixRouteValidator_NoPlanWarningsUnlessConfigured(t *testing.T) {
+	serverURL := withMockADCServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"success":true}`))
+	})
+	planner := &fakePlanner{}
+	withPlanWarnings(t, planner)
+	config.ControllerConfig.Webhook.PlanWarnings = false
+
+	route := &apisixv2.ApisixRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "default"},
+		Spec: apisixv2.ApisixRouteSpec{
+			IngressClassName: "apisix",
+			HTTP: []apisixv2.ApisixRouteHTTP{{
+				Name: "rule",
+				Backends: []apisixv2.ApisixRouteHTTPBackend{{
+					ServiceName:        "backend",
+					ServicePort:        intstr.FromInt(80),
+					ResolveGranularity: apisixv2.ResolveGranularityService,
+				}},
+			}},
+		},
+	}
+	objects := append(managedIngressClassWithGatewayProxy(serverURL),
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				ClusterIP: "10.0.0.1",
+				Ports:     []corev1.ServicePort{{Port: 80}},
+			},
+		},
+	)
+
+	warnings, err := buildApisixRouteValidator(t, objects...).ValidateCreate(context.Background(), route)
+	require.NoError(t, err)
+	require.Empty(t, warnings)
+	require.Empty(t, planner.tasks)
+}
@@ -0,0 +1,164 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
+	adcclient "github.com/apache/apisix-ingress-controller/internal/adc/client"
+	adctranslator "github.com/apache/apisix-ingress-controller/internal/adc/translator"
+	"github.com/apache/apisix-ingress-controller/internal/controller"
+	"github.com/apache/apisix-ingress-controller/internal/controller/config"
+	"github.com/apache/apisix-ingress-controller/internal/provider"
+	"github.com/apache/apisix-ingress-controller/internal/utils"
+)
+
+// RouteConflictChecker finds the conflicts the routes of a task would have, in each of
+// its configs, with the routes of other objects.
+type RouteConflictChecker interface {
+	CheckRouteConflicts(task adcclient.Task) ([]cache.RouteConflict, error)
+}
+
+var routeConflictChecker RouteConflictChecker
+
+// SetRouteConflictChecker sets what routes are checked for conflicts against. Without
+// one, admission does not check routes for conflicts.
+func SetRouteConflictChecker(checker RouteConflictChecker) {
+	routeConflictChecker = checker
+}
+
+func routeConflictMode() config.RouteConflictMode {
+	if config.ControllerConfig.Webhook == nil || config.ControllerConfig.Webhook.RouteConflicts == "" {
+		return config.RouteConflictModeWarn
+	}
+	return config.ControllerConfig.Webhook.RouteConflicts
+}
+
+// AdmitRoutes checks the routes an HTTPRoute, GRPCRoute or Ingress translates to for
+// conflicts with the routes of other objects. Like the SSL conflict check, it is best
+// effort: an object that cannot be translated is admitted.
+func (v *adcAdmissionValidator) AdmitRoutes(ctx context.Context, obj client.Object) (admission.Warnings, error) {
+	if v == nil || routeConflictChecker == nil || routeConflictMode() == config.RouteConflictModeOff {
+		return nil, nil
+	}
+	task, err := v.buildRouteTask(ctx, obj)
+	if err != nil {
+		v.log.Error(err, "failed to translate routes, skipping route conflict check", "resource", utils.NamespacedNameKind(obj))
+		return nil, nil
+	}
+	if task == nil {
+		return nil, nil
+	}
+	return v.routeConflicts(*task)
+}
+
+// routeConflicts warns about, or denies, the conflicts of the routes of task.
+func (v *adcAdmissionValidator) routeConflicts(task adcclient.Task) (admission.Warnings, error) {
+	mode := routeConflictMode()
+	if routeConflictChecker == nil || mode == config.RouteConflictModeOff ||
+		task.Resources == nil || len(task.Resources.Services) == 0 {
+		return nil, nil
+	}
+	conflicts, err := routeConflictChecker.CheckRouteConflicts(task)
+	if errors.Is(err, adcclient.ErrRoutesNotHeld) {
+		// Admission is served by every replica, but only the leader can check: say so,
+		// rather than admit as if there were no conflict.
+		v.log.Info("route conflicts not checked", "resource", task.Key, "reason", err.Error())
+		return admission.Warnings{"Route conflicts were not checked: " + err.Error()}, nil
+	}
+	if err != nil {
+		v.log.Error(err, "failed to check route conflicts", "resource", task.Key)
+		return nil, nil
+	}
+	if len(conflicts) == 0 {
+		return nil, nil
+	}
+	if mode == config.RouteConflictModeDeny {
+		return nil, errors.New(FormatRouteConflicts(conflicts))
+	}
+	warnings := make(admission.Warnings, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		warnings = append(warnings, "Route conflict: "+conflict.String())
+	}
+	return warnings, nil
+}
+
+// FormatRouteConflicts renders a human-readable error message for multiple conflicts.
+func FormatRouteConflicts(conflicts []cache.RouteConflict) string {
+	var sb strings.Builder
+	sb.WriteString("route conflicts detected:")
+	for _, conflict := range conflicts {
+		sb.WriteString("\n- ")
+		sb.WriteString(conflict.String())
+	}
+	return sb.String()
+}
+
+// buildRouteTask translates the routes of obj the way its reconciler and the provider do.
+// It returns nil when nothing would be pushed for obj.
+func (v *adcAdmissionValidator) buildRouteTask(ctx context.Context, obj client.Object) (*adcclient.Task, error) {
+	var (
+		tctx   *provider.TranslateContext
+		result *adctranslator.TranslateResult
+		err    error
+	)
+	switch resource := obj.(type) {
+	case *gatewayv1.HTTPRoute:
+		var route *gatewayv1.HTTPRoute
+		tctx, route, err = controller.PrepareHTTPRouteForValidation(ctx, v.kubeClient, v.log, resource.DeepCopy())
+		if err != nil || tctx == nil {
+			return nil, err
+		}
+		result, err = v.translator.TranslateHTTPRoute(tctx, route)
+	case *gatewayv1.GRPCRoute:
+		tctx, err = controller.PrepareGRPCRouteForValidation(ctx, v.kubeClient, v.log, resource.DeepCopy())
+		if err != nil || tctx == nil {
+			return nil, err
+		}
+		result, err = v.translator.TranslateGRPCRoute(tctx, resource.DeepCopy())
+	case *networkingv1.Ingress:
+		tctx, err = controller.PrepareIngressForValidation(ctx, v.kubeClient, v.log, resource.DeepCopy())
+		if err != nil || tctx == nil {
+			return nil, err
+		}
+		result, err = v.translator.TranslateIngress(tctx, resource.DeepCopy())
+	default:
+		return v.buildTask(ctx, obj)
+	}
+	if err != nil || result == nil {
+		return nil, err
+	}
+
+	configs, err := v.buildConfigs(tctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(configs) == 0 {
+		return nil, nil
+	}
+	return v.newTask(obj, configs, []string{adctypes.TypeService}, result), nil
+}
@@ -0,0 +1,207 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package v1
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	apisixv1alpha1 "github.com/apache/apisix-ingress-controller/api/v1alpha1"
+	apisixv2 "github.com/apache/apisix-ingress-controller/api/v2"
+	"github.com/apache/apisix-ingress-controller/internal/adc/cache"
+	adcclient "github.com/apache/apisix-ingress-controller/internal/adc/client"
+	"github.com/apache/apisix-ingress-controller/internal/controller/config"
+	"github.com/apache/apisix-ingress-controller/internal/controller/indexer"
+	"github.com/apache/apisix-ingress-controller/internal/controller/label"
+)
+
+// storeChecker checks every config of a task against the one config its store holds.
+type storeChecker struct {
+	store *cache.Store
+}
+
+func (c *storeChecker) CheckRouteConflicts(task adcclient.Task) ([]cache.RouteConflict, error) {
+	conflicts, _, err := c.store.CheckRouteConflicts("gp", task.Resources.Services, task.Labels)
+	return conflicts, err
+}
+
+// followerChecker is the checker of a replica that is not the leader.
+type followerChecker struct{}
+
+func (followerChecker) CheckRouteConflicts(adcclient.Task) ([]cache.RouteConflict, error) {
+	return nil, adcclient.ErrRoutesNotHeld
+}
+
+// withRouteConflicts checks routes against a store holding a route of Ingress
+// default/team-a for any host on /demo.
+func withRouteConflicts(t *testing.T, mode config.RouteConflictMode) {
+	t.Helper()
+	store := cache.NewStore(logr.Discard())
+	labels := map[string]string{label.LabelKind: "Ingress", label.LabelNamespace: "default", label.LabelName: "team-a"}
+	require.NoError(t, store.Insert("gp", []string{adctypes.TypeService}, &adctypes.Resources{
+		Services: []*adctypes.Service{{
+			Metadata: adctypes.Metadata{ID: "team-a", Labels: labels},
+			Routes: []*adctypes.Route{{
+				Metadata: adctypes.Metadata{ID: "existing", Labels: labels},
+				Uris:     []string{"/demo"},
+			}},
+		}},
+	}, labels))
+
+	prevWebhook, prevChecker := config.ControllerConfig.Webhook, routeConflictChecker
+	config.ControllerConfig.Webhook = &config.WebhookConfig{RouteConflicts: mode}
+	SetRouteConflictChecker(&storeChecker{store: store})
+	t.Cleanup(func() {
+		config.ControllerConfig.Webhook = prevWebhook
+		SetRouteConflictChecker(prevChecker)
+	})
+}
+
+func demoBackendObjects(serverURL string) []runtime.Object {
+	return append(managedIngressClassWithGatewayProxy(serverURL),
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				ClusterIP: "10.0.0.1",
+				Ports:     []corev1.ServicePort{{Port: 80}},
+			},
+		},
+	)
+}
+
+func demoApisixRoute() *apisixv2.ApisixRoute {
+	return &apisixv2.ApisixRoute{
+		TypeMeta:   metav1.TypeMeta{Kind: "ApisixRoute", APIVersion: apisixv2.GroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "default"},
+		Spec: apisixv2.ApisixRouteSpec{
+			IngressClassName: "apisix",
+			HTTP: []apisixv2.ApisixRouteHTTP{{
+				Name:  "rule",
+				Match: apisixv2.ApisixRouteHTTPMatch{Paths: []string{"/demo"}},
+				Backends: []apisixv2.ApisixRouteHTTPBackend{{
+					ServiceName:        "backend",
+					ServicePort:        intstr.FromInt(80),
+					ResolveGranularity: apisixv2.ResolveGranularityService,
+				}},
+			}},
+		},
+	}
+}
+
+func TestApisixRouteValidator_WarnsAboutRouteConflicts(t *testing.T) {
+	serverURL := withMockADCServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"success":true}`))
+	})
+	withRouteConflicts(t, config.RouteConflictModeWarn)
+
+	warnings, err := buildApisixRouteValidator(t, demoBackendObjects(serverURL)...).ValidateCreate(context.Background(), demoApisixRoute())
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	require.Contains(t, warnings[0], "Route conflict: gp route")
+	require.Contains(t, warnings[0], "Ingress/default/team-a")
+	require.Contains(t, warnings[0], "ApisixRoute/default/demo")
+	require.Contains(t, warnings[0], "set a priority to choose")
+}
+
+func TestApisixRouteValidator_DeniesRouteConflicts(t *testing.T) {
+	serverURL := withMockADCServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"success":true}`))
+	})
+	withRouteConflicts(t, config.RouteConflictModeDeny)
+
+	_, err := buildApisixRouteValidator(t, demoBackendObjects(serverURL)...).ValidateCreate(context.Background(), demoApisixRoute())
+	require.ErrorContains(t, err, "route conflicts detected")
+
+	route := demoApisixRoute()
+	route.Spec.HTTP[0].Match.Paths = []string{"/other"}
+	_, err = buildApisixRouteValidator(t, demoBackendObjects(serverURL)...).ValidateCreate(context.Background(), route)
+	require.NoError(t, err)
+}
+
+func TestApisixRouteValidator_WarnsWhenRoutesAreNotHeld(t *testing.T) {
+	serverURL := withMockADCServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"success":true}`))
+	})
+	withRouteConflicts(t, config.RouteConflictModeDeny)
+	SetRouteConflictChecker(followerChecker{})
+
+	warnings, err := buildApisixRouteValidator(t, demoBackendObjects(serverURL)...).ValidateCreate(context.Background(), demoApisixRoute())
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	require.Contains(t, warnings[0], "Route conflicts were not checked")
+}
+
+func TestIngressValidator_ChecksTranslatedRoutesForConflicts(t *testing.T) {
+	withRouteConflicts(t, config.RouteConflictModeDeny)
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, apisixv1alpha1.AddToScheme(scheme))
+	require.NoError(t, apisixv2.AddToScheme(scheme))
+	kubeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&networkingv1.IngressClass{}, indexer.IngressClass, indexer.IngressClassIndexFunc).
+		WithIndex(&apisixv1alpha1.HTTPRoutePolicy{}, indexer.PolicyTargetRefs, indexer.HTTPRoutePolicyIndexFunc).
+		WithIndex(&apisixv1alpha1.BackendTrafficPolicy{}, indexer.PolicyTargetRefs, indexer.BackendTrafficPolicyIndexFunc).
+		WithRuntimeObjects(demoBackendObjects("http://127.0.0.1:9180")...).
+		Build()
+	validator := NewIngressCustomValidator(kubeClient)
+
+	ingress := &networkingv1.Ingress{
+		TypeMeta:   metav1.TypeMeta{Kind: "Ingress", APIVersion: "networking.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "team-b", Namespace: "default"},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: ptr.To("apisix"),
+			Rules: []networkingv1.IngressRule{{
+				IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{
+						Path:     "/demo",
+						PathType: ptr.To(networkingv1.PathTypeExact),
+						Backend: networkingv1.IngressBackend{
+							Service: &networkingv1.IngressServiceBackend{
+								Name: "backend",
+								Port: networkingv1.ServiceBackendPort{Number: 80},
+							},
+						},
+					}},
+				}},
+			}},
+		},
+	}
+	_, err := validator.ValidateCreate(context.Background(), ingress)
+	require.ErrorContains(t, err, "Ingress/default/team-b")
+
+	ingress.Name = "team-a"
+	_, err = validator.ValidateCreate(context.Background(), ingress)
+	require.NoError(t, err, "an Ingress does not conflict with its own routes")
+}
@@ -30,7 +30,7 @@ var (
 			Help:    "Time spent on ADC sync operations",
 			Buckets: prometheus.DefBuckets,
 		},
-		[]string{"config_name", "resource_type", "status"},
+		[]string{"config_name", "mode", "resource_type", "status"},
 	)
 
 	// ADC sync operation counter
@@ -39,7 +39,7 @@ var (
 			Name: "apisix_ingress_adc_sync_total",
 			Help: "Total number of ADC sync operations",
 		},
-		[]string{"config_name", "resource_type", "status"},
+		[]string{"config_name", "mode", "resource_type", "status"},
 	)
 
 	// ADC execution errors counter
@@ -48,7 +48,7 @@ var (
 			Name: "apisix_ingress_adc_execution_errors_total",
 			Help: "Total number of ADC execution errors",
 		},
-		[]string{"config_name", "error_type"},
+		[]string{"config_name", "mode", "error_type"},
 	)
 
 	// Status update channel queue length gauge
@@ -59,6 +59,78 @@ var (
 		},
 	)
 
+	// Objects waiting in the provider's update queue, once per config they are waiting for
+	SyncQueueDepth = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "apisix_ingress_sync_queue_depth",
+			Help: "Number of updates of objects waiting for their configs to be pushed",
+		},
+	)
+
+	// Updates merged into one already waiting for the same object and config
+	SyncQueueCoalesced = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "apisix_ingress_sync_queue_coalesced_total",
+			Help: "Total number of updates merged into an update already waiting for the same object and config",
+		},
+	)
+
+	// Time from the first update of a batch until the batch was pushed
+	SyncQueueFlushDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "apisix_ingress_sync_queue_flush_duration_seconds",
+			Help:    "Time from the first update of a batch until the batch was pushed",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"config_name", "status"},
+	)
+
+	// Resources the drift detector found out of sync on the data plane
+	DriftResources = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "apisix_ingress_drift_resources",
+			Help: "Number of resources the data plane holds differently from the controller, as last checked",
+		},
+		[]string{"config_name", "server", "kind", "drift"},
+	)
+
+	// Resources held out of every push since the data plane rejected them
+	QuarantinedResources = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "apisix_ingress_quarantined_resources",
+			Help: "Number of resources held out of every push until they change, since the data plane rejected them",
+		},
+		[]string{"kind"},
+	)
+
+	// The mode and executor each config is synced with, for per-config series to be joined with
+	ConfigInfo = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "apisix_ingress_config_info",
+			Help: "Always 1, labelled with the mode and executor each config is synced with",
+		},
+		[]string{"config_name", "mode", "executor"},
+	)
+
+	// Time each provider of the composite provider spent on what it was forwarded
+	ProviderOperationDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "apisix_ingress_provider_operation_duration_seconds",
+			Help:    "Time each provider of the composite provider spent on an update or delete",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"provider", "operation", "status"},
+	)
+
+	// Operations the composite provider forwarded to each of its providers
+	ProviderOperationTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "apisix_ingress_provider_operation_total",
+			Help: "Total number of updates and deletes the composite provider forwarded to each of its providers",
+		},
+		[]string{"provider", "operation", "status"},
+	)
+
 	// File I/O operation duration histogram
 	FileIODuration = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -78,19 +150,27 @@ func init() {
 		ADCSyncTotal,
 		ADCExecutionErrors,
 		StatusUpdateQueueLength,
+		SyncQueueDepth,
+		SyncQueueCoalesced,
+		SyncQueueFlushDuration,
+		DriftResources,
+		QuarantinedResources,
+		ConfigInfo,
+		ProviderOperationDuration,
+		ProviderOperationTotal,
 		FileIODuration,
 	)
 }
 
-// RecordSyncDuration records the duration of an ADC sync operation
-func RecordSyncDuration(configName, resourceType, status string, duration float64) {
-	ADCSyncDuration.WithLabelValues(configName, resourceType, status).Observe(duration)
-	ADCSyncTotal.WithLabelValues(configName, resourceType, status).Inc()
+// RecordSyncDuration records the duration of an ADC sync operation of a config synced in mode
+func RecordSyncDuration(configName, mode, resourceType, status string, duration float64) {
+	ADCSyncDuration.WithLabelValues(configName, mode, resourceType, status).Observe(duration)
+	ADCSyncTotal.WithLabelValues(configName, mode, resourceType, status).Inc()
 }
 
-// RecordExecutionError records an ADC execution error
-func RecordExecutionError(configName, errorType string) {
-	ADCExecutionErrors.WithLabelValues(configName, errorType).Inc()
+// RecordExecutionError records an ADC execution error of a config synced in mode
+func RecordExecutionError(configName, mode, errorType string) {
+	ADCExecutionErrors.WithLabelValues(configName, mode, errorType).Inc()
 }
 
 // UpdateStatusQueueLength updates the status update queue length gauge
@@ -108,7 +188,60 @@ func DecStatusQueueLength() {
 	StatusUpdateQueueLength.Dec()
 }
 
+// SetConfigInfo records the mode and executor a config is synced with
+func SetConfigInfo(configName, mode, executor string) {
+	ConfigInfo.DeletePartialMatch(prometheus.Labels{"config_name": configName})
+	ConfigInfo.WithLabelValues(configName, mode, executor).Set(1)
+}
+
+// DeleteConfigInfo drops the info series of a config that is no longer synced
+func DeleteConfigInfo(configName string) {
+	ConfigInfo.DeletePartialMatch(prometheus.Labels{"config_name": configName})
+}
+
+// RecordProviderOperation records an operation the composite provider forwarded to one
+// of its providers
+func RecordProviderOperation(provider, operation, status string, duration float64) {
+	ProviderOperationDuration.WithLabelValues(provider, operation, status).Observe(duration)
+	ProviderOperationTotal.WithLabelValues(provider, operation, status).Inc()
+}
+
 // RecordFileIODuration records the duration of a file I/O operation
 func RecordFileIODuration(operation, status string, duration float64) {
 	FileIODuration.WithLabelValues(operation, status).Observe(duration)
 }
+
+// UpdateSyncQueueDepth updates the sync queue depth gauge
+func UpdateSyncQueueDepth(depth float64) {
+	SyncQueueDepth.Set(depth)
+}
+
+// IncSyncQueueCoalesced counts an update merged into one already queued
+func IncSyncQueueCoalesced() {
+	SyncQueueCoalesced.Inc()
+}
+
+// RecordSyncQueueFlushDuration records how long a batch of updates of a config took to be pushed
+func RecordSyncQueueFlushDuration(configName, status string, duration float64) {
+	SyncQueueFlushDuration.WithLabelValues(configName, status).Observe(duration)
+}
+
+// ResetDriftResources forgets the drift found by the previous check
+func ResetDriftResources() {
+	DriftResources.Reset()
+}
+
+// SetDriftResources records how many resources of a kind drifted one way on a server
+func SetDriftResources(configName, server, kind, drift string, count float64) {
+	DriftResources.WithLabelValues(configName, server, kind, drift).Set(count)
+}
+
+// ResetQuarantinedResources forgets every quarantined resource count
+func ResetQuarantinedResources() {
+	QuarantinedResources.Reset()
+}
+
+// SetQuarantinedResources records how many resources of a kind are quarantined
+func SetQuarantinedResources(kind string, count float64) {
+	QuarantinedResources.WithLabelValues(kind).Set(count)
+}
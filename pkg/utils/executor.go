@@ -21,15 +21,31 @@ import "sync"
 
 type ParallelExecutor struct {
 	wg sync.WaitGroup
+	// workers bounds how many handlers run at once; the zero value runs them all.
+	workers chan struct{}
 
 	errorsLock sync.Mutex
 	errors     []error
 }
 
+// NewParallelExecutor returns an executor that runs at most workers handlers at once.
+func NewParallelExecutor(workers int) *ParallelExecutor {
+	return &ParallelExecutor{workers: make(chan struct{}, max(workers, 1))}
+}
+
+func (exec *ParallelExecutor) acquire() func() {
+	if exec.workers == nil {
+		return func() {}
+	}
+	exec.workers <- struct{}{}
+	return func() { <-exec.workers }
+}
+
 func (exec *ParallelExecutor) Add(handler func()) {
 	exec.wg.Add(1)
 	go func() {
 		defer exec.wg.Done()
+		defer exec.acquire()()
 		handler()
 	}()
 }
@@ -38,6 +54,7 @@ func (exec *ParallelExecutor) AddE(handler func() error) {
 	exec.wg.Add(1)
 	go func() {
 		defer exec.wg.Done()
+		defer exec.acquire()()
 		err := handler()
 		if err != nil {
 			exec.errorsLock.Lock()
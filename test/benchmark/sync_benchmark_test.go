@@ -0,0 +1,149 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	adctypes "github.com/apache/apisix-ingress-controller/api/adc"
+	adcclient "github.com/apache/apisix-ingress-controller/internal/adc/client"
+	"github.com/apache/apisix-ingress-controller/internal/controller/config"
+	"github.com/apache/apisix-ingress-controller/internal/controller/label"
+	"github.com/apache/apisix-ingress-controller/internal/types"
+)
+
+// The benchmarks below compare a periodic full sync with a sync of changes alone, for a
+// GatewayProxy holding more and more HTTPRoutes of which one changes between syncs. They
+// need no cluster: the ADC server is a fake that only counts what it is sent.
+//
+//	go test ./test/benchmark -run '^$' -bench Sync
+//
+// push-bytes/op is the size of the request bodies sent to the ADC server per sync, and
+// ns/op the latency of the sync as seen by the controller.
+
+var benchmarkGatewayProxy = types.NamespacedNameKind{Kind: "GatewayProxy", Namespace: "default", Name: "gp"}
+
+type countingADCServer struct {
+	*httptest.Server
+	requests atomic.Int64
+	bytes    atomic.Int64
+}
+
+func newCountingADCServer(b *testing.B) *countingADCServer {
+	s := &countingADCServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n, _ := io.Copy(io.Discard, r.Body)
+		s.requests.Add(1)
+		s.bytes.Add(n)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	b.Cleanup(s.Close)
+	return s
+}
+
+func newBenchmarkClient(b *testing.B, routes int) (*adcclient.Client, *countingADCServer) {
+	server := newCountingADCServer(b)
+	b.Setenv("ADC_SERVER_URL", server.URL)
+	c, err := adcclient.New(logr.Discard(), "apisix-standalone", string(config.ProviderExecutorADC), 10*time.Second)
+	if err != nil {
+		b.Fatal(err)
+	}
+	c.ConfigManager.UpdateConfig(benchmarkGatewayProxy, adctypes.Config{
+		Name:        "GatewayProxy/default/gp",
+		ServerAddrs: []string{"http://apisix:9180"},
+		BackendType: "apisix-standalone",
+	})
+	for i := range routes {
+		updateBenchmarkRoute(b, c, fmt.Sprintf("route-%d", i), 0)
+	}
+	return c, server
+}
+
+func updateBenchmarkRoute(b *testing.B, c *adcclient.Client, name string, revision int) {
+	labels := map[string]string{
+		label.LabelKind:      "HTTPRoute",
+		label.LabelNamespace: "default",
+		label.LabelName:      name,
+	}
+	if err := c.UpdateConfig(context.Background(), adcclient.Task{
+		Key:           types.NamespacedNameKind{Kind: "HTTPRoute", Namespace: "default", Name: name},
+		Labels:        labels,
+		ResourceTypes: []string{adctypes.TypeService},
+		Configs:       c.ConfigManager.List(),
+		Resources: &adctypes.Resources{Services: []*adctypes.Service{{
+			Metadata: adctypes.Metadata{ID: name, Name: name, Labels: labels},
+			Hosts:    []string{name + ".example.com"},
+			Routes: []*adctypes.Route{{
+				Metadata: adctypes.Metadata{ID: name, Name: name, Labels: labels},
+				Uris:     []string{fmt.Sprintf("/%s/v%d", name, revision)},
+			}},
+			Upstream: &adctypes.Upstream{
+				Nodes: adctypes.UpstreamNodes{{Host: "10.0.0.1", Port: 80, Weight: 100}},
+			},
+		}}},
+	}); err != nil {
+		b.Fatal(err)
+	}
+}
+
+func benchmarkSync(b *testing.B, routes int, sync func(context.Context) (map[string]types.ADCExecutionErrors, error), c *adcclient.Client, server *countingADCServer) {
+	ctx := context.Background()
+	// Settle the first push, which is a whole one either way.
+	if _, err := c.Sync(ctx); err != nil {
+		b.Fatal(err)
+	}
+	server.requests.Store(0)
+	server.bytes.Store(0)
+
+	for i := 0; b.Loop(); i++ {
+		b.StopTimer()
+		updateBenchmarkRoute(b, c, fmt.Sprintf("route-%d", i%routes), i+1)
+		b.StartTimer()
+		if _, err := sync(ctx); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(server.bytes.Load())/float64(b.N), "push-bytes/op")
+	b.ReportMetric(float64(server.requests.Load())/float64(b.N), "requests/op")
+}
+
+func BenchmarkSync(b *testing.B) {
+	for _, routes := range []int{100, 1000, 5000} {
+		b.Run(fmt.Sprintf("full/routes=%d", routes), func(b *testing.B) {
+			c, server := newBenchmarkClient(b, routes)
+			benchmarkSync(b, routes, c.Sync, c, server)
+		})
+		b.Run(fmt.Sprintf("changes/routes=%d", routes), func(b *testing.B) {
+			c, server := newBenchmarkClient(b, routes)
+			syncChanges := func(ctx context.Context) (map[string]types.ADCExecutionErrors, error) {
+				return c.SyncChanges(ctx)
+			}
+			benchmarkSync(b, routes, syncChanges, c, server)
+		})
+	}
+}